@@ -0,0 +1,234 @@
+package blocks
+
+// Block is a layout block in a Slack message.
+type Block interface {
+	BlockType() string
+}
+
+// Element is an interactive element that can be placed in an actions block
+// or as the accessory of a section block.
+type Element interface {
+	ElementType() string
+}
+
+// Message is a Slack message made of layout blocks. Text is shown in
+// notifications and by clients that can't display blocks.
+type Message struct {
+	Text   string  `json:"text"`
+	Blocks []Block `json:"blocks,omitempty"`
+}
+
+// NewMessage returns a message with the given fallback text and blocks.
+func NewMessage(text string, blocks ...Block) *Message {
+	return &Message{Text: text, Blocks: blocks}
+}
+
+// Add appends the given blocks to the message and returns the message.
+func (m *Message) Add(blocks ...Block) *Message {
+	m.Blocks = append(m.Blocks, blocks...)
+	return m
+}
+
+// Text is a text object. Markdown text supports Slack's mrkdwn formatting.
+type Text struct {
+	Type string `json:"type"`
+	Text string `json:"text"`
+}
+
+// Markdown returns a text object formatted with Slack's mrkdwn.
+func Markdown(text string) *Text {
+	return &Text{Type: "mrkdwn", Text: text}
+}
+
+// PlainText returns a plain text object.
+func PlainText(text string) *Text {
+	return &Text{Type: "plain_text", Text: text}
+}
+
+// SectionBlock displays text, optionally with fields shown in two columns
+// and an accessory element beside the text.
+type SectionBlock struct {
+	Type      string  `json:"type"`
+	BlockID   string  `json:"block_id,omitempty"`
+	Text      *Text   `json:"text,omitempty"`
+	Fields    []*Text `json:"fields,omitempty"`
+	Accessory Element `json:"accessory,omitempty"`
+}
+
+// BlockType implements Block.
+func (*SectionBlock) BlockType() string { return "section" }
+
+// Section returns a section block displaying the given markdown text.
+func Section(text string) *SectionBlock {
+	return &SectionBlock{Type: "section", Text: Markdown(text)}
+}
+
+// Fields returns a section block displaying each of the given markdown
+// strings as a field.
+func Fields(fields ...string) *SectionBlock {
+	s := &SectionBlock{Type: "section"}
+	for _, f := range fields {
+		s.Fields = append(s.Fields, Markdown(f))
+	}
+	return s
+}
+
+// WithAccessory sets the element displayed beside the section's text and
+// returns the section.
+func (s *SectionBlock) WithAccessory(e Element) *SectionBlock {
+	s.Accessory = e
+	return s
+}
+
+// ActionsBlock holds interactive elements.
+type ActionsBlock struct {
+	Type     string    `json:"type"`
+	BlockID  string    `json:"block_id,omitempty"`
+	Elements []Element `json:"elements"`
+}
+
+// BlockType implements Block.
+func (*ActionsBlock) BlockType() string { return "actions" }
+
+// Actions returns an actions block holding the given elements. The block ID
+// is passed to action handlers along with the ID of the element that was
+// used, so it can be used to say what the actions apply to.
+func Actions(blockID string, elements ...Element) *ActionsBlock {
+	return &ActionsBlock{Type: "actions", BlockID: blockID, Elements: elements}
+}
+
+// ContextBlock displays small, secondary text.
+type ContextBlock struct {
+	Type     string  `json:"type"`
+	Elements []*Text `json:"elements"`
+}
+
+// BlockType implements Block.
+func (*ContextBlock) BlockType() string { return "context" }
+
+// Context returns a context block displaying the given markdown text.
+func Context(text string) *ContextBlock {
+	return &ContextBlock{Type: "context", Elements: []*Text{Markdown(text)}}
+}
+
+// DividerBlock separates blocks with a horizontal line.
+type DividerBlock struct {
+	Type string `json:"type"`
+}
+
+// BlockType implements Block.
+func (*DividerBlock) BlockType() string { return "divider" }
+
+// Divider returns a divider block.
+func Divider() *DividerBlock {
+	return &DividerBlock{Type: "divider"}
+}
+
+// Button styles
+const (
+	// Primary buttons are green and should be used for the main action in a
+	// message
+	Primary = "primary"
+	// Danger buttons are red and should be used for destructive actions
+	Danger = "danger"
+)
+
+// ButtonElement is a button. When it is clicked, Rocket calls the action
+// handler registered for the button's action ID with the button's value.
+type ButtonElement struct {
+	Type     string         `json:"type"`
+	Text     *Text          `json:"text"`
+	ActionID string         `json:"action_id"`
+	Value    string         `json:"value,omitempty"`
+	Style    string         `json:"style,omitempty"`
+	Confirm  *ConfirmDialog `json:"confirm,omitempty"`
+}
+
+// ElementType implements Element.
+func (*ButtonElement) ElementType() string { return "button" }
+
+// Button returns a button with the given action ID, label and value.
+func Button(actionID, text, value string) *ButtonElement {
+	return &ButtonElement{
+		Type:     "button",
+		Text:     PlainText(text),
+		ActionID: actionID,
+		Value:    value,
+	}
+}
+
+// WithStyle sets the button's style to Primary or Danger and returns the
+// button.
+func (b *ButtonElement) WithStyle(style string) *ButtonElement {
+	b.Style = style
+	return b
+}
+
+// WithConfirm makes Slack ask the user to confirm before the button's action
+// is sent, and returns the button.
+func (b *ButtonElement) WithConfirm(c *ConfirmDialog) *ButtonElement {
+	b.Confirm = c
+	return b
+}
+
+// SelectElement is a menu. When an option is chosen, Rocket calls the action
+// handler registered for the menu's action ID with the chosen value.
+type SelectElement struct {
+	Type        string        `json:"type"`
+	Placeholder *Text         `json:"placeholder"`
+	ActionID    string        `json:"action_id"`
+	Options     []*OptionItem `json:"options,omitempty"`
+}
+
+// ElementType implements Element.
+func (s *SelectElement) ElementType() string { return s.Type }
+
+// Select returns a menu of the given options.
+func Select(actionID, placeholder string, options ...*OptionItem) *SelectElement {
+	return &SelectElement{
+		Type:        "static_select",
+		Placeholder: PlainText(placeholder),
+		ActionID:    actionID,
+		Options:     options,
+	}
+}
+
+// SelectUser returns a menu of the users in the Slack workspace. The chosen
+// user's Slack ID is passed to the action handler as the action's value.
+func SelectUser(actionID, placeholder string) *SelectElement {
+	return &SelectElement{
+		Type:        "users_select",
+		Placeholder: PlainText(placeholder),
+		ActionID:    actionID,
+	}
+}
+
+// OptionItem is a choice in a menu.
+type OptionItem struct {
+	Text  *Text  `json:"text"`
+	Value string `json:"value"`
+}
+
+// Option returns a menu choice with the given label and value.
+func Option(text, value string) *OptionItem {
+	return &OptionItem{Text: PlainText(text), Value: value}
+}
+
+// ConfirmDialog asks the user to confirm an action before it is sent.
+type ConfirmDialog struct {
+	Title   *Text `json:"title"`
+	Text    *Text `json:"text"`
+	Confirm *Text `json:"confirm"`
+	Deny    *Text `json:"deny"`
+}
+
+// Confirm returns a confirmation dialog with the given title, markdown text
+// and confirm button label.
+func Confirm(title, text, confirm string) *ConfirmDialog {
+	return &ConfirmDialog{
+		Title:   PlainText(title),
+		Text:    Markdown(text),
+		Confirm: PlainText(confirm),
+		Deny:    PlainText("Cancel"),
+	}
+}
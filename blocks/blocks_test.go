@@ -0,0 +1,64 @@
+package blocks
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMessageJSON(t *testing.T) {
+	msg := NewMessage("Team Rocket", Section("*Rocket*")).Add(
+		Fields("*Platform*\nGo"),
+		Divider(),
+		Actions("Rocket",
+			Button("team-join", "Join", "Rocket").WithStyle(Primary),
+			SelectUser("team-add-member", "Add member"),
+			Button("team-remove", "Remove", "Rocket").
+				WithStyle(Danger).
+				WithConfirm(Confirm("Remove team", "Are you sure?", "Remove")),
+		),
+		Context("GitHub Team Name: rocket"),
+	)
+
+	data, err := json.Marshal(msg)
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"text": "Team Rocket",
+		"blocks": [
+			{"type": "section", "text": {"type": "mrkdwn", "text": "*Rocket*"}},
+			{"type": "section", "fields": [{"type": "mrkdwn", "text": "*Platform*\nGo"}]},
+			{"type": "divider"},
+			{"type": "actions", "block_id": "Rocket", "elements": [
+				{"type": "button", "text": {"type": "plain_text", "text": "Join"},
+				 "action_id": "team-join", "value": "Rocket", "style": "primary"},
+				{"type": "users_select", "placeholder": {"type": "plain_text", "text": "Add member"},
+				 "action_id": "team-add-member"},
+				{"type": "button", "text": {"type": "plain_text", "text": "Remove"},
+				 "action_id": "team-remove", "value": "Rocket", "style": "danger",
+				 "confirm": {
+					"title": {"type": "plain_text", "text": "Remove team"},
+					"text": {"type": "mrkdwn", "text": "Are you sure?"},
+					"confirm": {"type": "plain_text", "text": "Remove"},
+					"deny": {"type": "plain_text", "text": "Cancel"}
+				 }}
+			]},
+			{"type": "context", "elements": [{"type": "mrkdwn", "text": "GitHub Team Name: rocket"}]}
+		]
+	}`, string(data))
+}
+
+func TestSelectJSON(t *testing.T) {
+	data, err := json.Marshal(Select("platform", "Choose a platform",
+		Option("Web", "web"), Option("Mobile", "mobile")))
+	assert.Nil(t, err)
+	assert.JSONEq(t, `{
+		"type": "static_select",
+		"placeholder": {"type": "plain_text", "text": "Choose a platform"},
+		"action_id": "platform",
+		"options": [
+			{"text": {"type": "plain_text", "text": "Web"}, "value": "web"},
+			{"text": {"type": "plain_text", "text": "Mobile"}, "value": "mobile"}
+		]
+	}`, string(data))
+}
@@ -0,0 +1,4 @@
+// Package blocks builds Slack messages out of Block Kit layout blocks,
+// including buttons and menus that users can interact with.
+// See https://api.slack.com/block-kit
+package blocks
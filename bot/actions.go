@@ -0,0 +1,184 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"net/url"
+
+	"github.com/nlopes/slack"
+	"github.com/ubclaunchpad/rocket/blocks"
+	"github.com/ubclaunchpad/rocket/cmd"
+)
+
+// Action is a click on a button or a choice from a menu in a message made
+// of Block Kit blocks.
+type Action struct {
+	// ID is the action ID of the element that was used
+	ID string
+	// BlockID is the ID of the block containing the element
+	BlockID string
+	// Value is the value of the button that was clicked, or the value of the
+	// option that was chosen from a menu
+	Value string
+	// ResponseURL can be used to replace the message containing the element
+	ResponseURL string
+}
+
+// ActionHandler handles an action taken by the user in the given context.
+// Like a command handler, it returns a response message to post in the
+// channel the action was taken in, or an empty string to post nothing.
+type ActionHandler func(cmd.Context, Action) (string, slack.PostMessageParameters)
+
+// interactionEvent is the type of the events the bot dispatches when a user
+// interacts with a message
+const interactionEvent = "block_actions"
+
+// interaction is a request Slack sends when a user interacts with a message.
+// See https://api.slack.com/reference/interaction-payloads/block-actions
+type interaction struct {
+	Type string `json:"type"`
+	User struct {
+		ID string `json:"id"`
+	} `json:"user"`
+	Channel struct {
+		ID string `json:"id"`
+	} `json:"channel"`
+	Message struct {
+		Timestamp string `json:"ts"`
+	} `json:"message"`
+	ResponseURL string `json:"response_url"`
+	Actions     []struct {
+		ActionID       string `json:"action_id"`
+		BlockID        string `json:"block_id"`
+		Value          string `json:"value"`
+		SelectedUser   string `json:"selected_user"`
+		SelectedOption struct {
+			Value string `json:"value"`
+		} `json:"selected_option"`
+	} `json:"actions"`
+}
+
+// RegisterActionHandlers registers handlers for actions with the given action
+// IDs. Returns an error if a handler is already registered for an action ID.
+func (b *Bot) RegisterActionHandlers(handlers map[string]ActionHandler) error {
+	for id, handler := range handlers {
+		if b.actions[id] != nil {
+			return fmt.Errorf("Duplicate action handler %s", id)
+		}
+		b.actions[id] = handler
+	}
+	return nil
+}
+
+// InteractionsHandler returns an HTTP handler for requests Slack sends when a
+// user clicks a button or chooses an option from a menu. Each request's
+// signature is verified against the bot's signing secret, and its actions
+// are passed to the registered action handlers. Interactions are received
+// over HTTP regardless of the bot's transport.
+func (b *Bot) InteractionsHandler() http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		body, err := b.verifyRequest(res, req)
+		if err != nil {
+			b.Log.WithError(err).Warn("Rejected Slack interaction request")
+			res.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			b.Log.WithError(err).Error("Failed to decode Slack interaction")
+			res.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		var i interaction
+		if err := json.Unmarshal([]byte(form.Get("payload")), &i); err != nil {
+			b.Log.WithError(err).Error("Failed to decode Slack interaction")
+			res.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		// Slack expects a response within 3 seconds, so handle the actions
+		// after acknowledging the request
+		if i.Type == interactionEvent && !b.enqueue(slack.RTMEvent{Type: interactionEvent, Data: &i}) {
+			res.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		res.WriteHeader(http.StatusOK)
+	})
+}
+
+// handleInteraction calls the registered action handler for each of the
+// interaction's actions in the context of the user who took them, and posts
+// the handlers' responses in the channel the actions were taken in.
+func (b *Bot) handleInteraction(ctx context.Context, evt slack.RTMEvent) {
+	i := evt.Data.(*interaction)
+	member, err := b.getMember(i.User.ID)
+	if err != nil {
+		b.Log.WithError(err).Errorf("Error getting member by Slack ID %s", i.User.ID)
+		b.API.PostMessage(i.Channel.ID, errorMessage, noParams)
+		return
+	}
+	context := cmd.Context{
+		Context: ctx,
+		Message: &slack.Msg{
+			Channel:   i.Channel.ID,
+			User:      i.User.ID,
+			Timestamp: i.Message.Timestamp,
+		},
+		User:        member,
+		ResolveTeam: b.getTeam,
+	}
+
+	for _, action := range i.actions() {
+		res, params := b.handleAction(context, action)
+		if res != "" || len(params.Attachments) > 0 {
+			b.API.PostMessage(i.Channel.ID, res, params)
+		}
+	}
+}
+
+// handleAction calls the action handler registered for the given action.
+func (b *Bot) handleAction(ctx cmd.Context, action Action) (string, slack.PostMessageParameters) {
+	handler := b.actions[action.ID]
+	if handler == nil {
+		b.Log.Warnf("No handler registered for action %s", action.ID)
+		return "", noParams
+	}
+	return handler(ctx, action)
+}
+
+// actions returns the actions taken in this interaction.
+func (i interaction) actions() []Action {
+	actions := []Action{}
+	for _, a := range i.Actions {
+		value := a.Value
+		if a.SelectedUser != "" {
+			value = a.SelectedUser
+		} else if a.SelectedOption.Value != "" {
+			value = a.SelectedOption.Value
+		}
+		actions = append(actions, Action{
+			ID:          a.ActionID,
+			BlockID:     a.BlockID,
+			Value:       value,
+			ResponseURL: i.ResponseURL,
+		})
+	}
+	return actions
+}
+
+// handleCancel replaces the message containing a "Cancel" button with a
+// message saying it was cancelled. If the button cancels a destructive
+// command, only the user who sent the command may cancel it.
+func (b *Bot) handleCancel(ctx cmd.Context, action Action) (string, slack.PostMessageParameters) {
+	if action.Value != "" && b.confirmations.take(ctx, action.Value) == nil {
+		return "There is no command waiting for you to cancel. " +
+			"It may have expired, or been confirmed.", noParams
+	}
+	if err := b.ReplaceMessage(ctx, action, blocks.NewMessage("Cancelled")); err != nil {
+		b.Log.WithError(err).Error("Failed to replace cancelled message")
+	}
+	return "", noParams
+}
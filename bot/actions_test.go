@@ -0,0 +1,153 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/nlopes/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/ubclaunchpad/rocket/blocks"
+	"github.com/ubclaunchpad/rocket/cmd"
+)
+
+func TestInteractionActions(t *testing.T) {
+	var i interaction
+	err := json.Unmarshal([]byte(`{
+		"type": "block_actions",
+		"user": {"id": "U2147483697"},
+		"channel": {"id": "C2147483705"},
+		"message": {"ts": "1548261231.000200"},
+		"response_url": "https://hooks.slack.com/actions/T0/1/abc",
+		"actions": [
+			{"action_id": "team-join", "block_id": "Rocket", "value": "Rocket", "type": "button"},
+			{"action_id": "team-add-member", "block_id": "Rocket", "selected_user": "U5RU9TB38", "type": "users_select"},
+			{"action_id": "platform", "block_id": "b", "selected_option": {"value": "web"}, "type": "static_select"}
+		]
+	}`), &i)
+	assert.Nil(t, err)
+	assert.Equal(t, []Action{
+		{ID: "team-join", BlockID: "Rocket", Value: "Rocket", ResponseURL: "https://hooks.slack.com/actions/T0/1/abc"},
+		{ID: "team-add-member", BlockID: "Rocket", Value: "U5RU9TB38", ResponseURL: "https://hooks.slack.com/actions/T0/1/abc"},
+		{ID: "platform", BlockID: "b", Value: "web", ResponseURL: "https://hooks.slack.com/actions/T0/1/abc"},
+	}, i.actions())
+}
+
+func TestHandleAction(t *testing.T) {
+	b := NewEmptyBot()
+	err := b.RegisterActionHandlers(map[string]ActionHandler{
+		"team-join": func(ctx cmd.Context, a Action) (string, slack.PostMessageParameters) {
+			return ctx.User.SlackID + " joined " + a.Value, noParams
+		},
+	})
+	assert.Nil(t, err)
+
+	ctx := cmd.Context{}
+	ctx.User.SlackID = "U2147483697"
+	res, _ := b.handleAction(ctx, Action{ID: "team-join", Value: "Rocket"})
+	assert.Equal(t, "U2147483697 joined Rocket", res)
+
+	// Unknown actions are ignored
+	res, _ = b.handleAction(ctx, Action{ID: "launch"})
+	assert.Equal(t, "", res)
+
+	// Only one handler may be registered per action
+	err = b.RegisterActionHandlers(map[string]ActionHandler{CancelAction: nil})
+	assert.NotNil(t, err)
+}
+
+func TestInteractionsHandler(t *testing.T) {
+	b := newEventsBot()
+	slackAPI := fakeSlack{secret: testSigningSecret, now: time.Now()}
+	form := url.Values{"payload": {`{"type":"view_submission"}`}}
+
+	res := slackAPI.sendCommand(b.InteractionsHandler(), form)
+	assert.Equal(t, http.StatusOK, res.Code)
+
+	forger := fakeSlack{secret: "not the secret", now: time.Now()}
+	res = forger.sendCommand(b.InteractionsHandler(), form)
+	assert.Equal(t, http.StatusUnauthorized, res.Code)
+}
+
+func TestPostBlocks(t *testing.T) {
+	var body map[string]interface{}
+	var auth string
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		assert.Equal(t, "/chat.postMessage", req.URL.Path)
+		auth = req.Header.Get("Authorization")
+		data, _ := ioutil.ReadAll(req.Body)
+		json.Unmarshal(data, &body)
+		res.Write([]byte(`{"ok": true}`))
+	}))
+	defer srv.Close()
+
+	b := NewEmptyBot()
+	b.token = "xoxb-test"
+	b.apiURL = srv.URL + "/"
+	err := b.PostBlocks(context.Background(), "C2147483705", blocks.NewMessage("hello", blocks.Section("*hello*")))
+	assert.Nil(t, err)
+	assert.Equal(t, "Bearer xoxb-test", auth)
+	assert.Equal(t, "C2147483705", body["channel"])
+	assert.Equal(t, "hello", body["text"])
+	assert.Len(t, body["blocks"], 1)
+
+	// Messages aren't posted once the handler's context is done
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	body = nil
+	err = b.PostBlocks(ctx, "C2147483705", blocks.NewMessage("hello"))
+	assert.NotNil(t, err)
+	assert.Nil(t, body)
+}
+
+func TestReplaceMessage(t *testing.T) {
+	var body map[string]interface{}
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		data, _ := ioutil.ReadAll(req.Body)
+		json.Unmarshal(data, &body)
+		res.Write([]byte("ok"))
+	}))
+	defer srv.Close()
+
+	b := NewEmptyBot()
+	res, _ := b.handleAction(cmd.Context{Context: context.Background()},
+		Action{ID: CancelAction, ResponseURL: srv.URL})
+	assert.Equal(t, "", res)
+	assert.Equal(t, true, body["replace_original"])
+	assert.Equal(t, "Cancelled", body["text"])
+}
+
+func TestRunCommand(t *testing.T) {
+	b := NewEmptyBot()
+	var text string
+	err := b.RegisterCommands([]*cmd.Command{
+		&cmd.Command{
+			Name: "help",
+			HandleFunc: func(ctx cmd.Context) (string, slack.PostMessageParameters) {
+				text = ctx.Message.Text
+				return "help", noParams
+			},
+		},
+	})
+	assert.Nil(t, err)
+
+	ctx := cmd.Context{Message: &slack.Msg{Channel: "C2147483705"}}
+	res, _, err := b.RunCommand(ctx, " help ")
+	assert.Nil(t, err)
+	assert.Equal(t, "help", res)
+	assert.Equal(t, "<@U5RU9TB38> help", text)
+
+	// No command means help
+	res, _, err = b.RunCommand(ctx, "")
+	assert.Nil(t, err)
+	assert.Equal(t, "help", res)
+
+	_, _, err = b.RunCommand(ctx, "hlp")
+	assert.NotNil(t, err)
+	assert.Equal(t, "`hlp` is not a Rocket command. Did you mean `help`?", err.Error())
+}
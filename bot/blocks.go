@@ -0,0 +1,85 @@
+package bot
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"time"
+
+	"github.com/ubclaunchpad/rocket/blocks"
+)
+
+const (
+	// slackAPIURL is the base URL of Slack's Web API
+	slackAPIURL = "https://slack.com/api/"
+
+	// slackRequestTimeout is how long Slack has to respond to a message
+	// posted to its Web API or a response URL
+	slackRequestTimeout = 10 * time.Second
+
+	// CancelAction is the action ID of a button that cancels the action
+	// offered by the message it is in. The message is replaced when the
+	// button is clicked.
+	CancelAction = "cancel"
+)
+
+// slackClient is the client messages are posted to Slack with.
+var slackClient = &http.Client{Timeout: slackRequestTimeout}
+
+// PostBlocks posts a message made of Block Kit blocks in the given channel.
+func (b *Bot) PostBlocks(ctx context.Context, channel string, msg *blocks.Message) error {
+	body := struct {
+		Channel string `json:"channel"`
+		*blocks.Message
+	}{channel, msg}
+	return b.postJSON(ctx, b.apiURL+"chat.postMessage", body)
+}
+
+// ReplaceMessage replaces the message containing the element used to take
+// the given action with the given message, e.g. to stop the action from
+// being taken again.
+func (b *Bot) ReplaceMessage(ctx context.Context, action Action, msg *blocks.Message) error {
+	body := struct {
+		ReplaceOriginal bool `json:"replace_original"`
+		*blocks.Message
+	}{true, msg}
+	return b.postJSON(ctx, action.ResponseURL, body)
+}
+
+// postJSON posts the given body to Slack encoded as JSON, and returns an
+// error if the request fails or Slack reports an error. The request is
+// cancelled with the given context.
+func (b *Bot) postJSON(ctx context.Context, url string, body interface{}) error {
+	data, err := json.Marshal(body)
+	if err != nil {
+		return err
+	}
+	req, err := http.NewRequestWithContext(ctx, "POST", url, bytes.NewReader(data))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+	req.Header.Set("Authorization", "Bearer "+b.token)
+
+	res, err := slackClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer res.Body.Close()
+	if res.StatusCode != http.StatusOK {
+		return fmt.Errorf("Slack responded with status %s", res.Status)
+	}
+
+	// The Web API reports errors in the response body, while response URLs
+	// respond with plain text
+	var result struct {
+		OK    bool   `json:"ok"`
+		Error string `json:"error"`
+	}
+	if err := json.NewDecoder(res.Body).Decode(&result); err == nil && !result.OK {
+		return fmt.Errorf("Slack responded with error %s", result.Error)
+	}
+	return nil
+}
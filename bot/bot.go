@@ -1,8 +1,13 @@
 package bot
 
 import (
+	"context"
+	"errors"
 	"fmt"
+	"sort"
 	"strings"
+	"sync"
+	"time"
 
 	"github.com/nlopes/slack"
 	log "github.com/sirupsen/logrus"
@@ -21,63 +26,150 @@ const (
 	errorMessage = "Oops, an error occurred :robot_face:. Bruno must have " +
 		"coded a bug... Sorry about that!"
 
+	// The command recorded in the audit log for changes Rocket makes to keep
+	// members in sync with Slack
+	slackSyncCommand = "slack sync"
+
 	// GithubAllTeamID for the `all` team that everyone should be on
 	GithubAllTeamID = 2467607
+
+	// TransportRTM receives Slack events over the Real Time Messaging API.
+	// This is the default transport.
+	TransportRTM = "rtm"
+	// TransportEvents receives Slack events and slash commands over HTTP
+	// through the Events API. See EventsHandler and SlashCommandHandler.
+	TransportEvents = "events"
 )
 
 var noParams = slack.PostMessageParameters{}
 
-// EventHandler is any function that handles a Slack event
-type EventHandler func(slack.RTMEvent)
+// EventHandler is any function that handles a Slack event. The given context
+// is cancelled when the handler's time to handle the event runs out.
+type EventHandler func(context.Context, slack.RTMEvent)
 
 // Bot represents an instance of the Rocket Slack bot. Only one should be
 // created under normal circumstances.
 type Bot struct {
-	token    string
-	API      *slack.Client
-	rtm      *slack.RTM
-	DAL      *data.DAL
-	GitHub   *github.API
-	Log      *log.Entry
-	Commands map[string]*cmd.Command
-	handlers map[string][]EventHandler
-	Users    map[string]slack.User
+	token         string
+	transport     string
+	signingSecret string
+	// githubWebhookSecret is the secret GitHub signs webhook deliveries with
+	githubWebhookSecret string
+	API                 *slack.Client
+	rtm                 *slack.RTM
+	events              chan slack.RTMEvent
+	DAL                 data.Store
+	GitHub              *github.API
+	Log                 *log.Entry
+	Commands            map[string]*cmd.Command
+	Aliases             map[string]*cmd.Command
+	handlers            map[string][]EventHandler
+	actions             map[string]ActionHandler
+	confirmations       confirmations
+	apiURL              string
+	queues              []chan slack.RTMEvent
+	timeout             time.Duration
+	undoBackoff         time.Duration
+
+	// workers tracks the workers handling events, and ctx is cancelled to
+	// interrupt them if they take too long to stop
+	workers sync.WaitGroup
+	ctx     context.Context
+	cancel  context.CancelFunc
+	// stopped is set once the bot stops accepting events
+	stopMu  sync.RWMutex
+	stopped bool
+
+	// users caches Slack users, and deletedUsers holds users whose accounts
+	// have been deleted but who are still members
+	usersMu      sync.RWMutex
+	users        map[string]slack.User
+	deletedUsers map[string]slack.User
 }
 
 // New constructs and returns a new Slack bot instance. It creates a new RTM
 // object to receive incoming messages, populates a cache with users, and
 // sets up command handlers.
-func New(cfg *config.Config, dal *data.DAL, gh *github.API, log *log.Entry) *Bot {
+func New(cfg *config.Config, dal data.Store, gh *github.API, log *log.Entry) *Bot {
 	api := slack.New(cfg.SlackToken)
 
+	transport := cfg.SlackTransport
+	if transport == "" {
+		transport = TransportRTM
+	}
+
 	b := &Bot{
-		token:    cfg.SlackToken,
-		API:      api,
-		rtm:      api.NewRTM(),
-		DAL:      dal,
-		GitHub:   gh,
-		Log:      log,
-		Commands: map[string]*cmd.Command{},
-		handlers: map[string][]EventHandler{},
+		token:               cfg.SlackToken,
+		transport:           transport,
+		signingSecret:       cfg.SlackSigningSecret,
+		githubWebhookSecret: cfg.GithubWebhookSecret,
+		API:                 api,
+		rtm:                 api.NewRTM(),
+		events:              make(chan slack.RTMEvent, eventBufferSize),
+		DAL:                 dal,
+		GitHub:              gh,
+		Log:                 log,
+		Commands:            map[string]*cmd.Command{},
+		Aliases:             map[string]*cmd.Command{},
+		handlers:            map[string][]EventHandler{},
+		actions:             map[string]ActionHandler{},
+		confirmations:       confirmations{pending: map[string]*confirmation{}},
+		apiURL:              slackAPIURL,
+		queues:              newQueues(),
+		timeout:             handlerTimeout,
+		undoBackoff:         undoBackoff,
+		users:               map[string]slack.User{},
+		deletedUsers:        map[string]slack.User{},
 	}
+	b.ctx, b.cancel = context.WithCancel(context.Background())
 	b.UpdateUsers()
 
 	// Register default Slack event handlers
 	b.RegisterEventHandlers(map[string]EventHandler{
-		"message":     b.handleMessageEvent,
-		"team_join":   b.handleUserChange,
-		"user_change": b.handleUserChange,
+		"message":        b.handleMessageEvent,
+		"team_join":      b.handleUserChange,
+		"user_change":    b.handleUserChange,
+		interactionEvent: b.handleInteraction,
+
+		// Keep teams and projects in sync with changes made on GitHub
+		GitHubEventType("membership"):   b.handleGitHubMembership,
+		GitHubEventType("team"):         b.handleGitHubTeam,
+		GitHubEventType("organization"): b.handleGitHubOrganization,
+		GitHubEventType("repository"):   b.handleGitHubRepository,
+	})
+	b.RegisterActionHandlers(map[string]ActionHandler{
+		ConfirmAction: b.handleConfirm,
+		CancelAction:  b.handleCancel,
 	})
 	return b
 }
 
-// NewEmptyBot returns a bare-bones, empty bot used for testing
+// NewEmptyBot returns a bare-bones, empty bot used for testing, which stores
+// members and teams in memory.
 func NewEmptyBot() *Bot {
-	return &Bot{
-		Commands: map[string]*cmd.Command{},
-		handlers: map[string][]EventHandler{},
-		Log:      log.WithField("test", "test"),
+	b := &Bot{
+		transport:   TransportRTM,
+		DAL:         data.NewMemoryStore(),
+		events:      make(chan slack.RTMEvent, eventBufferSize),
+		Commands:    map[string]*cmd.Command{},
+		Aliases:     map[string]*cmd.Command{},
+		handlers:    map[string][]EventHandler{},
+		actions:     map[string]ActionHandler{},
+		apiURL:      slackAPIURL,
+		queues:      newQueues(),
+		timeout:     handlerTimeout,
+		undoBackoff: undoBackoff,
+		Log:         log.WithField("test", "test"),
+
+		users:        map[string]slack.User{},
+		deletedUsers: map[string]slack.User{},
+
+		confirmations: confirmations{pending: map[string]*confirmation{}},
 	}
+	b.ctx, b.cancel = context.WithCancel(context.Background())
+	b.actions[ConfirmAction] = b.handleConfirm
+	b.actions[CancelAction] = b.handleCancel
+	return b
 }
 
 // RegisterEventHandlers registers a handlers for different events. These
@@ -93,46 +185,64 @@ func (b *Bot) RegisterEventHandlers(handlers map[string]EventHandler) {
 	}
 }
 
-// RegisterCommands registers commands that the bot should handle. Returns an
-// error if multiple commands were registered with the same name.
+// RegisterCommands registers commands that the bot should handle, along with
+// the aliases of the commands and their subcommands. Returns an error if
+// multiple commands or aliases were registered with the same name.
 func (b *Bot) RegisterCommands(commands []*cmd.Command) error {
 	for _, c := range commands {
-		if b.Commands[c.Name] != nil {
+		if b.Command(c.Name) != nil {
 			return fmt.Errorf("multiple commands registered with name %s", c.Name)
 		}
 		b.Commands[c.Name] = c
+		if err := b.registerAliases(c); err != nil {
+			return err
+		}
 		b.Log.Infof("registered command %s", c.Name)
 	}
 	return nil
 }
 
-// Start causes an already initialized bot instance to begin listening for
-// and responding to commands sent on its Slack channel.
-func (b *Bot) Start() {
-	go b.rtm.ManageConnection()
-
-	for evt := range b.rtm.IncomingEvents {
-		// Call any registered event handlers that are expecting events of this
-		// type.
-		if handlers := b.handlers[evt.Type]; handlers != nil {
-			for _, handler := range handlers {
-				handler(evt)
-			}
+// registerAliases registers the aliases of the given command and all of its
+// subcommands.
+func (b *Bot) registerAliases(c *cmd.Command) error {
+	for _, alias := range c.Aliases {
+		if b.Command(alias) != nil {
+			return fmt.Errorf("multiple commands registered with name %s", alias)
 		}
+		b.Aliases[alias] = c
+		b.Log.Infof("registered alias %s for command %s", alias, c.FullName())
+	}
+	for _, sub := range c.Subcommands() {
+		if err := b.registerAliases(sub); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Command returns the command with the given name or alias, or nil if there
+// is no such command.
+func (b *Bot) Command(name string) *cmd.Command {
+	if c := b.Commands[name]; c != nil {
+		return c
 	}
+	return b.Aliases[name]
 }
 
 // UpdateUsers retrieves list of users from API, populates the bot
 // instance's cache, and updates any member entries in the DB with any relevant
-// info from their Slack profiles. It will also remove any users whose accounts
-// have been deleted.
+// info from their Slack profiles. Users whose accounts have been deleted are
+// kept in DeletedUsers until an admin makes them alumni with `RetireMember`.
+// Slack users are not updated if the list of users can't be retrieved.
 func (b *Bot) UpdateUsers() {
 	users, err := b.API.GetUsers()
 	if err != nil {
 		b.Log.WithError(err).Error("Failed to populate users")
+		return
 	}
 
-	b.Users = make(map[string]slack.User)
+	current := map[string]slack.User{}
+	deleted := map[string]slack.User{}
 	for _, u := range users {
 		member := &model.Member{
 			SlackID:  u.ID,
@@ -142,38 +252,101 @@ func (b *Bot) UpdateUsers() {
 			Position: u.Profile.Title,
 		}
 
-		// Delete the member from GitHub and the DB if they've been deleted from Slack
+		// Don't retire members who have been deleted from Slack without an
+		// admin confirming it
 		if u.Deleted {
-			// If the user has their GitHub username set, try remove them from
-			// the GitHub organization
-			m := &model.Member{
-				SlackID: u.ID,
-			}
-			b.DAL.GetMemberBySlackID(m)
-			if m.GithubUsername != "" {
-				if err := b.GitHub.RemoveUserFromOrg(m.GithubUsername); err != nil {
-					b.Log.WithError(err).Errorf(
-						"failed to remove %s from ubclaunchpad org on GitHub", m.GithubUsername)
-				} else {
-					b.Log.Debugf("removed %s from ubclaunchpad org on GitHub", m.GithubUsername)
-				}
-			}
-			// Delete the user from the DB
-			if err := b.DAL.DeleteMember(member); err != nil {
-				b.Log.WithError(err).Errorf("failed to delete member %s", member.Name)
-			} else {
-				b.Log.Debugf("deleted member %s", member.Name)
+			existing := &model.Member{SlackID: u.ID}
+			if err := b.DAL.GetMemberBySlackID(existing); err == nil && !existing.IsAlumni() {
+				deleted[u.ID] = u
 			}
 			continue
 		}
 
 		// Update the member in the DB and add them to the cache
-		b.Users[u.ID] = u
-		if err := b.DAL.UpdateMember(member); err != nil {
+		current[u.ID] = u
+		if err := b.syncStore().UpdateMember(member); err != nil {
 			b.Log.WithError(err).Error("failed to update member " + member.Name)
 		}
 		b.Log.Debugf("successfully updated user %s", member.Name)
 	}
+	if len(deleted) > 0 {
+		b.Log.Infof("%d members have been deleted from Slack", len(deleted))
+	}
+
+	b.usersMu.Lock()
+	b.users = current
+	b.deletedUsers = deleted
+	b.usersMu.Unlock()
+}
+
+// Users returns the cached list of Slack users.
+func (b *Bot) Users() []slack.User {
+	b.usersMu.RLock()
+	defer b.usersMu.RUnlock()
+	return sortedUsers(b.users)
+}
+
+// DeletedUsers returns the list of users whose Slack accounts have been
+// deleted, but who have not been made alumni with `RetireMember` yet.
+func (b *Bot) DeletedUsers() []slack.User {
+	b.usersMu.RLock()
+	defer b.usersMu.RUnlock()
+	return sortedUsers(b.deletedUsers)
+}
+
+// sortedUsers returns the given users sorted by name.
+func sortedUsers(users map[string]slack.User) []slack.User {
+	list := []slack.User{}
+	for _, u := range users {
+		list = append(list, u)
+	}
+	sort.Slice(list, func(i, j int) bool { return list[i].Name < list[j].Name })
+	return list
+}
+
+// RetireMember makes the member with the given Slack ID alumni and, if they
+// have their GitHub username set, removes them from the GitHub organization,
+// on behalf of the user in the given context. Alumni keep their profile and
+// team memberships.
+func (b *Bot) RetireMember(ctx cmd.Context, slackID string) error {
+	member := &model.Member{SlackID: slackID}
+	if err := b.DAL.GetMemberBySlackID(member); err != nil {
+		return err
+	}
+	// Removing them from GitHub is done last, since it can't be fully undone
+	err := b.Do(ctx, func(u *UnitOfWork) error {
+		now := time.Now().UTC()
+		member.LeftAt = &now
+		if err := u.Store.SetMemberLeftAt(member); err != nil {
+			return err
+		}
+		if member.GithubUsername == "" {
+			return nil
+		}
+		if err := b.GitHub.RemoveUserFromOrg(ctx, member.GithubUsername); err != nil {
+			return fmt.Errorf("failed to remove %s from ubclaunchpad org on GitHub: %s",
+				member.GithubUsername, err)
+		}
+		// Adding them back to the `all` team invites them to the org again
+		u.OnUndo("github.remove_org_member", slackID, func(ctx context.Context) error {
+			return b.GitHub.AddUserToTeam(ctx, member.GithubUsername, GithubAllTeamID)
+		})
+		b.Log.Debugf("removed %s from ubclaunchpad org on GitHub", member.GithubUsername)
+		err := u.Store.Record("github.remove_org_member", slackID,
+			map[string]string{"githubUsername": member.GithubUsername}, nil)
+		if err != nil {
+			b.Log.WithError(err).Error("Failed to record GitHub change")
+		}
+		return nil
+	})
+	if err != nil {
+		return err
+	}
+	b.usersMu.Lock()
+	delete(b.deletedUsers, slackID)
+	b.usersMu.Unlock()
+	b.Log.Debugf("made member %s alumni", member.Name)
+	return nil
 }
 
 // SendErrorMessage sends a generic error message back to the sender and
@@ -191,7 +364,7 @@ func (b *Bot) SendErrorMessage(channel string, err error, msg string) {
 // Determines whether the message is meant to be a command (if we need to
 // take action for it), populates the command context object for the message,
 // and calls the appropriate handler.
-func (b *Bot) handleMessageEvent(evt slack.RTMEvent) {
+func (b *Bot) handleMessageEvent(ctx context.Context, evt slack.RTMEvent) {
 	msg := evt.Data.(*slack.MessageEvent).Msg
 	b.Log.WithFields(log.Fields{
 		"Text":    msg.Text,
@@ -204,67 +377,156 @@ func (b *Bot) handleMessageEvent(evt slack.RTMEvent) {
 		return
 	}
 
-	member := model.Member{
-		SlackID:  msg.User,
-		ImageURL: b.Users[msg.User].Profile.Image192,
-	}
-
-	// Create member if doesn't already exist (this acts like an upsert)
-	if err := b.DAL.CreateMember(&member); err != nil {
-		b.Log.WithError(err).Errorf("Error creating member with Slack ID %s", member.SlackID)
+	member, err := b.getMember(msg.User)
+	if err != nil {
+		b.Log.WithError(err).Errorf("Error getting member by Slack ID %s", msg.User)
 		b.API.PostMessage(msg.Channel, errorMessage, noParams)
 		return
 	}
 
-	// Set member image to their slack profile image
-	if err := b.DAL.SetMemberImageURL(&member); err != nil {
-		b.Log.WithError(err).Errorf("Error setting member image URL")
-		b.API.PostMessage(msg.Channel, errorMessage, noParams)
+	context := cmd.Context{
+		Context:     ctx,
+		Message:     &msg,
+		User:        member,
+		ResolveTeam: b.getTeam,
+	}
+
+	// Check whether this is a reply to a request to confirm a command
+	if b.handleConfirmationReply(context) {
 		return
 	}
 
-	// Retrieves the full member object from the database
-	if err := b.DAL.GetMemberBySlackID(&member); err != nil {
-		b.Log.WithError(err).Errorf("Error getting member by Slack ID %s", member.SlackID)
-		b.API.PostMessage(msg.Channel, errorMessage, noParams)
+	// A command is defined by being prefixed by our username
+	// i.e. "@rocket <command> <arg1> ..."
+	text := strings.TrimSpace(msg.Text)
+	if args := strings.Fields(text); len(args) == 0 || args[0] != cmd.ToMention(username) {
+		return
+	}
+	res, params, err := b.RunCommand(context, strings.TrimPrefix(text, cmd.ToMention(username)))
+	if err != nil {
+		b.SendErrorMessage(msg.Channel, err, err.Error())
 		return
 	}
+	if res != "" || len(params.Attachments) > 0 {
+		b.API.PostMessage(msg.Channel, res, params)
+	}
+}
 
-	args := strings.Fields(msg.Text)
+// RunCommand runs the command in the given text, which should not include
+// the leading "@rocket", as if the user in the given context had sent it.
+// Responds to a message with no command with general help. If the command is
+// destructive and hasn't been confirmed, the user is asked to confirm it
+// instead.
+func (b *Bot) RunCommand(ctx cmd.Context, text string) (string, slack.PostMessageParameters, error) {
+	args := strings.Fields(text)
 	if len(args) == 0 {
-		return
+		text = "help"
+		args = []string{text}
+	}
+	command := b.Command(args[0])
+	if command == nil {
+		return "", noParams, errors.New(b.unknownCommandMessage(args[0]))
 	}
 
-	// A command is defined by being prefixed by our username
-	// i.e. "@rocket <command> <arg1> ..."
-	if args[0] == cmd.ToMention(username) {
-		context := cmd.Context{
-			Message: &msg,
-			User:    member,
-		}
+	msg := slack.Msg{}
+	if ctx.Message != nil {
+		msg = *ctx.Message
+	}
+	msg.Text = cmd.ToMention(username) + " " + strings.TrimSpace(text)
+	ctx.Message = &msg
+	res, params, err := command.Execute(ctx)
+	if req, ok := err.(*cmd.ConfirmationRequired); ok {
+		return b.requestConfirmation(ctx, text, req), noParams, nil
+	}
+	return res, params, err
+}
 
-		var cmd *cmd.Command
-		if len(args) > 1 {
-			command := args[1]
-			cmd = b.Commands[command]
-			if cmd == nil {
-				cmd = b.Commands["help"]
-			}
-		} else {
-			cmd = b.Commands["help"]
-		}
-		res, params, err := cmd.Execute(context)
-		if err != nil {
-			log.WithError(err).Error("Failed to execute command")
-			b.SendErrorMessage(context.Message.Channel, err, err.Error())
+// Store returns the store that commands make changes through, which records
+// them in the audit log as made by the user running the command in the given
+// context.
+func (b *Bot) Store(ctx cmd.Context) *data.AuditedStore {
+	command := ""
+	if ctx.Message != nil {
+		// Commands are prefixed by a mention of Rocket
+		parts := strings.SplitN(strings.TrimSpace(ctx.Message.Text), " ", 2)
+		if len(parts) == 2 {
+			command = strings.TrimSpace(parts[1])
 		}
-		b.API.PostMessage(context.Message.Channel, res, params)
 	}
+	return data.NewAuditedStore(b.DAL, ctx.User.SlackID, command)
+}
+
+// syncStore returns the store that changes Rocket makes to keep members in
+// sync with Slack are made through, which records them in the audit log.
+func (b *Bot) syncStore() *data.AuditedStore {
+	return data.NewAuditedStore(b.DAL, "", slackSyncCommand)
+}
+
+// SystemContext returns a context for changes Rocket makes on its own rather
+// than on behalf of a user, like fixing GitHub memberships, which Store
+// records in the audit log as made by the given command with no actor.
+func SystemContext(ctx context.Context, command string) cmd.Context {
+	return cmd.Context{
+		Context: ctx,
+		Message: &slack.Msg{Text: cmd.ToMention(username) + " " + command},
+	}
+}
+
+// getMember retrieves the member with the given Slack ID from the DB,
+// creating them first if they don't exist yet.
+func (b *Bot) getMember(slackID string) (model.Member, error) {
+	b.usersMu.RLock()
+	member := model.Member{
+		SlackID:  slackID,
+		ImageURL: b.users[slackID].Profile.Image192,
+	}
+	b.usersMu.RUnlock()
+
+	// Create member if doesn't already exist (this acts like an upsert)
+	store := b.syncStore()
+	if err := store.CreateMember(&member); err != nil {
+		return member, err
+	}
+
+	// Set member image to their slack profile image
+	if err := store.SetMemberImageURL(&member); err != nil {
+		return member, err
+	}
+
+	// Retrieves the full member object from the database
+	err := b.DAL.GetMemberBySlackID(&member)
+	return member, err
+}
+
+// unknownCommandMessage returns the response to a message invoking a command
+// that doesn't exist, suggesting a similarly named command if there is one.
+func (b *Bot) unknownCommandMessage(name string) string {
+	names := []string{}
+	for n := range b.Commands {
+		names = append(names, n)
+	}
+	for n := range b.Aliases {
+		names = append(names, n)
+	}
+	if suggestion := cmd.Suggest(name, names); suggestion != "" {
+		return fmt.Sprintf("`%s` is not a Rocket command. Did you mean `%s`?", name, suggestion)
+	}
+	return fmt.Sprintf("`%s` is not a Rocket command. See `@rocket help`", name)
+}
+
+// getTeam retrieves the team with the given name and its members from the DB.
+// It is used to resolve options of type cmd.TeamOption.
+func (b *Bot) getTeam(name string) (*model.Team, error) {
+	team := &model.Team{Name: name}
+	if err := b.DAL.GetTeamByName(team); err != nil {
+		return nil, err
+	}
+	return team, nil
 }
 
 // Handler for when a user changes their profile, or a user is added/deleted.
 // Creates the member if they don't already exist and sets their profile image.
-func (b *Bot) handleUserChange(evt slack.RTMEvent) {
+func (b *Bot) handleUserChange(ctx context.Context, evt slack.RTMEvent) {
 	var user slack.User
 
 	// This function is only called for team join or user change events, so
@@ -275,19 +537,22 @@ func (b *Bot) handleUserChange(evt slack.RTMEvent) {
 		user = evt.Data.(*slack.UserChangeEvent).User
 	}
 
-	b.Users[user.ID] = user
+	b.usersMu.Lock()
+	b.users[user.ID] = user
+	b.usersMu.Unlock()
 	member := model.Member{
 		SlackID:  user.ID,
 		ImageURL: user.Profile.Image192,
 	}
 
 	// Create user if doesn't exist
-	if err := b.DAL.CreateMember(&member); err != nil {
+	store := b.syncStore()
+	if err := store.CreateMember(&member); err != nil {
 		b.Log.WithError(err).Errorf("Error creating user with Slack ID %s", member.SlackID)
 	}
 
 	// Update image URL
-	if err := b.DAL.SetMemberImageURL(&member); err != nil {
+	if err := store.SetMemberImageURL(&member); err != nil {
 		b.Log.WithError(err).Errorf("Error setting image URL for Slack ID %s", member.SlackID)
 	}
 }
@@ -0,0 +1,24 @@
+package bot
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ubclaunchpad/rocket/cmd"
+)
+
+func TestUnknownCommandMessage(t *testing.T) {
+	b := NewEmptyBot()
+	err := b.RegisterCommands([]*cmd.Command{
+		&cmd.Command{Name: "view-team", Aliases: []string{"show-team"}},
+		&cmd.Command{Name: "help"},
+	})
+	assert.Nil(t, err)
+
+	assert.Equal(t, "`vew-team` is not a Rocket command. Did you mean `view-team`?",
+		b.unknownCommandMessage("vew-team"))
+	assert.Equal(t, "`shw-team` is not a Rocket command. Did you mean `show-team`?",
+		b.unknownCommandMessage("shw-team"))
+	assert.Equal(t, "`launch` is not a Rocket command. See `@rocket help`",
+		b.unknownCommandMessage("launch"))
+}
@@ -0,0 +1,173 @@
+package bot
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/nlopes/slack"
+	"github.com/ubclaunchpad/rocket/blocks"
+	"github.com/ubclaunchpad/rocket/cmd"
+)
+
+const (
+	// ConfirmAction is the action ID of the button that confirms a
+	// destructive command
+	ConfirmAction = "confirm"
+
+	// confirmTimeout is how long a user has to confirm a destructive command
+	confirmTimeout = 2 * time.Minute
+)
+
+// confirmation is a destructive command waiting for the user who sent it to
+// confirm it.
+type confirmation struct {
+	id      string
+	ctx     cmd.Context
+	text    string
+	expires time.Time
+}
+
+// confirmations holds the destructive commands waiting to be confirmed. Each
+// user can have one command waiting per channel.
+type confirmations struct {
+	sync.Mutex
+	pending map[string]*confirmation
+}
+
+// confirmationKey returns the key of the confirmation the user in the given
+// context may have waiting in the context's channel.
+func confirmationKey(ctx cmd.Context) string {
+	return ctx.User.SlackID + "/" + ctx.Message.Channel
+}
+
+// add stores a command waiting for confirmation, replacing any other command
+// the user has waiting in the same channel, and drops expired commands.
+func (cs *confirmations) add(ctx cmd.Context, text string) *confirmation {
+	cs.Lock()
+	defer cs.Unlock()
+	now := time.Now()
+	for key, c := range cs.pending {
+		if now.After(c.expires) {
+			delete(cs.pending, key)
+		}
+	}
+	key := confirmationKey(ctx)
+	c := &confirmation{
+		id:      key + "/" + strconv.FormatInt(now.UnixNano(), 36),
+		ctx:     ctx,
+		text:    text,
+		expires: now.Add(confirmTimeout),
+	}
+	cs.pending[key] = c
+	return c
+}
+
+// take removes and returns the command the user in the given context has
+// waiting in the context's channel. If id is not empty, the command is only
+// returned if it has the given ID. Returns nil if there is no such command
+// or it has expired.
+func (cs *confirmations) take(ctx cmd.Context, id string) *confirmation {
+	cs.Lock()
+	defer cs.Unlock()
+	key := confirmationKey(ctx)
+	c := cs.pending[key]
+	if c == nil || (id != "" && c.id != id) {
+		return nil
+	}
+	delete(cs.pending, key)
+	if time.Now().After(c.expires) {
+		return nil
+	}
+	return c
+}
+
+// requestConfirmation stores a destructive command the user in the given
+// context sent, and asks them to confirm it with a reply or a button.
+// Returns a plain response to post if the request could not be posted.
+func (b *Bot) requestConfirmation(ctx cmd.Context, text string, req *cmd.ConfirmationRequired) string {
+	c := b.confirmations.add(ctx, text)
+	instructions := fmt.Sprintf("Reply `confirm` or `cancel` within %s.", confirmTimeout)
+	msg := blocks.NewMessage(req.Summary,
+		blocks.Section(":warning: "+req.Summary),
+		blocks.Context(instructions),
+		blocks.Actions("",
+			blocks.Button(ConfirmAction, "Confirm", c.id).WithStyle(blocks.Danger),
+			blocks.Button(CancelAction, "Cancel", c.id),
+		),
+	)
+	if err := b.PostBlocks(ctx, ctx.Message.Channel, msg); err != nil {
+		b.Log.WithError(err).Error("Failed to post confirmation buttons")
+		return req.Summary + "\n" + instructions
+	}
+	return ""
+}
+
+// confirmationReply returns true and whether the message confirms or cancels
+// a destructive command if the given text is a reply to a request for
+// confirmation, e.g. "confirm" or "@rocket cancel".
+func confirmationReply(text string) (isReply bool, confirmed bool) {
+	text = strings.TrimSpace(strings.TrimPrefix(strings.TrimSpace(text), cmd.ToMention(username)))
+	switch strings.ToLower(text) {
+	case "confirm", "yes", "y":
+		return true, true
+	case "cancel", "no", "n":
+		return true, false
+	}
+	return false, false
+}
+
+// handleConfirmationReply runs or cancels the destructive command the user
+// in the given context has waiting in the context's channel if the context's
+// message is a reply to the request for confirmation. Returns false if the
+// message is not such a reply.
+func (b *Bot) handleConfirmationReply(ctx cmd.Context) bool {
+	isReply, confirmed := confirmationReply(ctx.Message.Text)
+	if !isReply {
+		return false
+	}
+	c := b.confirmations.take(ctx, "")
+	if c == nil {
+		return false
+	}
+	if !confirmed {
+		b.API.PostMessage(ctx.Message.Channel, "Cancelled", noParams)
+		return true
+	}
+	res, params, err := b.runConfirmed(ctx, c)
+	if err != nil {
+		b.SendErrorMessage(ctx.Message.Channel, err, err.Error())
+	} else if res != "" || len(params.Attachments) > 0 {
+		b.API.PostMessage(ctx.Message.Channel, res, params)
+	}
+	return true
+}
+
+// handleConfirm runs the destructive command whose "Confirm" button was
+// clicked, if it was clicked by the user who sent the command.
+func (b *Bot) handleConfirm(ctx cmd.Context, action Action) (string, slack.PostMessageParameters) {
+	c := b.confirmations.take(ctx, action.Value)
+	if c == nil {
+		return "There is no command waiting for you to confirm. " +
+			"It may have expired, or been cancelled.", noParams
+	}
+	if err := b.ReplaceMessage(ctx, action, blocks.NewMessage("Confirmed")); err != nil {
+		b.Log.WithError(err).Error("Failed to replace confirmation message")
+	}
+	res, params, err := b.runConfirmed(ctx, c)
+	if err != nil {
+		return err.Error(), noParams
+	}
+	return res, params
+}
+
+// runConfirmed runs a destructive command after the user confirmed it in the
+// given context.
+func (b *Bot) runConfirmed(confirmed cmd.Context, c *confirmation) (string, slack.PostMessageParameters, error) {
+	ctx := c.ctx
+	ctx.Context = confirmed.Context
+	ctx.Confirmed = true
+	return b.RunCommand(ctx, c.text)
+}
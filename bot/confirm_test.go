@@ -0,0 +1,141 @@
+package bot
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/nlopes/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/ubclaunchpad/rocket/cmd"
+)
+
+// newConfirmTestBot returns a bot with a destructive command that records
+// whether it ran, and a fake Slack API that records the last message posted.
+func newConfirmTestBot(t *testing.T) (*Bot, *bool, *map[string]interface{}, func()) {
+	ran := false
+	posted := map[string]interface{}{}
+	srv := httptest.NewServer(http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		data, _ := ioutil.ReadAll(req.Body)
+		posted = map[string]interface{}{}
+		json.Unmarshal(data, &posted)
+		res.Write([]byte(`{"ok": true}`))
+	}))
+
+	b := NewEmptyBot()
+	b.apiURL = srv.URL + "/"
+	err := b.RegisterCommands([]*cmd.Command{
+		&cmd.Command{
+			Name:        "launch",
+			Destructive: true,
+			Summary:     func(cmd.Context) string { return "This will launch the rocket" },
+			HandleFunc: func(ctx cmd.Context) (string, slack.PostMessageParameters) {
+				ran = ctx.Confirmed
+				return "Launched", noParams
+			},
+		},
+	})
+	assert.Nil(t, err)
+	return b, &ran, &posted, srv.Close
+}
+
+func getConfirmTestContext(user string) cmd.Context {
+	ctx := cmd.Context{
+		Context: context.Background(),
+		Message: &slack.Msg{Channel: "C2147483705"},
+	}
+	ctx.User.SlackID = user
+	return ctx
+}
+
+func TestConfirmationRequest(t *testing.T) {
+	b, ran, posted, done := newConfirmTestBot(t)
+	defer done()
+	ctx := getConfirmTestContext("U1")
+
+	res, _, err := b.RunCommand(ctx, "launch")
+	assert.Nil(t, err)
+	assert.Equal(t, "", res)
+	assert.False(t, *ran)
+	assert.Equal(t, "C2147483705", (*posted)["channel"])
+	assert.Equal(t, "This will launch the rocket", (*posted)["text"])
+	assert.Len(t, b.confirmations.pending, 1)
+}
+
+func TestConfirmButton(t *testing.T) {
+	b, ran, posted, done := newConfirmTestBot(t)
+	defer done()
+	b.RunCommand(getConfirmTestContext("U1"), "launch")
+	id := b.confirmations.pending["U1/C2147483705"].id
+
+	// Other users can't confirm the command
+	res, _ := b.handleAction(getConfirmTestContext("U2"), Action{ID: ConfirmAction, Value: id})
+	assert.Contains(t, res, "There is no command waiting for you to confirm")
+	assert.False(t, *ran)
+
+	// The user who sent it can, once
+	action := Action{ID: ConfirmAction, Value: id, ResponseURL: b.apiURL}
+	res, _ = b.handleAction(getConfirmTestContext("U1"), action)
+	assert.Equal(t, "Launched", res)
+	assert.True(t, *ran)
+	assert.Equal(t, true, (*posted)["replace_original"])
+
+	*ran = false
+	res, _ = b.handleAction(getConfirmTestContext("U1"), action)
+	assert.Contains(t, res, "There is no command waiting for you to confirm")
+	assert.False(t, *ran)
+}
+
+func TestCancelButton(t *testing.T) {
+	b, ran, posted, done := newConfirmTestBot(t)
+	defer done()
+	b.RunCommand(getConfirmTestContext("U1"), "launch")
+	id := b.confirmations.pending["U1/C2147483705"].id
+
+	res, _ := b.handleAction(getConfirmTestContext("U1"),
+		Action{ID: CancelAction, Value: id, ResponseURL: b.apiURL})
+	assert.Equal(t, "", res)
+	assert.Equal(t, "Cancelled", (*posted)["text"])
+
+	res, _ = b.handleAction(getConfirmTestContext("U1"), Action{ID: ConfirmAction, Value: id})
+	assert.Contains(t, res, "There is no command waiting for you to confirm")
+	assert.False(t, *ran)
+}
+
+func TestConfirmationExpires(t *testing.T) {
+	b, ran, _, done := newConfirmTestBot(t)
+	defer done()
+	b.RunCommand(getConfirmTestContext("U1"), "launch")
+	c := b.confirmations.pending["U1/C2147483705"]
+	c.expires = time.Now().Add(-time.Second)
+
+	res, _ := b.handleAction(getConfirmTestContext("U1"), Action{ID: ConfirmAction, Value: c.id})
+	assert.Contains(t, res, "There is no command waiting for you to confirm")
+	assert.False(t, *ran)
+	assert.Len(t, b.confirmations.pending, 0)
+}
+
+func TestConfirmationReply(t *testing.T) {
+	tests := []struct {
+		text      string
+		isReply   bool
+		confirmed bool
+	}{
+		{"confirm", true, true},
+		{" Yes ", true, true},
+		{"<@U5RU9TB38> confirm", true, true},
+		{"cancel", true, false},
+		{"<@U5RU9TB38> no", true, false},
+		{"<@U5RU9TB38> launch", false, false},
+		{"confirmed", false, false},
+	}
+	for _, test := range tests {
+		isReply, confirmed := confirmationReply(test.text)
+		assert.Equal(t, test.isReply, isReply, test.text)
+		assert.Equal(t, test.confirmed, confirmed, test.text)
+	}
+}
@@ -0,0 +1,201 @@
+package bot
+
+import (
+	"context"
+	"fmt"
+	"hash/fnv"
+	"runtime/debug"
+	"time"
+
+	"github.com/nlopes/slack"
+)
+
+const (
+	// workerCount is the number of events that can be handled at once
+	workerCount = 8
+	// queueSize is the number of events each worker can have waiting
+	queueSize = 50
+	// handlerTimeout is how long an event handler has to handle an event
+	handlerTimeout = 30 * time.Second
+
+	// Message to send when handling a command takes too long
+	timeoutMessage = "Sorry, that took too long :hourglass:. " +
+		"Please try again later."
+	// Message to send when handling a command panics, with what it panicked
+	// with
+	panicMessage = "Oops, an error occurred :robot_face:: %v"
+)
+
+// newQueues returns the event queues of the bot's workers.
+func newQueues() []chan slack.RTMEvent {
+	queues := make([]chan slack.RTMEvent, workerCount)
+	for i := range queues {
+		queues[i] = make(chan slack.RTMEvent, queueSize)
+	}
+	return queues
+}
+
+// Start causes an already initialized bot instance to begin listening for
+// and responding to commands sent on its Slack channel. Events are received
+// over the RTM or, if the bot is configured to use the Events API transport,
+// from the bot's HTTP handlers. Interactions with messages are always
+// received from the bot's HTTP handlers. Start returns once the bot has been
+// stopped with Stop.
+func (b *Bot) Start() {
+	b.workers.Add(len(b.queues))
+	for _, queue := range b.queues {
+		go b.work(queue)
+	}
+
+	if b.transport == TransportRTM {
+		go b.rtm.ManageConnection()
+		go func() {
+			for evt := range b.rtm.IncomingEvents {
+				b.stopMu.RLock()
+				if !b.stopped {
+					b.dispatch(evt)
+				}
+				b.stopMu.RUnlock()
+			}
+		}()
+	}
+	b.Log.Infof("listening for Slack events over %s", b.transport)
+
+	// Events are no longer queued once the bot is stopped, but the ones
+	// that were already queued are still handled
+	for evt := range b.events {
+		b.dispatch(evt)
+	}
+	b.stopMu.Lock()
+	for _, queue := range b.queues {
+		close(queue)
+	}
+	b.stopMu.Unlock()
+}
+
+// Stop stops the bot from accepting new events, and waits until the events it
+// has already accepted have been handled. If the given context is done
+// first, the handlers' contexts are cancelled, and the context's error is
+// returned once the handlers in progress have returned, so that nothing they
+// use is closed while they are still running.
+func (b *Bot) Stop(ctx context.Context) error {
+	b.stopMu.Lock()
+	if !b.stopped {
+		b.stopped = true
+		if b.transport == TransportRTM && b.rtm != nil {
+			if err := b.rtm.Disconnect(); err != nil {
+				b.Log.WithError(err).Error("Failed to disconnect from the RTM")
+			}
+		}
+		close(b.events)
+	}
+	b.stopMu.Unlock()
+
+	done := make(chan struct{})
+	go func() {
+		b.workers.Wait()
+		close(done)
+	}()
+	select {
+	case <-done:
+		b.Log.Info("Finished handling Slack events")
+		return nil
+	case <-ctx.Done():
+		b.cancel()
+		<-done
+		return ctx.Err()
+	}
+}
+
+// dispatch queues an event to be handled by one of the bot's workers. Events
+// from the same user are always handled by the same worker, so that they are
+// handled in the order they were received, while events from different users
+// can be handled at the same time.
+func (b *Bot) dispatch(evt slack.RTMEvent) {
+	if len(b.handlers[evt.Type]) == 0 {
+		return
+	}
+	h := fnv.New32a()
+	h.Write([]byte(eventUser(evt)))
+	b.queues[h.Sum32()%uint32(len(b.queues))] <- evt
+}
+
+// work handles the events in the given queue one at a time, until the queue
+// is closed. Events still queued once the bot has been told to stop handling
+// them are dropped.
+func (b *Bot) work(queue chan slack.RTMEvent) {
+	defer b.workers.Done()
+	for evt := range queue {
+		if b.ctx.Err() != nil {
+			continue
+		}
+		for _, handler := range b.handlers[evt.Type] {
+			b.handle(handler, evt)
+		}
+	}
+}
+
+// handle calls the given handler with the given event, and waits until it
+// returns. If the handler panics or its time to handle the event runs out,
+// the user who sent the event is told something went wrong. A handler that
+// times out is cancelled, and still waited for so that the user's next event
+// isn't handled while it is running.
+func (b *Bot) handle(handler EventHandler, evt slack.RTMEvent) {
+	ctx, cancel := context.WithTimeout(b.ctx, b.timeout)
+	defer cancel()
+
+	done := make(chan struct{})
+	go func() {
+		defer close(done)
+		defer func() {
+			if r := recover(); r != nil {
+				b.Log.WithError(fmt.Errorf("%v", r)).Errorf(
+					"Handler for %s event panicked\n%s", evt.Type, debug.Stack())
+				b.reply(evt, fmt.Sprintf(panicMessage, r))
+			}
+		}()
+		handler(ctx, evt)
+	}()
+
+	select {
+	case <-done:
+	case <-ctx.Done():
+		// Handlers cancelled because the bot is stopping didn't take too long
+		if b.ctx.Err() == nil {
+			b.Log.Errorf("Handler for %s event timed out after %s", evt.Type, b.timeout)
+			b.reply(evt, timeoutMessage)
+		}
+		<-done
+	}
+}
+
+// reply posts the given message in the channel the given event came from, if
+// it came from a channel.
+func (b *Bot) reply(evt slack.RTMEvent, msg string) {
+	var channel string
+	switch data := evt.Data.(type) {
+	case *slack.MessageEvent:
+		channel = data.Channel
+	case *interaction:
+		channel = data.Channel.ID
+	}
+	if channel != "" && b.API != nil {
+		b.API.PostMessage(channel, msg, noParams)
+	}
+}
+
+// eventUser returns the ID of the user who caused the given event, or an
+// empty string if it wasn't caused by a user.
+func eventUser(evt slack.RTMEvent) string {
+	switch data := evt.Data.(type) {
+	case *slack.MessageEvent:
+		return data.User
+	case *slack.TeamJoinEvent:
+		return data.User.ID
+	case *slack.UserChangeEvent:
+		return data.User.ID
+	case *interaction:
+		return data.User.ID
+	}
+	return ""
+}
@@ -0,0 +1,180 @@
+package bot
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/nlopes/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func joinEvent(user string) slack.RTMEvent {
+	evt := &slack.TeamJoinEvent{Type: "team_join"}
+	evt.User.ID = user
+	return slack.RTMEvent{Type: "team_join", Data: evt}
+}
+
+func startWorkers(b *Bot) {
+	b.workers.Add(len(b.queues))
+	for _, queue := range b.queues {
+		go b.work(queue)
+	}
+}
+
+func waitFor(t *testing.T, c chan string) string {
+	select {
+	case s := <-c:
+		return s
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event to be handled")
+		return ""
+	}
+}
+
+func TestDispatchOrdersEventsPerUser(t *testing.T) {
+	b := NewEmptyBot()
+	release := make(chan struct{})
+	handled := make(chan string, 10)
+	b.RegisterEventHandlers(map[string]EventHandler{
+		"team_join": func(ctx context.Context, evt slack.RTMEvent) {
+			user := evt.Data.(*slack.TeamJoinEvent).User.ID
+			if user == "U1" {
+				<-release
+			}
+			handled <- user
+		},
+	})
+	startWorkers(b)
+
+	// U1's first event blocks its worker, so its second event has to wait,
+	// but other users' events don't
+	b.dispatch(joinEvent("U1"))
+	b.dispatch(joinEvent("U1"))
+	b.dispatch(joinEvent("U2"))
+	assert.Equal(t, "U2", waitFor(t, handled))
+
+	close(release)
+	assert.Equal(t, "U1", waitFor(t, handled))
+	assert.Equal(t, "U1", waitFor(t, handled))
+}
+
+func TestDispatchRecoversFromPanics(t *testing.T) {
+	b := NewEmptyBot()
+	handled := make(chan string, 10)
+	b.RegisterEventHandlers(map[string]EventHandler{
+		"team_join": func(ctx context.Context, evt slack.RTMEvent) {
+			user := evt.Data.(*slack.TeamJoinEvent).User.ID
+			if user == "U1" {
+				// An unchecked type assertion gone wrong
+				_ = evt.Data.(*slack.MessageEvent)
+			}
+			handled <- user
+		},
+	})
+	startWorkers(b)
+
+	b.dispatch(joinEvent("U1"))
+	b.dispatch(joinEvent("U1"))
+	b.dispatch(slack.RTMEvent{Type: "team_join", Data: &slack.TeamJoinEvent{}})
+	b.dispatch(joinEvent("U2"))
+	assert.ElementsMatch(t, []string{"", "U2"}, []string{waitFor(t, handled), waitFor(t, handled)})
+}
+
+func TestDispatchTimesOut(t *testing.T) {
+	b := NewEmptyBot()
+	b.timeout = 10 * time.Millisecond
+	handled := make(chan string, 10)
+	b.RegisterEventHandlers(map[string]EventHandler{
+		"team_join": func(ctx context.Context, evt slack.RTMEvent) {
+			user := evt.Data.(*slack.TeamJoinEvent).User.ID
+			if user == "slow" {
+				handled <- "started"
+				<-ctx.Done()
+				// Take a while to notice the handler was cancelled
+				time.Sleep(20 * time.Millisecond)
+				handled <- ctx.Err().Error()
+				return
+			}
+			handled <- user
+		},
+	})
+	startWorkers(b)
+
+	// The user's next event waits until the handler that timed out returns
+	b.dispatch(joinEvent("slow"))
+	b.dispatch(joinEvent("slow"))
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, "started", waitFor(t, handled))
+		assert.Equal(t, context.DeadlineExceeded.Error(), waitFor(t, handled))
+	}
+}
+
+func TestDispatchIgnoresUnhandledEvents(t *testing.T) {
+	b := NewEmptyBot()
+	b.dispatch(joinEvent("U1"))
+	for _, queue := range b.queues {
+		assert.Len(t, queue, 0)
+	}
+}
+
+func TestStopDrainsEvents(t *testing.T) {
+	b := newEventsBot()
+	started := make(chan string, 10)
+	release := make(chan struct{})
+	handled := make(chan string, 10)
+	b.RegisterEventHandlers(map[string]EventHandler{
+		"team_join": func(ctx context.Context, evt slack.RTMEvent) {
+			user := evt.Data.(*slack.TeamJoinEvent).User.ID
+			started <- user
+			<-release
+			handled <- user
+		},
+	})
+	go b.Start()
+	assert.True(t, b.enqueue(joinEvent("U1")))
+	assert.True(t, b.enqueue(joinEvent("U1")))
+	waitFor(t, started)
+
+	stopped := make(chan error)
+	go func() { stopped <- b.Stop(context.Background()) }()
+	close(release)
+	select {
+	case err := <-stopped:
+		assert.Nil(t, err)
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for bot to stop")
+	}
+	assert.Len(t, handled, 2)
+
+	// Events received after stopping are turned away
+	assert.False(t, b.enqueue(joinEvent("U3")))
+}
+
+func TestStopTimesOut(t *testing.T) {
+	b := newEventsBot()
+	started := make(chan string, 2)
+	cancelled := make(chan error, 2)
+	b.RegisterEventHandlers(map[string]EventHandler{
+		"team_join": func(ctx context.Context, evt slack.RTMEvent) {
+			started <- evt.Data.(*slack.TeamJoinEvent).User.ID
+			<-ctx.Done()
+			// Take a while to notice the handler was cancelled
+			time.Sleep(20 * time.Millisecond)
+			cancelled <- ctx.Err()
+		},
+	})
+	go b.Start()
+	assert.True(t, b.enqueue(joinEvent("U1")))
+	assert.True(t, b.enqueue(joinEvent("U1")))
+	waitFor(t, started)
+
+	// Stop returns once the cancelled handler has returned, and the event
+	// still queued is dropped
+	ctx, cancel := context.WithTimeout(context.Background(), 10*time.Millisecond)
+	defer cancel()
+	assert.Equal(t, context.DeadlineExceeded, b.Stop(ctx))
+	if assert.Len(t, cancelled, 1) {
+		assert.Equal(t, context.Canceled, <-cancelled)
+	}
+}
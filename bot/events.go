@@ -0,0 +1,195 @@
+package bot
+
+import (
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/nlopes/slack"
+	"github.com/ubclaunchpad/rocket/cmd"
+)
+
+const (
+	// eventBufferSize is the number of events received over HTTP that may be
+	// waiting to be dispatched before Rocket asks Slack to retry later
+	eventBufferSize = 100
+	// maxRequestAge is how old a request from Slack may be before we reject
+	// it, to protect against replay attacks
+	maxRequestAge = 5 * time.Minute
+	// maxRequestSize is the maximum size of a request body we accept from Slack
+	maxRequestSize = 1 << 20
+)
+
+// callback is the outer envelope of a request from the Slack Events API.
+type callback struct {
+	Type      string          `json:"type"`
+	Challenge string          `json:"challenge"`
+	Event     json.RawMessage `json:"event"`
+}
+
+// EventsHandler returns an HTTP handler for Slack Events API callbacks. Each
+// request's signature is verified against the bot's signing secret, and the
+// events it carries are passed to the bot's registered event handlers.
+// The bot must be started with the Events API transport for events to be
+// dispatched.
+func (b *Bot) EventsHandler() http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		body, err := b.verifyRequest(res, req)
+		if err != nil {
+			b.Log.WithError(err).Warn("Rejected Slack event request")
+			res.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		var cb callback
+		if err := json.Unmarshal(body, &cb); err != nil {
+			b.Log.WithError(err).Error("Failed to decode Slack event")
+			res.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		switch cb.Type {
+		case "url_verification":
+			// Slack sends this when the events URL is configured
+			res.Header().Set("Content-Type", "text/plain")
+			res.Write([]byte(cb.Challenge))
+		case "event_callback":
+			evt, err := parseEvent(cb.Event)
+			if err != nil {
+				b.Log.WithError(err).Error("Failed to decode Slack event")
+				res.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			if evt != nil && !b.enqueue(*evt) {
+				res.WriteHeader(http.StatusServiceUnavailable)
+				return
+			}
+			res.WriteHeader(http.StatusOK)
+		default:
+			res.WriteHeader(http.StatusOK)
+		}
+	})
+}
+
+// SlashCommandHandler returns an HTTP handler for the `/rocket` slash
+// command. Each request's signature is verified against the bot's signing
+// secret, and `/rocket COMMAND ...` is then handled exactly like a message
+// saying `@rocket COMMAND ...` in the channel the command was used in.
+func (b *Bot) SlashCommandHandler() http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		body, err := b.verifyRequest(res, req)
+		if err != nil {
+			b.Log.WithError(err).Warn("Rejected Slack slash command request")
+			res.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		form, err := url.ParseQuery(string(body))
+		if err != nil {
+			b.Log.WithError(err).Error("Failed to decode Slack slash command")
+			res.WriteHeader(http.StatusBadRequest)
+			return
+		}
+
+		msg := &slack.MessageEvent{}
+		msg.Type = "message"
+		msg.Channel = form.Get("channel_id")
+		msg.User = form.Get("user_id")
+		msg.Text = cmd.ToMention(username) + " " + form.Get("text")
+		if !b.enqueue(slack.RTMEvent{Type: "message", Data: msg}) {
+			res.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		// Rocket responds in the channel, so acknowledge without a message
+		res.WriteHeader(http.StatusOK)
+	})
+}
+
+// enqueue queues an event received over HTTP to be dispatched by Start.
+// Returns false if the queue is full or the bot has been stopped.
+func (b *Bot) enqueue(evt slack.RTMEvent) bool {
+	b.stopMu.RLock()
+	defer b.stopMu.RUnlock()
+	if b.stopped {
+		return false
+	}
+	select {
+	case b.events <- evt:
+		return true
+	default:
+		b.Log.Warnf("Dropped Slack %s event: event queue is full", evt.Type)
+		return false
+	}
+}
+
+// parseEvent converts the inner event of an Events API callback to the
+// same type of event Rocket receives over the RTM. Returns nil if Rocket does
+// not handle events of this type.
+func parseEvent(raw json.RawMessage) (*slack.RTMEvent, error) {
+	var inner struct {
+		Type string `json:"type"`
+	}
+	if err := json.Unmarshal(raw, &inner); err != nil {
+		return nil, err
+	}
+
+	var data interface{}
+	switch inner.Type {
+	case "message":
+		data = &slack.MessageEvent{}
+	case "team_join":
+		data = &slack.TeamJoinEvent{}
+	case "user_change":
+		data = &slack.UserChangeEvent{}
+	default:
+		return nil, nil
+	}
+	if err := json.Unmarshal(raw, data); err != nil {
+		return nil, err
+	}
+	return &slack.RTMEvent{Type: inner.Type, Data: data}, nil
+}
+
+// verifyRequest reads the body of a request from Slack and checks that it
+// was signed with the bot's signing secret. Returns the body if it was.
+// See https://api.slack.com/docs/verifying-requests-from-slack
+func (b *Bot) verifyRequest(res http.ResponseWriter, req *http.Request) ([]byte, error) {
+	if b.signingSecret == "" {
+		return nil, fmt.Errorf("no Slack signing secret is configured")
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(res, req.Body, maxRequestSize))
+	if err != nil {
+		return nil, err
+	}
+
+	ts := req.Header.Get("X-Slack-Request-Timestamp")
+	secs, err := strconv.ParseInt(ts, 10, 64)
+	if err != nil {
+		return nil, fmt.Errorf("invalid request timestamp %q", ts)
+	}
+	if age := time.Since(time.Unix(secs, 0)); age > maxRequestAge || age < -maxRequestAge {
+		return nil, fmt.Errorf("request timestamp %q is too old", ts)
+	}
+
+	expected := signature(b.signingSecret, ts, body)
+	if !hmac.Equal([]byte(req.Header.Get("X-Slack-Signature")), []byte(expected)) {
+		return nil, fmt.Errorf("invalid request signature")
+	}
+	return body, nil
+}
+
+// signature returns the signature Slack sends with a request with the given
+// timestamp and body.
+func signature(secret, ts string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write([]byte("v0:" + ts + ":"))
+	mac.Write(body)
+	return "v0=" + hex.EncodeToString(mac.Sum(nil))
+}
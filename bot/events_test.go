@@ -0,0 +1,161 @@
+package bot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nlopes/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+const testSigningSecret = "8f742231b10e8888abcd99yyyzzz85a5"
+
+// fakeSlack sends signed requests to a handler the way Slack does.
+type fakeSlack struct {
+	secret string
+	now    time.Time
+}
+
+func (f fakeSlack) send(h http.Handler, contentType, body string) *httptest.ResponseRecorder {
+	ts := strconv.FormatInt(f.now.Unix(), 10)
+	req := httptest.NewRequest("POST", "/", strings.NewReader(body))
+	req.Header.Set("Content-Type", contentType)
+	req.Header.Set("X-Slack-Request-Timestamp", ts)
+	req.Header.Set("X-Slack-Signature", signature(f.secret, ts, []byte(body)))
+	res := httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+	return res
+}
+
+func (f fakeSlack) sendEvent(h http.Handler, body string) *httptest.ResponseRecorder {
+	return f.send(h, "application/json", body)
+}
+
+func (f fakeSlack) sendCommand(h http.Handler, form url.Values) *httptest.ResponseRecorder {
+	return f.send(h, "application/x-www-form-urlencoded", form.Encode())
+}
+
+func newEventsBot() *Bot {
+	b := NewEmptyBot()
+	b.transport = TransportEvents
+	b.signingSecret = testSigningSecret
+	return b
+}
+
+func TestEventsURLVerification(t *testing.T) {
+	b := newEventsBot()
+	slackAPI := fakeSlack{secret: testSigningSecret, now: time.Now()}
+
+	res := slackAPI.sendEvent(b.EventsHandler(),
+		`{"type":"url_verification","challenge":"3eZbrw1aBm2rZgRNFdxV2595E9CY3gmdALWMmHkvFXO7tYXAYM8P"}`)
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Equal(t, "3eZbrw1aBm2rZgRNFdxV2595E9CY3gmdALWMmHkvFXO7tYXAYM8P", res.Body.String())
+}
+
+func TestEventsMessage(t *testing.T) {
+	b := newEventsBot()
+	slackAPI := fakeSlack{secret: testSigningSecret, now: time.Now()}
+
+	res := slackAPI.sendEvent(b.EventsHandler(), `{
+		"type": "event_callback",
+		"event": {
+			"type": "message",
+			"channel": "C2147483705",
+			"user": "U2147483697",
+			"text": "<@U5RU9TB38> help",
+			"ts": "1355517523.000005"
+		}
+	}`)
+	assert.Equal(t, http.StatusOK, res.Code)
+
+	evt := <-b.events
+	assert.Equal(t, "message", evt.Type)
+	msg := evt.Data.(*slack.MessageEvent).Msg
+	assert.Equal(t, "C2147483705", msg.Channel)
+	assert.Equal(t, "U2147483697", msg.User)
+	assert.Equal(t, "<@U5RU9TB38> help", msg.Text)
+}
+
+func TestEventsIgnoresUnhandledTypes(t *testing.T) {
+	b := newEventsBot()
+	slackAPI := fakeSlack{secret: testSigningSecret, now: time.Now()}
+
+	res := slackAPI.sendEvent(b.EventsHandler(),
+		`{"type":"event_callback","event":{"type":"reaction_added","user":"U2147483697"}}`)
+	assert.Equal(t, http.StatusOK, res.Code)
+	assert.Len(t, b.events, 0)
+}
+
+func TestEventsDispatch(t *testing.T) {
+	b := newEventsBot()
+	slackAPI := fakeSlack{secret: testSigningSecret, now: time.Now()}
+
+	joined := make(chan slack.User, 1)
+	b.RegisterEventHandlers(map[string]EventHandler{
+		"team_join": func(ctx context.Context, evt slack.RTMEvent) {
+			joined <- evt.Data.(*slack.TeamJoinEvent).User
+		},
+	})
+	go b.Start()
+
+	res := slackAPI.sendEvent(b.EventsHandler(),
+		`{"type":"event_callback","event":{"type":"team_join","user":{"id":"U2147483697","name":"bruno"}}}`)
+	assert.Equal(t, http.StatusOK, res.Code)
+
+	select {
+	case user := <-joined:
+		assert.Equal(t, "U2147483697", user.ID)
+		assert.Equal(t, "bruno", user.Name)
+	case <-time.After(time.Second):
+		t.Fatal("team_join event was not dispatched")
+	}
+}
+
+func TestSlashCommand(t *testing.T) {
+	b := newEventsBot()
+	slackAPI := fakeSlack{secret: testSigningSecret, now: time.Now()}
+
+	res := slackAPI.sendCommand(b.SlashCommandHandler(), url.Values{
+		"command":    {"/rocket"},
+		"text":       {`team add "Rocket Devs"`},
+		"user_id":    {"U2147483697"},
+		"channel_id": {"C2147483705"},
+	})
+	assert.Equal(t, http.StatusOK, res.Code)
+
+	evt := <-b.events
+	assert.Equal(t, "message", evt.Type)
+	msg := evt.Data.(*slack.MessageEvent).Msg
+	assert.Equal(t, "C2147483705", msg.Channel)
+	assert.Equal(t, "U2147483697", msg.User)
+	assert.Equal(t, `<@U5RU9TB38> team add "Rocket Devs"`, msg.Text)
+}
+
+func TestVerifyRequest(t *testing.T) {
+	b := newEventsBot()
+	body := `{"type":"event_callback","event":{"type":"message","user":"U2147483697"}}`
+
+	// Wrong secret
+	forger := fakeSlack{secret: "not the secret", now: time.Now()}
+	res := forger.sendEvent(b.EventsHandler(), body)
+	assert.Equal(t, http.StatusUnauthorized, res.Code)
+
+	// Replayed request
+	replayer := fakeSlack{secret: testSigningSecret, now: time.Now().Add(-time.Hour)}
+	res = replayer.sendEvent(b.EventsHandler(), body)
+	assert.Equal(t, http.StatusUnauthorized, res.Code)
+
+	// No signing secret configured
+	b.signingSecret = ""
+	slackAPI := fakeSlack{secret: "", now: time.Now()}
+	res = slackAPI.sendEvent(b.EventsHandler(), body)
+	assert.Equal(t, http.StatusUnauthorized, res.Code)
+
+	assert.Len(t, b.events, 0)
+}
@@ -0,0 +1,391 @@
+package bot
+
+import (
+	"context"
+	"crypto/hmac"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+
+	"github.com/nlopes/slack"
+	"github.com/ubclaunchpad/rocket/data"
+	"github.com/ubclaunchpad/rocket/github"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// The command recorded in the audit log for changes Rocket makes to keep
+// teams and projects in sync with GitHub events
+const githubWebhookCommand = "github webhook"
+
+// GitHubEvent is an event GitHub sent to Rocket's webhook. Handlers
+// registered with RegisterEventHandlers under GitHubEventType(name) receive
+// it as the data of a slack.RTMEvent, so that plugins subscribe to GitHub
+// events the same way they subscribe to Slack events.
+type GitHubEvent struct {
+	// Name is the event's name, e.g. "membership"
+	Name string
+	// Delivery is the unique ID GitHub gave the delivery of the event
+	Delivery string
+	// Action is what happened, e.g. "added", for events that have one
+	Action string
+	// Payload is the event's JSON payload, which handlers decode into the
+	// fields they need
+	Payload json.RawMessage
+}
+
+// GitHubEventType returns the type of the events handlers of the GitHub event
+// with the given name are registered under, e.g. "github_membership".
+func GitHubEventType(name string) string {
+	return "github_" + name
+}
+
+// githubUser is a GitHub user in an event payload.
+type githubUser struct {
+	Login string `json:"login"`
+}
+
+// githubTeam is a GitHub team in an event payload.
+type githubTeam struct {
+	ID   int    `json:"id"`
+	Name string `json:"name"`
+}
+
+// githubRepo is a GitHub repository in an event payload.
+type githubRepo struct {
+	Name    string     `json:"name"`
+	HTMLURL string     `json:"html_url"`
+	Owner   githubUser `json:"owner"`
+}
+
+// githubChange is the previous value of a field changed by an event.
+type githubChange struct {
+	From string `json:"from"`
+}
+
+// githubPayload has the fields of the payloads of the GitHub events Rocket
+// keeps its database in sync with.
+type githubPayload struct {
+	Action string `json:"action"`
+	// Scope is the scope of a membership event, which is "team" for team
+	// memberships
+	Scope  string      `json:"scope"`
+	Member *githubUser `json:"member"`
+	Team   *githubTeam `json:"team"`
+	// Membership is the organization membership of an organization event
+	Membership *struct {
+		User githubUser `json:"user"`
+	} `json:"membership"`
+	Repository *githubRepo `json:"repository"`
+	Changes    struct {
+		Name       *githubChange `json:"name"`
+		Repository struct {
+			Name *githubChange `json:"name"`
+		} `json:"repository"`
+		Owner struct {
+			From struct {
+				User         *githubUser `json:"user"`
+				Organization *githubUser `json:"organization"`
+			} `json:"from"`
+		} `json:"owner"`
+	} `json:"changes"`
+}
+
+// GitHubWebhookHandler returns an HTTP handler for GitHub webhook deliveries.
+// Each delivery's signature is verified against the bot's GitHub webhook
+// secret, and its event is passed to the handlers registered for it.
+func (b *Bot) GitHubWebhookHandler() http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		body, err := b.verifyGitHubRequest(res, req)
+		if err != nil {
+			b.Log.WithError(err).Warn("Rejected GitHub webhook request")
+			res.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+
+		evt := &GitHubEvent{
+			Name:     req.Header.Get("X-GitHub-Event"),
+			Delivery: req.Header.Get("X-GitHub-Delivery"),
+			Payload:  body,
+		}
+		var payload struct {
+			Action string `json:"action"`
+		}
+		if err := json.Unmarshal(body, &payload); err != nil {
+			b.Log.WithError(err).Error("Failed to decode GitHub event")
+			res.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		evt.Action = payload.Action
+
+		// GitHub sends a ping when the webhook is created, and events no
+		// one handles are acknowledged without queueing them
+		typ := GitHubEventType(evt.Name)
+		if len(b.handlers[typ]) == 0 {
+			res.WriteHeader(http.StatusOK)
+			return
+		}
+		if !b.enqueue(slack.RTMEvent{Type: typ, Data: evt}) {
+			res.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		res.WriteHeader(http.StatusAccepted)
+	})
+}
+
+// verifyGitHubRequest reads the body of a request from GitHub and checks
+// that it was signed with the bot's GitHub webhook secret. Returns the body,
+// or an error if the request can't be verified.
+func (b *Bot) verifyGitHubRequest(res http.ResponseWriter, req *http.Request) ([]byte, error) {
+	if b.githubWebhookSecret == "" {
+		return nil, fmt.Errorf("no GitHub webhook secret is configured")
+	}
+	body, err := ioutil.ReadAll(http.MaxBytesReader(res, req.Body, maxRequestSize))
+	if err != nil {
+		return nil, err
+	}
+	expected := githubSignature(b.githubWebhookSecret, body)
+	if !hmac.Equal([]byte(req.Header.Get("X-Hub-Signature-256")), []byte(expected)) {
+		return nil, fmt.Errorf("invalid request signature")
+	}
+	return body, nil
+}
+
+// githubSignature returns the signature GitHub sends with a delivery with the
+// given body.
+func githubSignature(secret string, body []byte) string {
+	mac := hmac.New(sha256.New, []byte(secret))
+	mac.Write(body)
+	return "sha256=" + hex.EncodeToString(mac.Sum(nil))
+}
+
+// githubStore returns the store that changes Rocket makes to keep teams and
+// projects in sync with GitHub are made through, which records them in the
+// audit log.
+func (b *Bot) githubStore() *data.AuditedStore {
+	return data.NewAuditedStore(b.DAL, "", githubWebhookCommand)
+}
+
+// decodeGitHubEvent decodes the payload of the GitHub event in the given
+// event, logging an error if it can't be decoded.
+func (b *Bot) decodeGitHubEvent(evt slack.RTMEvent) (*githubPayload, bool) {
+	ghEvt := evt.Data.(*GitHubEvent)
+	payload := &githubPayload{}
+	if err := json.Unmarshal(ghEvt.Payload, payload); err != nil {
+		b.Log.WithError(err).Errorf("Failed to decode GitHub %s event %s",
+			ghEvt.Name, ghEvt.Delivery)
+		return nil, false
+	}
+	return payload, true
+}
+
+// memberByGitHubUsername returns the member with the given GitHub username,
+// compared case-insensitively like GitHub does, or nil if there is none.
+func (b *Bot) memberByGitHubUsername(username string) (*model.Member, error) {
+	var members model.Members
+	if err := b.DAL.GetAllMembers(&members); err != nil {
+		return nil, err
+	}
+	for _, m := range members {
+		if m.GithubUsername != "" && strings.EqualFold(m.GithubUsername, username) {
+			return m, nil
+		}
+	}
+	return nil, nil
+}
+
+// handleGitHubMembership adds members to or removes them from teams in the
+// current term when they are added to or removed from their GitHub teams.
+// People who aren't members and teams Rocket doesn't know about are ignored.
+func (b *Bot) handleGitHubMembership(ctx context.Context, evt slack.RTMEvent) {
+	payload, ok := b.decodeGitHubEvent(evt)
+	if !ok || payload.Scope != "team" || payload.Member == nil || payload.Team == nil {
+		return
+	}
+	member, err := b.memberByGitHubUsername(payload.Member.Login)
+	if err != nil {
+		b.Log.WithError(err).Error("Failed to get members")
+		return
+	}
+	if member == nil {
+		return
+	}
+	team := &model.Team{GithubTeamID: payload.Team.ID}
+	if err := b.DAL.GetTeamByGithubID(team); err != nil {
+		if err != data.ErrNotFound {
+			b.Log.WithError(err).Errorf("Failed to get team %s", payload.Team.Name)
+		}
+		return
+	}
+	if team.Archived {
+		return
+	}
+
+	teamMember := &model.TeamMember{MemberSlackID: member.SlackID, GithubTeamID: team.GithubTeamID}
+	switch payload.Action {
+	case "added":
+		err = b.githubStore().CreateTeamMember(teamMember)
+	case "removed":
+		err = b.githubStore().DeleteTeamMember(teamMember)
+	default:
+		return
+	}
+	if err != nil {
+		b.Log.WithError(err).Errorf("Failed to sync membership of %s on team %s",
+			member.Name, team.Name)
+	}
+}
+
+// handleGitHubTeam renames teams that are renamed on GitHub and archives teams
+// that are deleted there, unlinking them from their GitHub teams. Archived
+// teams keep their members in past terms and their projects. Teams created on
+// GitHub aren't added, since Rocket creates teams on GitHub itself.
+func (b *Bot) handleGitHubTeam(ctx context.Context, evt slack.RTMEvent) {
+	payload, ok := b.decodeGitHubEvent(evt)
+	if !ok || payload.Team == nil {
+		return
+	}
+	team := &model.Team{GithubTeamID: payload.Team.ID}
+	if err := b.DAL.GetTeamByGithubID(team); err != nil {
+		if err != data.ErrNotFound {
+			b.Log.WithError(err).Errorf("Failed to get team %s", payload.Team.Name)
+		}
+		return
+	}
+
+	var err error
+	switch payload.Action {
+	case "edited":
+		if payload.Changes.Name == nil || payload.Team.Name == team.Name {
+			return
+		}
+		err = b.githubStore().UpdateTeam(team, &model.Team{Name: payload.Team.Name})
+	case "deleted":
+		err = b.githubStore().Transaction(func(store *data.AuditedStore) error {
+			if !team.Archived {
+				current := &model.Term{}
+				if err := store.GetCurrentTerm(current); err != nil {
+					return err
+				}
+				if err := store.RemoveTeamFromTerm(team, current.Code); err != nil {
+					return err
+				}
+			}
+			return store.UnlinkTeam(team)
+		})
+	default:
+		return
+	}
+	if err != nil {
+		b.Log.WithError(err).Errorf("Failed to sync team %s", team.Name)
+	}
+}
+
+// handleGitHubOrganization removes members from their teams in the current
+// term when they are removed from the GitHub organization, since GitHub
+// removes them from its teams too.
+func (b *Bot) handleGitHubOrganization(ctx context.Context, evt slack.RTMEvent) {
+	payload, ok := b.decodeGitHubEvent(evt)
+	if !ok || payload.Action != "member_removed" || payload.Membership == nil {
+		return
+	}
+	member, err := b.memberByGitHubUsername(payload.Membership.User.Login)
+	if err != nil {
+		b.Log.WithError(err).Error("Failed to get members")
+		return
+	}
+	if member == nil {
+		return
+	}
+	err = b.githubStore().Transaction(func(store *data.AuditedStore) error {
+		var teams model.Teams
+		if err := store.GetTeams(&teams); err != nil {
+			return err
+		}
+		for _, team := range teams {
+			for _, m := range team.Members {
+				if m.SlackID != member.SlackID {
+					continue
+				}
+				err := store.DeleteTeamMember(&model.TeamMember{
+					MemberSlackID: member.SlackID,
+					GithubTeamID:  team.GithubTeamID,
+				})
+				if err != nil {
+					return err
+				}
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		b.Log.WithError(err).Errorf("Failed to remove %s from their teams", member.Name)
+	}
+}
+
+// handleGitHubRepository updates the repositories of projects when they are
+// renamed or transferred on GitHub, and removes them when they are deleted.
+func (b *Bot) handleGitHubRepository(ctx context.Context, evt slack.RTMEvent) {
+	payload, ok := b.decodeGitHubEvent(evt)
+	if !ok || payload.Repository == nil {
+		return
+	}
+	repo := payload.Repository
+	owner, name := repo.Owner.Login, repo.Name
+	switch payload.Action {
+	case "deleted":
+	case "renamed":
+		if payload.Changes.Repository.Name == nil {
+			return
+		}
+		name = payload.Changes.Repository.Name.From
+	case "transferred":
+		from := payload.Changes.Owner.From
+		switch {
+		case from.User != nil:
+			owner = from.User.Login
+		case from.Organization != nil:
+			owner = from.Organization.Login
+		default:
+			return
+		}
+	default:
+		return
+	}
+
+	err := b.githubStore().Transaction(func(store *data.AuditedStore) error {
+		var projects model.Projects
+		if err := store.GetProjects(&projects); err != nil {
+			return err
+		}
+		for _, project := range projects {
+			repos := []string{}
+			changed := false
+			for _, url := range project.Repos {
+				o, n, err := github.ParseRepo(url)
+				if err != nil || !strings.EqualFold(o, owner) || !strings.EqualFold(n, name) {
+					repos = append(repos, url)
+					continue
+				}
+				changed = true
+				if payload.Action != "deleted" {
+					repos = append(repos, repo.HTMLURL)
+				}
+			}
+			if !changed {
+				continue
+			}
+			project.Repos = repos
+			if err := store.SaveProject(project); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		b.Log.WithError(err).Errorf("Failed to sync repository %s/%s", owner, name)
+	}
+}
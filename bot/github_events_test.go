@@ -0,0 +1,161 @@
+package bot
+
+import (
+	"context"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nlopes/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/ubclaunchpad/rocket/data"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+const testWebhookSecret = "It's a Secret to Everybody"
+
+// sendGitHubEvent sends a delivery of the given event to the given handler,
+// signed with the given secret the way GitHub signs it.
+func sendGitHubEvent(h http.Handler, secret, event, body string) *httptest.ResponseRecorder {
+	req := httptest.NewRequest("POST", "/webhooks/github", strings.NewReader(body))
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("X-GitHub-Event", event)
+	req.Header.Set("X-GitHub-Delivery", "72d3162e-cc78-11e3-81ab-4c9367dc0958")
+	req.Header.Set("X-Hub-Signature-256", githubSignature(secret, []byte(body)))
+	res := httptest.NewRecorder()
+	h.ServeHTTP(res, req)
+	return res
+}
+
+// githubEvent returns a GitHub event with the given name and payload as it
+// is passed to handlers.
+func githubEvent(name, payload string) slack.RTMEvent {
+	return slack.RTMEvent{
+		Type: GitHubEventType(name),
+		Data: &GitHubEvent{Name: name, Payload: []byte(payload)},
+	}
+}
+
+func TestGitHubWebhook(t *testing.T) {
+	b := newEventsBot()
+	b.githubWebhookSecret = testWebhookSecret
+	added := make(chan *GitHubEvent, 1)
+	b.RegisterEventHandlers(map[string]EventHandler{
+		GitHubEventType("membership"): func(ctx context.Context, evt slack.RTMEvent) {
+			added <- evt.Data.(*GitHubEvent)
+		},
+	})
+	go b.Start()
+	defer b.Stop(context.Background())
+
+	body := `{"action":"added","scope":"team","member":{"login":"bfbachmann"},"team":{"id":1,"name":"Rocket"}}`
+
+	// Deliveries that aren't signed with the secret are rejected
+	res := sendGitHubEvent(b.GitHubWebhookHandler(), "wrong secret", "membership", body)
+	assert.Equal(t, http.StatusUnauthorized, res.Code)
+
+	// Events no one handles are acknowledged
+	res = sendGitHubEvent(b.GitHubWebhookHandler(), testWebhookSecret, "ping", `{"zen":"Keep it logically awesome."}`)
+	assert.Equal(t, http.StatusOK, res.Code)
+
+	res = sendGitHubEvent(b.GitHubWebhookHandler(), testWebhookSecret, "membership", body)
+	assert.Equal(t, http.StatusAccepted, res.Code)
+	select {
+	case evt := <-added:
+		assert.Equal(t, "membership", evt.Name)
+		assert.Equal(t, "added", evt.Action)
+		assert.Equal(t, "72d3162e-cc78-11e3-81ab-4c9367dc0958", evt.Delivery)
+		assert.JSONEq(t, body, string(evt.Payload))
+	case <-time.After(time.Second):
+		t.Fatal("membership event was not dispatched")
+	}
+
+	// Deliveries are rejected if no secret is configured
+	b.githubWebhookSecret = ""
+	res = sendGitHubEvent(b.GitHubWebhookHandler(), "", "membership", body)
+	assert.Equal(t, http.StatusUnauthorized, res.Code)
+}
+
+func TestGitHubSync(t *testing.T) {
+	b := NewEmptyBot()
+	b.DAL.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno", GithubUsername: "bfbachmann"})
+	b.DAL.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1})
+	b.DAL.SaveProject(&model.Project{GithubTeamID: 1, Name: "Rocket",
+		Repos: []string{"https://github.com/ubclaunchpad/rocket", "https://github.com/ubclaunchpad/inertia"}})
+	ctx := context.Background()
+	teamMembers := func() []string {
+		team := &model.Team{GithubTeamID: 1}
+		assert.Nil(t, b.DAL.GetTeamByGithubID(team))
+		slackIDs := []string{}
+		for _, m := range team.Members {
+			slackIDs = append(slackIDs, m.SlackID)
+		}
+		return slackIDs
+	}
+
+	// Team memberships follow GitHub team memberships, and are recorded
+	b.handleGitHubMembership(ctx, githubEvent("membership",
+		`{"action":"added","scope":"team","member":{"login":"BFBachmann"},"team":{"id":1,"name":"Rocket"}}`))
+	assert.Equal(t, []string{"U1"}, teamMembers())
+	var entries model.AuditEntries
+	assert.Nil(t, b.DAL.GetAuditEntries(data.AuditFilter{Target: "U1"}, &entries))
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "team_member.create", entries[0].Action)
+		assert.Equal(t, githubWebhookCommand, entries[0].Command)
+	}
+	b.handleGitHubMembership(ctx, githubEvent("membership",
+		`{"action":"added","scope":"team","member":{"login":"octocat"},"team":{"id":1,"name":"Rocket"}}`))
+	assert.Equal(t, []string{"U1"}, teamMembers())
+	b.handleGitHubMembership(ctx, githubEvent("membership",
+		`{"action":"removed","scope":"team","member":{"login":"bfbachmann"},"team":{"id":1,"name":"Rocket"}}`))
+	assert.Equal(t, []string{}, teamMembers())
+
+	// Members removed from the organization are removed from their teams
+	b.DAL.CreateTeamMember(&model.TeamMember{MemberSlackID: "U1", GithubTeamID: 1})
+	b.handleGitHubOrganization(ctx, githubEvent("organization",
+		`{"action":"member_removed","membership":{"user":{"login":"bfbachmann"}}}`))
+	assert.Equal(t, []string{}, teamMembers())
+
+	// Repositories of projects follow renames and deletions
+	b.handleGitHubRepository(ctx, githubEvent("repository",
+		`{"action":"renamed","changes":{"repository":{"name":{"from":"rocket"}}},
+		"repository":{"name":"rocket-bot","html_url":"https://github.com/ubclaunchpad/rocket-bot","owner":{"login":"ubclaunchpad"}}}`))
+	b.handleGitHubRepository(ctx, githubEvent("repository",
+		`{"action":"deleted","repository":{"name":"inertia","html_url":"https://github.com/ubclaunchpad/inertia","owner":{"login":"ubclaunchpad"}}}`))
+	project := &model.Project{GithubTeamID: 1}
+	assert.Nil(t, b.DAL.GetProjectByTeam(project))
+	assert.Equal(t, []string{"https://github.com/ubclaunchpad/rocket-bot"}, project.Repos)
+
+	// Teams follow renames
+	b.handleGitHubTeam(ctx, githubEvent("team",
+		`{"action":"edited","changes":{"name":{"from":"Rocket"}},"team":{"id":1,"name":"Rocket Bot"}}`))
+	team := &model.Team{GithubTeamID: 1}
+	assert.Nil(t, b.DAL.GetTeamByGithubID(team))
+	assert.Equal(t, "Rocket Bot", team.Name)
+
+	// Deleted teams are archived and unlinked from GitHub, keeping their
+	// members in past terms and their project
+	b.DAL.CreateTeamMember(&model.TeamMember{MemberSlackID: "U1", GithubTeamID: 1})
+	assert.Nil(t, b.DAL.CreateTerm(&model.Term{Code: "2099W", StartedAt: time.Now().Add(time.Hour)}))
+	assert.Nil(t, b.DAL.AddTeamToTerm(team, "2099W"))
+	b.handleGitHubTeam(ctx, githubEvent("team",
+		`{"action":"deleted","team":{"id":1,"name":"Rocket Bot"}}`))
+	assert.Equal(t, data.ErrNotFound, b.DAL.GetTeamByGithubID(team))
+	team = &model.Team{Name: "Rocket Bot"}
+	if assert.Nil(t, b.DAL.GetTeamByName(team)) {
+		assert.Equal(t, -1, team.GithubTeamID)
+		assert.True(t, team.Archived)
+	}
+	var terms model.Terms
+	assert.Nil(t, b.DAL.GetTerms(&terms))
+	var past model.Teams
+	assert.Nil(t, b.DAL.GetTeamsByTerm(terms[1].Code, &past))
+	if assert.Len(t, past, 1) && assert.Len(t, past[0].Members, 1) {
+		assert.Equal(t, "U1", past[0].Members[0].SlackID)
+	}
+	project = &model.Project{GithubTeamID: -1}
+	assert.Nil(t, b.DAL.GetProjectByTeam(project))
+	assert.Equal(t, "Rocket", project.Name)
+}
@@ -0,0 +1,96 @@
+package bot
+
+import (
+	"context"
+	"time"
+
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/data"
+)
+
+const (
+	// undoAttempts is how many times an undo action is tried before giving
+	// up on it
+	undoAttempts = 3
+	// undoBackoff is how long to wait before trying an undo action again,
+	// multiplied by the number of attempts so far
+	undoBackoff = time.Second
+	// undoTimeout is how long each attempt of an undo action has
+	undoTimeout = 30 * time.Second
+)
+
+// UnitOfWork is a change to the store and to systems outside it, like
+// GitHub, that is made entirely or not at all. Its changes to the store are
+// made in a transaction, and each change it makes outside the store
+// registers an undo action. If the unit of work fails, its transaction is
+// rolled back and its undo actions are run, newest first.
+//
+// Changes that can't be undone, like removing someone from GitHub, should be
+// made last, so that nothing can fail after them but committing.
+type UnitOfWork struct {
+	// Store is the store the unit of work's changes are made through. Reads
+	// made through other stores may wait for the unit of work to finish.
+	Store *data.AuditedStore
+	undo  []undoAction
+}
+
+// undoAction undoes a change a unit of work made outside the store.
+type undoAction struct {
+	action string
+	target string
+	fn     func(context.Context) error
+}
+
+// OnUndo registers a function that undoes a change the unit of work made
+// outside the store, e.g. by deleting a team it created on GitHub. The
+// action and target describe the change in the audit log if it can't be
+// undone.
+func (u *UnitOfWork) OnUndo(action, target string, fn func(context.Context) error) {
+	u.undo = append(u.undo, undoAction{action, target, fn})
+}
+
+// Do runs the given function as a unit of work on behalf of the user in the
+// given context. Returns the error the function returned or that committing
+// its changes failed with, after undoing its changes.
+func (b *Bot) Do(ctx cmd.Context, fn func(*UnitOfWork) error) error {
+	u := &UnitOfWork{}
+	err := b.Store(ctx).Transaction(func(tx *data.AuditedStore) error {
+		u.Store = tx
+		return fn(u)
+	})
+	if err != nil {
+		b.undo(ctx, u)
+	}
+	return err
+}
+
+// undo runs the undo actions of the given unit of work newest first, trying
+// each until it succeeds or runs out of attempts. Changes that can't be
+// undone are logged and recorded in the audit log, so that they can be
+// undone by hand.
+func (b *Bot) undo(ctx cmd.Context, u *UnitOfWork) {
+	for i := len(u.undo) - 1; i >= 0; i-- {
+		action := u.undo[i]
+		var err error
+		for attempt := 1; attempt <= undoAttempts; attempt++ {
+			if attempt > 1 {
+				time.Sleep(time.Duration(attempt-1) * b.undoBackoff)
+			}
+			undoCtx, cancel := context.WithTimeout(context.Background(), undoTimeout)
+			err = action.fn(undoCtx)
+			cancel()
+			if err == nil {
+				break
+			}
+		}
+		if err == nil {
+			continue
+		}
+		b.Log.WithError(err).Errorf("Failed to undo %s of %s", action.action, action.target)
+		err = b.Store(ctx).Record(action.action+".undo_failed", action.target, nil,
+			map[string]string{"error": err.Error()})
+		if err != nil {
+			b.Log.WithError(err).Error("Failed to record change that couldn't be undone")
+		}
+	}
+}
@@ -0,0 +1,90 @@
+package bot
+
+import (
+	"context"
+	"errors"
+	"testing"
+
+	"github.com/nlopes/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/data"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+func TestUnitOfWork(t *testing.T) {
+	b := NewEmptyBot()
+	b.undoBackoff = 0
+	ctx := cmd.Context{Message: &slack.Msg{Text: "@rocket team add Rocket"}}
+	ctx.User.SlackID = "U1"
+
+	// Successful units of work keep their changes and aren't undone
+	err := b.Do(ctx, func(u *UnitOfWork) error {
+		u.OnUndo("github.create_team", "Rocket", func(context.Context) error {
+			t.Error("undid a unit of work that succeeded")
+			return nil
+		})
+		return u.Store.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1})
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, b.DAL.GetTeamByName(&model.Team{Name: "Rocket"}))
+
+	// Units of work that fail discard their changes, including the entries
+	// recording them, and are undone newest first
+	failed := errors.New("failed")
+	undone := []string{}
+	err = b.Do(ctx, func(u *UnitOfWork) error {
+		u.OnUndo("github.create_team", "Buddy", func(context.Context) error {
+			undone = append(undone, "Buddy")
+			return nil
+		})
+		u.OnUndo("github.add_team_member", "U1", func(context.Context) error {
+			undone = append(undone, "U1")
+			return nil
+		})
+		assert.Nil(t, u.Store.CreateTeam(&model.Team{Name: "Buddy", GithubTeamID: 2}))
+		return failed
+	})
+	assert.Equal(t, failed, err)
+	assert.Equal(t, []string{"U1", "Buddy"}, undone)
+	assert.Equal(t, data.ErrNotFound, b.DAL.GetTeamByName(&model.Team{Name: "Buddy"}))
+	var entries model.AuditEntries
+	assert.Nil(t, b.DAL.GetAuditEntries(data.AuditFilter{Target: "Buddy"}, &entries))
+	assert.Len(t, entries, 0)
+
+	// Undo actions are retried, and changes that can't be undone are
+	// recorded
+	attempts := 0
+	err = b.Do(ctx, func(u *UnitOfWork) error {
+		u.OnUndo("github.create_team", "Sleuth", func(context.Context) error {
+			attempts++
+			return errors.New("GitHub is down")
+		})
+		return failed
+	})
+	assert.Equal(t, failed, err)
+	assert.Equal(t, undoAttempts, attempts)
+	assert.Nil(t, b.DAL.GetAuditEntries(data.AuditFilter{Target: "Sleuth"}, &entries))
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "github.create_team.undo_failed", entries[0].Action)
+		assert.Equal(t, "U1", entries[0].ActorSlackID)
+		assert.Equal(t, `{"error":"GitHub is down"}`, entries[0].After)
+	}
+
+	// Undo actions that succeed after failing aren't recorded
+	attempts = 0
+	err = b.Do(ctx, func(u *UnitOfWork) error {
+		u.OnUndo("github.create_team", "Pinpoint", func(context.Context) error {
+			attempts++
+			if attempts < 2 {
+				return errors.New("GitHub is down")
+			}
+			return nil
+		})
+		return failed
+	})
+	assert.Equal(t, failed, err)
+	assert.Equal(t, 2, attempts)
+	assert.Nil(t, b.DAL.GetAuditEntries(data.AuditFilter{Target: "Pinpoint"}, &entries))
+	assert.Len(t, entries, 0)
+}
@@ -1,8 +1,9 @@
 package cmd
 
 import (
+	"context"
 	"fmt"
-	"regexp"
+	"sort"
 	"strings"
 
 	"github.com/nlopes/slack"
@@ -24,42 +25,180 @@ type Command struct {
 	// the `key` field in that option.
 	Options map[string]*Option
 
+	// Args lists the keys of options that can also be given as positional
+	// arguments, in the order they are expected. For example, a command
+	// named `view-user` with `Args: []string{"user"}` accepts both
+	// `@rocket view-user @bruno` and `@rocket view-user user={@bruno}`.
+	Args []string
+
+	// Aliases are additional names that invoke this command directly, e.g.
+	// `add-team` for the `add` subcommand of `team`. Aliases are always
+	// top-level names, even for subcommands.
+	Aliases []string
+
+	// Permission decides who may use this command. It is checked by the `cmd`
+	// framework before the command's handler is called. If it is nil, anyone
+	// may use the command.
+	Permission Permission
+
+	// Destructive marks commands whose effects are hard to undo. Instead of
+	// calling the handler of a destructive command straight away, Rocket
+	// replies with a summary of what the command will do and waits for the
+	// user to confirm it.
+	Destructive bool
+
+	// Summary describes what a destructive command will do with the options
+	// in the given context, e.g. "This will delete the team `Rocket`". If it
+	// is nil, the confirmation message repeats the command instead. If it
+	// returns an empty string, the command runs without confirmation.
+	Summary func(Context) string
+
 	// HandleFunc is the `CommandHandler` that executes the command. It should
 	// take `cmd.Context` as its only argument and return a `string` response
-	// message with `slack.PostMessageParameters`.
+	// message with `slack.PostMessageParameters`. Commands that only group
+	// subcommands may leave this unset, in which case Rocket responds with
+	// the command's help text.
 	HandleFunc CommandHandler
+
+	// subcommands maps subcommand names to subcommands added with
+	// `AddSubcommands`
+	subcommands map[string]*Command
+	// parent is the command this command is a subcommand of, if any
+	parent *Command
+}
+
+// AddSubcommands adds the given commands as subcommands of this command so
+// that they can be invoked with `@rocket COMMAND SUBCOMMAND`. Returns the
+// command so it can be used when constructing command trees.
+func (c *Command) AddSubcommands(subcommands ...*Command) *Command {
+	if c.subcommands == nil {
+		c.subcommands = map[string]*Command{}
+	}
+	for _, sub := range subcommands {
+		sub.parent = c
+		c.subcommands[sub.Name] = sub
+	}
+	return c
+}
+
+// Subcommands returns this command's subcommands sorted by name.
+func (c *Command) Subcommands() []*Command {
+	subs := []*Command{}
+	for _, sub := range c.subcommands {
+		subs = append(subs, sub)
+	}
+	sort.Slice(subs, func(i, j int) bool { return subs[i].Name < subs[j].Name })
+	return subs
+}
+
+// FullName returns the name used to invoke this command, including the names
+// of its parent commands (e.g. "team add").
+func (c *Command) FullName() string {
+	if c.parent == nil {
+		return c.Name
+	}
+	return c.parent.FullName() + " " + c.Name
+}
+
+// Find returns the subcommand of this command at the given path of
+// subcommand names, or nil if there is no such subcommand.
+func (c *Command) Find(path ...string) *Command {
+	if len(path) == 0 {
+		return c
+	}
+	if sub := c.subcommands[path[0]]; sub != nil {
+		return sub.Find(path[1:]...)
+	}
+	return nil
 }
 
 // Execute executes the given command and returns an error if
 // if the command is invalid.
 func (c *Command) Execute(ctx Context) (string, slack.PostMessageParameters, error) {
+	if ctx.Context == nil {
+		ctx.Context = context.Background()
+	}
 	// Parse and validate command
-	if err := c.parse(ctx.Message.Text); err != nil {
+	command, opts, err := c.parse(ctx.Message.Text)
+	if err != nil {
 		return "", slack.PostMessageParameters{}, err
 	}
-	// Copy options to context for use by command handler
-	ctx.Options = map[string]Option{}
-	for key, opt := range c.Options {
-		ctx.Options[key] = *opt
-		// Clear option value now that it's been copied
-		opt.Value = ""
+	// Commands that only group subcommands respond with their help text
+	if command.HandleFunc == nil {
+		res, params := command.Help()
+		return res, params, nil
+	}
+	// Look up any teams referred to by the options
+	if err := resolveTeams(ctx, opts); err != nil {
+		return "", slack.PostMessageParameters{}, err
+	}
+	// Check that the user is allowed to use this command
+	ctx.Options = opts
+	if command.Permission != nil && !command.Permission.Allows(ctx) {
+		return "", slack.PostMessageParameters{}, fmt.Errorf(
+			"Only %s can use `%s`", command.Permission, command.FullName())
+	}
+	// Check that the user has confirmed destructive commands
+	if command.Destructive && !ctx.Confirmed {
+		summary := "This will run `" + command.FullName() + "`"
+		if command.Summary != nil {
+			summary = command.Summary(ctx)
+		}
+		if summary != "" {
+			return "", slack.PostMessageParameters{}, &ConfirmationRequired{
+				Command: command,
+				Summary: summary,
+			}
+		}
 	}
-	// Pass context to command handler
-	res, params := c.HandleFunc(ctx)
+	// Pass options to command handler through the context
+	res, params := command.HandleFunc(ctx)
 	return res, params, nil
 }
 
+// ConfirmationRequired is the error returned by `Execute` when a destructive
+// command has not been confirmed. The command should be executed again with
+// `Context.Confirmed` set once the user confirms it.
+type ConfirmationRequired struct {
+	Command *Command
+	// Summary describes what the command will do
+	Summary string
+}
+
+func (e *ConfirmationRequired) Error() string {
+	return "`" + e.Command.FullName() + "` must be confirmed before it runs"
+}
+
 // Help returns full help text for the given command
 func (c *Command) Help() (string, slack.PostMessageParameters) {
-	usage := "Usage: `@rocket " + c.Name + ""
+	usage := "Usage: `@rocket " + c.FullName() + ""
+	if len(c.subcommands) > 0 {
+		if c.HandleFunc == nil {
+			usage += " SUBCOMMAND"
+		} else {
+			usage += " [SUBCOMMAND]"
+		}
+	}
+	for _, key := range c.Args {
+		if o := c.Options[key]; o != nil && o.Required {
+			usage += " " + strings.ToUpper(key)
+		} else {
+			usage += " [" + strings.ToUpper(key) + "]"
+		}
+	}
 	opts := ""
 	attachments := []slack.Attachment{}
 	if len(c.Options) > 0 {
-		usage += " OPTIONS`"
+		if len(c.Options) > len(c.Args) {
+			usage += " OPTIONS"
+		}
+		usage += "`"
 		opts = ""
 		for _, o := range c.Options {
 			if o.Required {
-				opts += fmt.Sprintf("`%s` (required): %s\n", o.Key, o.HelpText)
+				opts += fmt.Sprintf("`%s` (required%s): %s\n", o.Key, o.typeHint(", "), o.HelpText)
+			} else if hint := o.typeHint(""); hint != "" {
+				opts += fmt.Sprintf("%s (%s): %s\n", o.Key, hint, o.HelpText)
 			} else {
 				opts += fmt.Sprintf("%s: %s\n", o.Key, o.HelpText)
 			}
@@ -72,61 +211,172 @@ func (c *Command) Help() (string, slack.PostMessageParameters) {
 	} else {
 		usage += "`"
 	}
+	if len(c.subcommands) > 0 {
+		subs := ""
+		for _, sub := range c.Subcommands() {
+			subs += fmt.Sprintf("`%s`: %s\n", sub.Name, sub.HelpText)
+		}
+		attachments = append(attachments, slack.Attachment{
+			Title: "Subcommands",
+			Text:  subs + "\nGet help using a subcommand with `@rocket help " + c.FullName() + " SUBCOMMAND`",
+			Color: "#e5e7ea",
+		})
+	}
+	helpText := c.HelpText
+	if c.Permission != nil {
+		helpText += " (" + c.Permission.String() + " only)"
+	}
+	if c.Destructive {
+		helpText += "\nAsks for confirmation before running"
+	}
+	if len(c.Aliases) > 0 {
+		helpText += "\nAlso available as `" + strings.Join(c.Aliases, "`, `") + "`"
+	}
 	params := slack.PostMessageParameters{Attachments: attachments}
-	return fmt.Sprintf("%s\n\n%s", usage, c.HelpText), params
+	return fmt.Sprintf("%s\n\n%s", usage, helpText), params
 }
 
 // parse checks whether the given command meets the requirements of this
-// Command and returns nil if it does, and the validation error otherwise.
-// The command format should be "@rocket COMMAND OPTIONS ARGUMENTS"
-func (c *Command) parse(cmd string) error {
+// Command or one of its subcommands, and returns the command that was invoked
+// and its options populated with the values the user entered if it does,
+// and the validation error otherwise.
+// The command format should be "@rocket COMMAND [SUBCOMMAND...] ARGUMENTS OPTIONS"
+func (c *Command) parse(cmd string) (*Command, map[string]Option, error) {
+	tokens, err := tokenize(cmd)
+	if err != nil {
+		return nil, nil, err
+	}
 	// Check that we received the correct command
-	tokens := strings.Fields(cmd)
 	if len(tokens) < 2 {
-		return fmt.Errorf("Received empty command")
-	} else if tokens[1] != c.Name {
-		return fmt.Errorf("Invalid command \"%s\"", tokens[1])
+		return nil, nil, fmt.Errorf("Received empty command")
+	} else if tokens[1].Key != "" || !c.isNamed(tokens[1].Value) {
+		return nil, nil, fmt.Errorf("Invalid command \"%s\"", tokens[1].Value)
+	}
+	// Find the subcommand that was invoked, if any
+	command, tokens, err := c.find(tokens[2:])
+	if err != nil {
+		return nil, nil, err
 	}
 	// Check options and store their values
-	optionsRegex := regexp.MustCompile("[a-zA-Z-]+={[^}]+}")
-	opts := optionsRegex.FindAllString(strings.Join(tokens[2:], " "), -1)
-	return c.parseOptions(opts)
+	opts, err := command.parseOptions(tokens)
+	return command, opts, err
 }
 
-// parseOptions checks that the value corresponding to each option matches
-// that option's required format, then stores that value. Returns an error
-// if an option is malformatted, or a required option is missing.
-// opts should be a slice of strings of the format "key=value".
-func (c *Command) parseOptions(opts []string) error {
-	for _, token := range opts {
-		// Token has format my-key={my value}. Extract option key and value
-		parts := strings.SplitN(token, "=", 2)
-		key := parts[0][:len(parts[0])]
-		value := strings.TrimSpace(strings.TrimRight(strings.TrimLeft(parts[1], "{"), "}"))
+// find follows the leading words of the given tokens down this command's
+// tree of subcommands, and returns the command they refer to along with the
+// remaining tokens. Returns an error if a word does not match a subcommand
+// of a command that can't be executed itself.
+func (c *Command) find(tokens []token) (*Command, []token, error) {
+	if len(c.subcommands) == 0 || len(tokens) == 0 {
+		return c, tokens, nil
+	}
+	if tokens[0].Key == "" {
+		if sub := c.subcommands[tokens[0].Value]; sub != nil {
+			return sub.find(tokens[1:])
+		}
+	}
+	if c.HandleFunc != nil {
+		return c, tokens, nil
+	}
+	names := []string{}
+	for name := range c.subcommands {
+		names = append(names, name)
+	}
+	return nil, nil, fmt.Errorf("Unknown subcommand \"%s\" for `%s`%s",
+		tokens[0].Value, c.FullName(), didYouMean(tokens[0].Value, names))
+}
+
+// isNamed returns true if the given name is this command's name or one of
+// its aliases.
+func (c *Command) isNamed(name string) bool {
+	if name == c.Name && c.parent == nil {
+		return true
+	}
+	for _, alias := range c.Aliases {
+		if name == alias {
+			return true
+		}
+	}
+	return false
+}
+
+// parseOptions assigns each token to the option with the corresponding key,
+// or to the next positional argument if it has no key. Then it checks that
+// each value matches its option's required format and type, and stores that
+// value in a copy of the option. Returns an error if an option is
+// malformatted or unrecognized, or a required option is missing.
+func (c *Command) parseOptions(tokens []token) (map[string]Option, error) {
+	values := map[string]Option{}
+	nextArg := 0
+	for _, t := range tokens {
+		key := t.Key
+		if key == "" {
+			// Token is a positional argument
+			if nextArg >= len(c.Args) {
+				return nil, fmt.Errorf("Unexpected argument \"%s\"", t.Value)
+			}
+			key = c.Args[nextArg]
+			nextArg++
+		}
 
 		// Check that it is a valid option
 		option := c.Options[key]
 		if option == nil {
-			return fmt.Errorf("Unrecognized option %s", key)
+			keys := []string{}
+			for k := range c.Options {
+				keys = append(keys, k)
+			}
+			return nil, fmt.Errorf("Unrecognized option %s%s", key, didYouMean(key, keys))
+		}
+
+		// Check that this option has not already been set
+		if _, ok := values[key]; ok {
+			return nil, fmt.Errorf("Duplicate option \"%s\"", key)
+		}
+		if t.Value == "" {
+			return nil, fmt.Errorf("Missing value for option \"%s\"", key)
 		}
 
-		// Check that the option fits it's specified format
-		if err := option.validate(value); err != nil {
-			return err
+		// Check that the option fits it's specified format and type
+		opt, err := option.parse(t.Value)
+		if err != nil {
+			return nil, err
 		}
+		values[key] = opt
+	}
 
-		// Check that this option has not already been set
-		if option.Value != "" {
-			return fmt.Errorf("Duplicate option \"%s\"", key)
+	// Check that we aren't missing any required options, and include
+	// options that weren't set so handlers can look them up by key
+	for key, option := range c.Options {
+		if _, ok := values[key]; ok {
+			continue
 		}
-		option.Value = value
+		if option.Required {
+			return nil, fmt.Errorf("Missing value for required option \"%s\"", option.Key)
+		}
+		values[key] = *option
 	}
+	return values, nil
+}
 
-	// Check that we aren't missing any required options
-	for _, option := range c.Options {
-		if option.Required && option.Value == "" {
-			return fmt.Errorf("Missing value for required option \"%s\"", option.Key)
+// resolveTeams replaces the placeholder teams of all set options of type
+// TeamOption with the corresponding teams from the context's TeamResolver.
+// Returns an error if a team does not exist.
+func resolveTeams(ctx Context, opts map[string]Option) error {
+	if ctx.ResolveTeam == nil {
+		return nil
+	}
+	for key, opt := range opts {
+		if opt.Type != TeamOption || !opt.IsSet() {
+			continue
+		}
+		team, err := ctx.ResolveTeam(opt.Value)
+		if err != nil {
+			return fmt.Errorf("Option \"%s\" must be the name of an existing "+
+				"team, but there is no team called \"%s\"", opt.Key, opt.Value)
 		}
+		opt.parsed = team
+		opts[key] = opt
 	}
 	return nil
 }
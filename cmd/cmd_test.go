@@ -1,9 +1,11 @@
 package cmd
 
 import (
+	"errors"
 	"regexp"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/nlopes/slack"
 	"github.com/stretchr/testify/assert"
@@ -109,3 +111,219 @@ func TestCommandHelp(t *testing.T) {
 	assert.Equal(t, res,
 		"Usage: `@rocket test OPTIONS`\n\nfake command with two options")
 }
+
+func getTypedTestCommand(ch CommandHandler) *Command {
+	return &Command{
+		Name:     "typed",
+		HelpText: "fake command with typed options",
+		Options: map[string]*Option{
+			"count":   &Option{Key: "count", Type: IntOption},
+			"flag":    &Option{Key: "flag", Type: BoolOption},
+			"colour":  &Option{Key: "colour", Type: EnumOption, Choices: []string{"red", "blue"}},
+			"user":    &Option{Key: "user", Type: UserOption},
+			"channel": &Option{Key: "channel", Type: ChannelOption},
+			"team":    &Option{Key: "team", Type: TeamOption},
+			"wait":    &Option{Key: "wait", Type: DurationOption},
+			"date":    &Option{Key: "date", Type: DateOption},
+			"tags":    &Option{Key: "tags", Type: ListOption},
+		},
+		HandleFunc: ch,
+	}
+}
+
+func TestCommandTypedOptions(t *testing.T) {
+	ctx := getTestContext("@rocket typed count={42} flag={yes} colour={BLUE} " +
+		"user={<@U5RU9TB38|rocket>} channel={<#C024BE7LR|general>} team={Rocket} " +
+		"wait={1h30m} date={2018-09-01} tags={go, react,,}")
+	ch := func(c Context) (string, slack.PostMessageParameters) {
+		ctx = c
+		return "", slack.PostMessageParameters{}
+	}
+	_, _, err := getTypedTestCommand(ch).Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, 42, ctx.Options["count"].Int())
+	assert.True(t, ctx.Options["flag"].Bool())
+	assert.Equal(t, "blue", ctx.Options["colour"].Value)
+	assert.Equal(t, "U5RU9TB38", ctx.Options["user"].UserID())
+	assert.Equal(t, "C024BE7LR", ctx.Options["channel"].ChannelID())
+	assert.Equal(t, "Rocket", ctx.Options["team"].Team().Name)
+	assert.Equal(t, 90*time.Minute, ctx.Options["wait"].Duration())
+	assert.Equal(t, time.Date(2018, 9, 1, 0, 0, 0, 0, time.UTC), ctx.Options["date"].Date())
+	assert.Equal(t, []string{"go", "react"}, ctx.Options["tags"].List())
+}
+
+func TestCommandUnsetTypedOptions(t *testing.T) {
+	ctx := getTestContext("@rocket typed")
+	ch := func(c Context) (string, slack.PostMessageParameters) {
+		ctx = c
+		return "", slack.PostMessageParameters{}
+	}
+	_, _, err := getTypedTestCommand(ch).Execute(ctx)
+	assert.Nil(t, err)
+	assert.False(t, ctx.Options["count"].IsSet())
+	assert.Equal(t, 0, ctx.Options["count"].Int())
+	assert.Equal(t, "", ctx.Options["user"].UserID())
+	assert.Nil(t, ctx.Options["team"].Team())
+	assert.Nil(t, ctx.Options["tags"].List())
+}
+
+func TestCommandInvalidTypedOptions(t *testing.T) {
+	tests := map[string]string{
+		"count={forty}":        "must be a whole number",
+		"flag={maybe}":         "must be true or false",
+		"colour={green}":       "must be one of red, blue",
+		"user={rocket}":        "must be a Slack user mention",
+		"user={<@U5RU9TB38}":   "must be a Slack user mention",
+		"channel={general}":    "must be a Slack channel",
+		"wait={forever}":       "must be a duration",
+		"date={September 1st}": "must be a date",
+	}
+	for opt, msg := range tests {
+		ctx := getTestContext("@rocket typed " + opt)
+		_, _, err := getTypedTestCommand(testHandler).Execute(ctx)
+		assert.NotNil(t, err, opt)
+		assert.True(t, strings.Contains(err.Error(), msg), err.Error())
+	}
+}
+
+func TestCommandResolveTeam(t *testing.T) {
+	ctx := getTestContext("@rocket typed team={Rocket}")
+	ctx.ResolveTeam = func(name string) (*model.Team, error) {
+		if name != "Rocket" {
+			return nil, errors.New("not found")
+		}
+		return &model.Team{Name: name, Platform: "Slack"}, nil
+	}
+	ch := func(c Context) (string, slack.PostMessageParameters) {
+		ctx = c
+		return "", slack.PostMessageParameters{}
+	}
+	_, _, err := getTypedTestCommand(ch).Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "Slack", ctx.Options["team"].Team().Platform)
+
+	ctx.Message.Text = "@rocket typed team={Rockette}"
+	_, _, err = getTypedTestCommand(ch).Execute(ctx)
+	assert.NotNil(t, err)
+	assert.True(t, strings.Contains(err.Error(), "no team called \"Rockette\""))
+}
+
+func TestCommandHelpTypedOptions(t *testing.T) {
+	cmd := getTestCommand(testHandler)
+	cmd.Options["optional"].Type = UserOption
+	_, params := cmd.Help()
+	assert.True(t, strings.Contains(params.Attachments[0].Text, "optional (@user)"))
+}
+
+func TestParseMention(t *testing.T) {
+	assert.Equal(t, "U5RU9TB38", ParseMention("<@U5RU9TB38>"))
+	assert.Equal(t, "U5RU9TB38", ParseMention("<@U5RU9TB38|rocket>"))
+	assert.Equal(t, "W012A3CDE", ParseMention("<@W012A3CDE>"))
+	assert.Equal(t, "", ParseMention("@rocket"))
+	assert.Equal(t, "", ParseMention("<#C024BE7LR>"))
+	assert.Equal(t, "C024BE7LR", ParseChannel("<#C024BE7LR|general>"))
+	assert.Equal(t, "", ParseChannel("general"))
+}
+
+func getTestCommandTree(ch CommandHandler) *Command {
+	sub := getTestCommand(ch)
+	sub.Name = "sub"
+	sub.Aliases = []string{"test-sub"}
+	return (&Command{
+		Name:     "test",
+		HelpText: "fake command with a subcommand",
+		Options:  map[string]*Option{},
+	}).AddSubcommands(sub)
+}
+
+func TestCommandSubcommand(t *testing.T) {
+	var ctx Context
+	ch := func(c Context) (string, slack.PostMessageParameters) {
+		ctx = c
+		return "sub", slack.PostMessageParameters{}
+	}
+	cmd := getTestCommandTree(ch)
+	assert.Equal(t, "test sub", cmd.Find("sub").FullName())
+	assert.Nil(t, cmd.Find("sub", "nope"))
+
+	res, _, err := cmd.Execute(getTestContext("@rocket test sub required={ayy}"))
+	assert.Nil(t, err)
+	assert.Equal(t, "sub", res)
+	assert.Equal(t, "ayy", ctx.Options["required"].Value)
+
+	// Subcommands can be invoked directly through their aliases
+	res, _, err = cmd.Find("sub").Execute(getTestContext("@rocket test-sub required={lmao}"))
+	assert.Nil(t, err)
+	assert.Equal(t, "lmao", ctx.Options["required"].Value)
+
+	// But not by their own name outside of their parent
+	_, _, err = cmd.Find("sub").Execute(getTestContext("@rocket sub required={lmao}"))
+	assert.NotNil(t, err)
+	assert.True(t, strings.Contains(err.Error(), "Invalid command"))
+}
+
+func TestCommandUnknownSubcommand(t *testing.T) {
+	cmd := getTestCommandTree(testHandler)
+	_, _, err := cmd.Execute(getTestContext("@rocket test nope"))
+	assert.NotNil(t, err)
+	assert.Equal(t, "Unknown subcommand \"nope\" for `test`", err.Error())
+}
+
+func TestCommandGroupHelp(t *testing.T) {
+	cmd := getTestCommandTree(testHandler)
+	res, params, err := cmd.Execute(getTestContext("@rocket test"))
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(res, "Usage: `@rocket test SUBCOMMAND`"))
+	assert.Equal(t, "Subcommands", params.Attachments[0].Title)
+
+	res, _ = cmd.Find("sub").Help()
+	assert.True(t, strings.HasPrefix(res, "Usage: `@rocket test sub OPTIONS`"))
+	assert.True(t, strings.Contains(res, "Also available as `test-sub`"))
+}
+
+func TestDestructiveCommand(t *testing.T) {
+	called := false
+	cmd := getTestCommand(func(ctx Context) (string, slack.PostMessageParameters) {
+		called = true
+		return "", slack.PostMessageParameters{}
+	})
+	cmd.Destructive = true
+	ctx := getTestContext("@rocket test required={everything}")
+
+	// Unconfirmed commands are not run
+	_, _, err := cmd.Execute(ctx)
+	assert.False(t, called)
+	confirm, ok := err.(*ConfirmationRequired)
+	assert.True(t, ok)
+	assert.Equal(t, cmd, confirm.Command)
+	assert.Equal(t, "This will run `test`", confirm.Summary)
+
+	// The summary can describe the options
+	cmd.Summary = func(ctx Context) string {
+		return "This will delete " + ctx.Options["required"].Value
+	}
+	_, _, err = cmd.Execute(ctx)
+	assert.Equal(t, "This will delete everything", err.(*ConfirmationRequired).Summary)
+
+	// Confirmed commands are
+	ctx.Confirmed = true
+	_, _, err = cmd.Execute(ctx)
+	assert.Nil(t, err)
+	assert.True(t, called)
+}
+
+func TestDestructiveCommandEmptySummary(t *testing.T) {
+	cmd := getTestCommand(testHandler)
+	cmd.Destructive = true
+	cmd.Summary = func(ctx Context) string { return "" }
+	_, _, err := cmd.Execute(getTestContext("@rocket test required={nothing}"))
+	assert.Nil(t, err)
+}
+
+func TestDestructiveCommandHelp(t *testing.T) {
+	cmd := getTestCommand(testHandler)
+	cmd.Destructive = true
+	res, _ := cmd.Help()
+	assert.Equal(t, "Usage: `@rocket test OPTIONS`\n\n"+
+		"fake command with two options\nAsks for confirmation before running", res)
+}
@@ -1,16 +1,35 @@
 package cmd
 
 import (
+	"context"
+
 	"github.com/nlopes/slack"
 	"github.com/ubclaunchpad/rocket/model"
 )
 
-// Context stores a Slack message and the user who sent it.
+// Context stores a Slack message and the user who sent it. It also carries
+// the deadline for handling the message, so it can be passed to anything that
+// accepts a `context.Context` (e.g. GitHub API calls).
 type Context struct {
+	context.Context
+
 	Message *slack.Msg
 	User    model.Member
 	Options map[string]Option
+
+	// ResolveTeam is used to look up the teams named by options of type
+	// TeamOption. If it is nil, team options are passed to the handler
+	// with only their name set.
+	ResolveTeam TeamResolver
+
+	// Confirmed is true if the user has confirmed that a destructive command
+	// should run.
+	Confirmed bool
 }
 
 // CommandHandler is the interface all handlers of Rocket commands must implement.
 type CommandHandler func(Context) (string, slack.PostMessageParameters)
+
+// TeamResolver returns the team with the given name, or an error if no such
+// team exists.
+type TeamResolver func(name string) (*model.Team, error)
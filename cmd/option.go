@@ -3,8 +3,70 @@ package cmd
 import (
 	"fmt"
 	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/ubclaunchpad/rocket/model"
 )
 
+// OptionType identifies the kind of value an option accepts. The `cmd`
+// framework uses it to validate values and convert them to Go types before a
+// command handler is called.
+type OptionType int
+
+const (
+	// StringOption accepts any string. This is the default option type.
+	StringOption OptionType = iota
+	// IntOption accepts whole numbers
+	IntOption
+	// BoolOption accepts true/false, yes/no and on/off
+	BoolOption
+	// EnumOption accepts one of the values listed in the option's `Choices`
+	EnumOption
+	// UserOption accepts a Slack user mention, e.g. @rocket
+	UserOption
+	// ChannelOption accepts a Slack channel mention, e.g. #general
+	ChannelOption
+	// TeamOption accepts the name of an existing Launch Pad team
+	TeamOption
+	// DurationOption accepts a duration, e.g. 1h30m
+	DurationOption
+	// DateOption accepts a date formatted as YYYY-MM-DD
+	DateOption
+	// ListOption accepts a comma-separated list of strings
+	ListOption
+)
+
+// DateFormat is the layout expected for values of options of type DateOption.
+const DateFormat = "2006-01-02"
+
+// String returns a human-readable name for the option type.
+func (t OptionType) String() string {
+	switch t {
+	case IntOption:
+		return "number"
+	case BoolOption:
+		return "true/false"
+	case EnumOption:
+		return "choice"
+	case UserOption:
+		return "@user"
+	case ChannelOption:
+		return "#channel"
+	case TeamOption:
+		return "team"
+	case DurationOption:
+		return "duration"
+	case DateOption:
+		return "date"
+	case ListOption:
+		return "list"
+	default:
+		return "text"
+	}
+}
+
 // Option represents a parameter that can be passed as part of a
 // Rocket command
 type Option struct {
@@ -13,17 +75,31 @@ type Option struct {
 	// create a command with one option who's key is `name`. In this case the
 	// user would assign a value to this key in their Slack command with
 	// `name={myvalue}`.
-	Key   string
+	Key string
+
+	// Value is the raw value the user entered for this option. It is only set
+	// on the copies of options handed to command handlers through
+	// `cmd.Context`. Use the typed accessors (`Int`, `Bool`, `UserID`, etc.)
+	// to get the converted value.
 	Value string
 
 	// HelpText is a description of what the option is used for.
 	HelpText string
 
-	// Format is a `regexp.Regexp` object that specifies the required format of
-	// a value for an option. The `cmd` framework will enforce that this format
-	// is met when a user enters a value for a given option, and will return an
-	// appropriate error response if this is not the case. Commonly used format
-	// `Regex`s can be found in [bot/util.go](bot/util.go).
+	// Type is the kind of value this option accepts. The `cmd` framework will
+	// reject values that can't be converted to this type and make the
+	// converted value available through the option's typed accessors.
+	Type OptionType
+
+	// Choices lists the values accepted by an option of type EnumOption.
+	// Matching is case-insensitive.
+	Choices []string
+
+	// Format is an optional `regexp.Regexp` object that specifies the required
+	// format of a value for an option. The `cmd` framework will enforce that
+	// this format is met when a user enters a value for a given option, and
+	// will return an appropriate error response if this is not the case.
+	// Commonly used format `Regex`s can be found in [cmd/util.go](cmd/util.go).
 	Format *regexp.Regexp
 
 	// Required defines whether or not a value for this option is required when
@@ -31,15 +107,176 @@ type Option struct {
 	// is set for each required option when a user enters a command, and will
 	// return an appropriate error if this is not the case.
 	Required bool
+
+	// parsed holds the value converted to the Go type matching `Type`
+	parsed interface{}
+}
+
+// IsSet returns true if the user entered a value for this option.
+func (o Option) IsSet() bool {
+	return o.Value != ""
+}
+
+// Int returns the value of an option of type IntOption.
+func (o Option) Int() int {
+	v, _ := o.parsed.(int)
+	return v
+}
+
+// Bool returns the value of an option of type BoolOption.
+func (o Option) Bool() bool {
+	v, _ := o.parsed.(bool)
+	return v
+}
+
+// UserID returns the Slack ID of the user mentioned in an option of type
+// UserOption.
+func (o Option) UserID() string {
+	v, _ := o.parsed.(string)
+	return v
 }
 
-// validate returns nil if the given value meets the format requirements for
-// this option, returns the validation error otherwise.
-func (o *Option) validate(value string) error {
+// ChannelID returns the Slack ID of the channel mentioned in an option of
+// type ChannelOption.
+func (o Option) ChannelID() string {
+	v, _ := o.parsed.(string)
+	return v
+}
+
+// Team returns the team named by an option of type TeamOption. The team is
+// only populated if the command was executed with a `TeamResolver` in its
+// context, otherwise only the team's name is set.
+func (o Option) Team() *model.Team {
+	v, _ := o.parsed.(*model.Team)
+	return v
+}
+
+// Duration returns the value of an option of type DurationOption.
+func (o Option) Duration() time.Duration {
+	v, _ := o.parsed.(time.Duration)
+	return v
+}
+
+// Date returns the value of an option of type DateOption.
+func (o Option) Date() time.Time {
+	v, _ := o.parsed.(time.Time)
+	return v
+}
+
+// List returns the values of an option of type ListOption.
+func (o Option) List() []string {
+	v, _ := o.parsed.([]string)
+	return v
+}
+
+// typeHint returns a short description of the values this option accepts
+// for use in help text, prefixed with the given separator. Returns an empty
+// string for plain string options.
+func (o *Option) typeHint(sep string) string {
+	switch o.Type {
+	case StringOption:
+		return ""
+	case EnumOption:
+		return sep + "one of " + strings.Join(o.Choices, ", ")
+	default:
+		return sep + o.Type.String()
+	}
+}
+
+// parse checks that the given value meets the requirements of this option
+// and returns a copy of the option holding the value and its conversion to
+// the option's type, or the validation error.
+func (o *Option) parse(value string) (Option, error) {
+	opt := *o
+	opt.Value = value
+
 	// Check that the value meets the required format
-	if !o.Format.MatchString(value) {
-		return fmt.Errorf("Invalid format for option \"%s\". "+
+	if o.Format != nil && !o.Format.MatchString(value) {
+		return opt, fmt.Errorf("Invalid format for option \"%s\". "+
 			"Format must match regular expression %s.", o.Key, o.Format.String())
 	}
-	return nil
+
+	switch o.Type {
+	case IntOption:
+		i, err := strconv.Atoi(value)
+		if err != nil {
+			return opt, fmt.Errorf("Option \"%s\" must be a whole number, "+
+				"but got \"%s\"", o.Key, value)
+		}
+		opt.parsed = i
+	case BoolOption:
+		b, err := parseBool(value)
+		if err != nil {
+			return opt, fmt.Errorf("Option \"%s\" must be true or false, "+
+				"but got \"%s\"", o.Key, value)
+		}
+		opt.parsed = b
+	case EnumOption:
+		choice := ""
+		for _, c := range o.Choices {
+			if strings.EqualFold(c, value) {
+				choice = c
+				break
+			}
+		}
+		if choice == "" {
+			return opt, fmt.Errorf("Option \"%s\" must be one of %s, "+
+				"but got \"%s\"", o.Key, strings.Join(o.Choices, ", "), value)
+		}
+		opt.Value = choice
+		opt.parsed = choice
+	case UserOption:
+		id := ParseMention(value)
+		if id == "" {
+			return opt, fmt.Errorf("Option \"%s\" must be a Slack user "+
+				"mention (e.g. @rocket), but got \"%s\"", o.Key, value)
+		}
+		opt.parsed = id
+	case ChannelOption:
+		id := ParseChannel(value)
+		if id == "" {
+			return opt, fmt.Errorf("Option \"%s\" must be a Slack channel "+
+				"(e.g. #general), but got \"%s\"", o.Key, value)
+		}
+		opt.parsed = id
+	case TeamOption:
+		// Teams are resolved against the database in Command.Execute
+		opt.parsed = &model.Team{Name: value}
+	case DurationOption:
+		d, err := time.ParseDuration(value)
+		if err != nil {
+			return opt, fmt.Errorf("Option \"%s\" must be a duration "+
+				"(e.g. 1h30m), but got \"%s\"", o.Key, value)
+		}
+		opt.parsed = d
+	case DateOption:
+		t, err := time.Parse(DateFormat, value)
+		if err != nil {
+			return opt, fmt.Errorf("Option \"%s\" must be a date formatted "+
+				"as YYYY-MM-DD, but got \"%s\"", o.Key, value)
+		}
+		opt.parsed = t
+	case ListOption:
+		items := []string{}
+		for _, item := range strings.Split(value, ",") {
+			if item = strings.TrimSpace(item); item != "" {
+				items = append(items, item)
+			}
+		}
+		opt.parsed = items
+	default:
+		opt.parsed = value
+	}
+	return opt, nil
+}
+
+// parseBool is like strconv.ParseBool, but also accepts yes/no and on/off.
+func parseBool(value string) (bool, error) {
+	switch strings.ToLower(value) {
+	case "yes", "y", "on":
+		return true, nil
+	case "no", "n", "off":
+		return false, nil
+	}
+	return strconv.ParseBool(value)
 }
@@ -0,0 +1,96 @@
+package cmd
+
+import (
+	"strings"
+
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// Permission decides whether the sender of a command may execute it. Commands
+// declare the permission they require in their `Permission` field, and the
+// `cmd` framework checks it before calling the command's handler. A command
+// with no permission can be used by anyone.
+type Permission interface {
+	// Allows returns true if the user in the given context may execute the
+	// command. Options (including teams) have already been parsed and
+	// resolved when this is called.
+	Allows(ctx Context) bool
+	// String describes who is allowed, e.g. "admins or tech leads". It is
+	// shown in help text and in the error sent to users who aren't allowed.
+	String() string
+}
+
+// Roles returns a permission that allows members who hold any of the given
+// roles.
+func Roles(roles ...model.Role) Permission {
+	return rolePermission(roles)
+}
+
+// TeamLead returns a permission that allows the leads of the team given by
+// the option with the given key, which must be of type TeamOption.
+func TeamLead(teamKey string) Permission {
+	return teamLeadPermission(teamKey)
+}
+
+// AnyOf returns a permission that allows anyone allowed by at least one of
+// the given permissions.
+func AnyOf(permissions ...Permission) Permission {
+	return anyOfPermission(permissions)
+}
+
+type rolePermission []model.Role
+
+func (p rolePermission) Allows(ctx Context) bool {
+	for _, role := range p {
+		if ctx.User.HasRole(role) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p rolePermission) String() string {
+	names := []string{}
+	for _, role := range p {
+		names = append(names, string(role)+"s")
+	}
+	return strings.Join(names, " or ")
+}
+
+type teamLeadPermission string
+
+func (p teamLeadPermission) Allows(ctx Context) bool {
+	team := ctx.Options[string(p)].Team()
+	if team == nil {
+		return false
+	}
+	for _, member := range team.Members {
+		if member.SlackID == ctx.User.SlackID {
+			return member.TeamRole == model.TeamRoleLead
+		}
+	}
+	return false
+}
+
+func (p teamLeadPermission) String() string {
+	return "leads of the team"
+}
+
+type anyOfPermission []Permission
+
+func (p anyOfPermission) Allows(ctx Context) bool {
+	for _, perm := range p {
+		if perm.Allows(ctx) {
+			return true
+		}
+	}
+	return false
+}
+
+func (p anyOfPermission) String() string {
+	names := []string{}
+	for _, perm := range p {
+		names = append(names, perm.String())
+	}
+	return strings.Join(names, " or ")
+}
@@ -0,0 +1,62 @@
+package cmd
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+func TestPermissionDenied(t *testing.T) {
+	cmd := getTestCommand(testHandler)
+	cmd.Permission = Roles(model.RoleAdmin, model.RoleTechLead)
+
+	ctx := getTestContext("@rocket test required={ayy}")
+	_, _, err := cmd.Execute(ctx)
+	assert.NotNil(t, err)
+	assert.Equal(t, "Only admins or tech leads can use `test`", err.Error())
+
+	ctx.User.IsTechLead = true
+	_, _, err = cmd.Execute(ctx)
+	assert.Nil(t, err)
+}
+
+func TestTeamLeadPermission(t *testing.T) {
+	cmd := getTypedTestCommand(testHandler)
+	cmd.Permission = AnyOf(Roles(model.RoleAdmin), TeamLead("team"))
+	lead := &model.Member{SlackID: "U1", TeamRole: model.TeamRoleLead}
+	developer := &model.Member{SlackID: "U2", IsTechLead: true, TeamRole: model.TeamRoleDeveloper}
+	ctx := getTestContext("@rocket typed team={Rocket}")
+	ctx.ResolveTeam = func(name string) (*model.Team, error) {
+		return &model.Team{Name: name, Members: []*model.Member{lead, developer}}, nil
+	}
+
+	// Leads of the team are allowed
+	ctx.User = model.Member{SlackID: "U1"}
+	_, _, err := cmd.Execute(ctx)
+	assert.Nil(t, err)
+
+	// Other members of the team are not, even if they lead other teams
+	ctx.User = model.Member{SlackID: "U2", IsTechLead: true}
+	_, _, err = cmd.Execute(ctx)
+	assert.NotNil(t, err)
+	assert.Equal(t, "Only admins or leads of the team can use `typed`", err.Error())
+
+	// Neither are people who aren't on the team
+	ctx.User = model.Member{SlackID: "U4", IsTechLead: true}
+	_, _, err = cmd.Execute(ctx)
+	assert.NotNil(t, err)
+
+	// Admins always are
+	ctx.User = model.Member{SlackID: "U3", IsAdmin: true}
+	_, _, err = cmd.Execute(ctx)
+	assert.Nil(t, err)
+}
+
+func TestPermissionHelp(t *testing.T) {
+	cmd := getTestCommand(testHandler)
+	cmd.Permission = Roles(model.RoleAdmin)
+	res, _ := cmd.Help()
+	assert.Equal(t, "Usage: `@rocket test OPTIONS`\n\n"+
+		"fake command with two options (admins only)", res)
+}
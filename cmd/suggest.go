@@ -0,0 +1,80 @@
+package cmd
+
+import "sort"
+
+// Suggest returns the candidate that is most similar to the given name, or
+// an empty string if none of the candidates are similar enough to be worth
+// suggesting. Similarity is measured by edit distance, where inserting,
+// deleting, substituting or swapping adjacent characters counts as one edit.
+func Suggest(name string, candidates []string) string {
+	// Allow roughly one typo for every three characters
+	maxDistance := len(name)/3 + 1
+	if maxDistance > 3 {
+		maxDistance = 3
+	}
+
+	// Sort candidates so ties are broken consistently
+	sorted := append([]string{}, candidates...)
+	sort.Strings(sorted)
+
+	best := ""
+	bestDistance := maxDistance + 1
+	for _, c := range sorted {
+		if d := editDistance(name, c); d < bestDistance {
+			best = c
+			bestDistance = d
+		}
+	}
+	return best
+}
+
+// didYouMean returns the end of an error message suggesting the candidate
+// most similar to the given name, or an empty string if there is no
+// suggestion.
+func didYouMean(name string, candidates []string) string {
+	if s := Suggest(name, candidates); s != "" {
+		return ". Did you mean `" + s + "`?"
+	}
+	return ""
+}
+
+// editDistance returns the optimal string alignment distance between the
+// given strings, which is the Levenshtein distance extended to count a
+// transposition of two adjacent characters as a single edit.
+func editDistance(a, b string) int {
+	s, t := []rune(a), []rune(b)
+	// d[i][j] is the distance between the first i runes of s and the first
+	// j runes of t
+	d := make([][]int, len(s)+1)
+	for i := range d {
+		d[i] = make([]int, len(t)+1)
+		d[i][0] = i
+	}
+	for j := range d[0] {
+		d[0][j] = j
+	}
+	for i := 1; i <= len(s); i++ {
+		for j := 1; j <= len(t); j++ {
+			cost := 1
+			if s[i-1] == t[j-1] {
+				cost = 0
+			}
+			d[i][j] = min(d[i-1][j]+1, d[i][j-1]+1, d[i-1][j-1]+cost)
+			if i > 1 && j > 1 && s[i-1] == t[j-2] && s[i-2] == t[j-1] {
+				d[i][j] = min(d[i][j], d[i-2][j-2]+1)
+			}
+		}
+	}
+	return d[len(s)][len(t)]
+}
+
+// min returns the smallest of the given integers.
+func min(first int, rest ...int) int {
+	m := first
+	for _, i := range rest {
+		if i < m {
+			m = i
+		}
+	}
+	return m
+}
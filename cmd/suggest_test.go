@@ -0,0 +1,38 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestEditDistance(t *testing.T) {
+	assert.Equal(t, 0, editDistance("view-team", "view-team"))
+	assert.Equal(t, 1, editDistance("vew-team", "view-team"))
+	assert.Equal(t, 1, editDistance("veiw-team", "view-team"))
+	assert.Equal(t, 2, editDistance("tams", "teams!"))
+	assert.Equal(t, 5, editDistance("", "teams"))
+}
+
+func TestSuggest(t *testing.T) {
+	commands := []string{"view-team", "view-user", "teams", "set", "help"}
+	assert.Equal(t, "view-team", Suggest("vew-team", commands))
+	assert.Equal(t, "view-user", Suggest("veiw-user", commands))
+	assert.Equal(t, "set", Suggest("st", commands))
+	assert.Equal(t, "", Suggest("refresh", commands))
+	assert.Equal(t, "", Suggest("x", commands))
+}
+
+func TestCommandUnrecognizedOptionSuggestion(t *testing.T) {
+	ctx := getTestContext("@rocket test requird={ayy}")
+	_, _, err := getTestCommand(testHandler).Execute(ctx)
+	assert.NotNil(t, err)
+	assert.Equal(t, "Unrecognized option requird. Did you mean `required`?", err.Error())
+}
+
+func TestCommandUnknownSubcommandSuggestion(t *testing.T) {
+	_, _, err := getTestCommandTree(testHandler).Execute(getTestContext("@rocket test sbu"))
+	assert.NotNil(t, err)
+	assert.True(t, strings.HasSuffix(err.Error(), "Did you mean `sub`?"))
+}
@@ -0,0 +1,159 @@
+package cmd
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// token is a single word or option assignment in a command message. Tokens
+// that were not of the form `key=value` have an empty key.
+type token struct {
+	Key   string
+	Value string
+}
+
+// tokenize splits a command message into tokens. It understands the
+// following forms, where values may be separated by any amount of whitespace:
+//
+//	word                  a positional argument
+//	"quoted words"        a positional argument containing spaces
+//	key=value             an option whose value contains no spaces
+//	key="quoted value"    an option whose value may contain spaces
+//	key={braced value}    an option whose value may contain spaces and quotes
+//
+// Inside quotes, \" and \\ are unescaped. Inside braces, \{, \} and \\ are
+// unescaped, and balanced pairs of braces are allowed. Curly quotes (as
+// inserted by some Slack clients) are treated like straight quotes.
+func tokenize(text string) ([]token, error) {
+	tokens := []token{}
+	runes := []rune(text)
+	i := 0
+	for {
+		// Skip whitespace between tokens
+		for i < len(runes) && unicode.IsSpace(runes[i]) {
+			i++
+		}
+		if i >= len(runes) {
+			return tokens, nil
+		}
+
+		// Check whether this token is an option assignment
+		key := ""
+		if k := scanKey(runes[i:]); k != "" {
+			key = k
+			i += len(k) + 1
+		}
+
+		// Read the value
+		var value string
+		var err error
+		switch {
+		case i < len(runes) && isOpenQuote(runes[i]):
+			value, i, err = scanQuoted(runes, i)
+		case i < len(runes) && runes[i] == '{' && key != "":
+			value, i, err = scanBraced(runes, i)
+		default:
+			start := i
+			for i < len(runes) && !unicode.IsSpace(runes[i]) {
+				i++
+			}
+			value = string(runes[start:i])
+		}
+		if err != nil {
+			if key != "" {
+				return nil, fmt.Errorf("%s for option \"%s\"", err.Error(), key)
+			}
+			return nil, err
+		}
+		if key != "" {
+			value = strings.TrimSpace(value)
+		}
+		tokens = append(tokens, token{Key: key, Value: value})
+	}
+}
+
+// scanKey returns the option key at the start of the given runes if they
+// start with an option assignment (i.e. "my-key="), or an empty string
+// otherwise.
+func scanKey(runes []rune) string {
+	for i, r := range runes {
+		switch {
+		case r == '=' && i > 0:
+			return string(runes[:i])
+		case r == '-' || (r >= 'a' && r <= 'z') || (r >= 'A' && r <= 'Z'):
+			continue
+		default:
+			return ""
+		}
+	}
+	return ""
+}
+
+// scanQuoted reads a quoted value starting at runes[start] and returns the
+// unquoted value and the index after the closing quote.
+func scanQuoted(runes []rune, start int) (string, int, error) {
+	closing := closingQuote(runes[start])
+	var b strings.Builder
+	for i := start + 1; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\' && i+1 < len(runes) && (runes[i+1] == closing || runes[i+1] == '\\'):
+			b.WriteRune(runes[i+1])
+			i++
+		case r == closing:
+			return b.String(), i + 1, nil
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return "", 0, fmt.Errorf("Missing closing quote")
+}
+
+// scanBraced reads a value in braces starting at runes[start] and returns
+// the value without its enclosing braces and the index after the closing
+// brace.
+func scanBraced(runes []rune, start int) (string, int, error) {
+	var b strings.Builder
+	depth := 0
+	for i := start + 1; i < len(runes); i++ {
+		r := runes[i]
+		switch {
+		case r == '\\' && i+1 < len(runes) && strings.ContainsRune(`{}\`, runes[i+1]):
+			b.WriteRune(runes[i+1])
+			i++
+		case r == '{':
+			depth++
+			b.WriteRune(r)
+		case r == '}' && depth == 0:
+			return b.String(), i + 1, nil
+		case r == '}':
+			depth--
+			b.WriteRune(r)
+		default:
+			b.WriteRune(r)
+		}
+	}
+	return "", 0, fmt.Errorf("Missing closing }")
+}
+
+// Quote returns the given value in quotes, escaping it so that it is
+// tokenized as a single value, e.g. when building a command to run.
+func Quote(value string) string {
+	value = strings.Replace(value, `\`, `\\`, -1)
+	value = strings.Replace(value, `"`, `\"`, -1)
+	return `"` + value + `"`
+}
+
+// isOpenQuote returns true if the given rune opens a quoted value.
+func isOpenQuote(r rune) bool {
+	return r == '"' || r == '“'
+}
+
+// closingQuote returns the rune that closes a value opened by the given quote.
+func closingQuote(open rune) rune {
+	if open == '“' {
+		return '”'
+	}
+	return open
+}
@@ -0,0 +1,121 @@
+package cmd
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/nlopes/slack"
+	"github.com/stretchr/testify/assert"
+)
+
+func TestTokenize(t *testing.T) {
+	tests := []struct {
+		text   string
+		tokens []token
+	}{
+		{
+			"@rocket test",
+			[]token{{Value: "@rocket"}, {Value: "test"}},
+		},
+		{
+			"a   b\tc\n",
+			[]token{{Value: "a"}, {Value: "b"}, {Value: "c"}},
+		},
+		{
+			"key={ braced value } other-key={a=b}",
+			[]token{{"key", "braced value"}, {"other-key", "a=b"}},
+		},
+		{
+			`key={a \} b \{ c \\ d} nested={a {b} c}`,
+			[]token{{"key", `a } b { c \ d`}, {"nested", "a {b} c"}},
+		},
+		{
+			`key="quoted value" other="say \"hi\"" arg "positional arg"`,
+			[]token{{"key", "quoted value"}, {"other", `say "hi"`},
+				{Value: "arg"}, {Value: "positional arg"}},
+		},
+		{
+			"key=value other=“curly quotes”",
+			[]token{{"key", "value"}, {"other", "curly quotes"}},
+		},
+		{
+			"{not-an-option} =nokey",
+			[]token{{Value: "{not-an-option}"}, {Value: "=nokey"}},
+		},
+	}
+	for _, tt := range tests {
+		tokens, err := tokenize(tt.text)
+		assert.Nil(t, err, tt.text)
+		assert.Equal(t, tt.tokens, tokens, tt.text)
+	}
+}
+
+func TestTokenizeUnterminated(t *testing.T) {
+	_, err := tokenize("@rocket test key={oops")
+	assert.NotNil(t, err)
+	assert.Equal(t, "Missing closing } for option \"key\"", err.Error())
+
+	_, err = tokenize(`@rocket test "oops`)
+	assert.NotNil(t, err)
+	assert.Equal(t, "Missing closing quote", err.Error())
+}
+
+func TestQuote(t *testing.T) {
+	for _, value := range []string{"Rocket", "Rocket Devs", `say "hi"`, `back\slash"`} {
+		tokens, err := tokenize("team=" + Quote(value) + " " + Quote(value))
+		assert.Nil(t, err)
+		assert.Equal(t, []token{{"team", value}, {Value: value}}, tokens)
+	}
+}
+
+func TestCommandPositionalArgs(t *testing.T) {
+	ctx := getTestContext(`@rocket test "gre at" optional="awes }ome"`)
+	ch := func(c Context) (string, slack.PostMessageParameters) {
+		ctx = c
+		return "", slack.PostMessageParameters{}
+	}
+	cmd := getTestCommand(ch)
+	cmd.Args = []string{"required", "optional"}
+	_, _, err := cmd.Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "gre at", ctx.Options["required"].Value)
+	assert.Equal(t, "awes }ome", ctx.Options["optional"].Value)
+
+	ctx = getTestContext("@rocket test first second")
+	_, _, err = cmd.Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "first", ctx.Options["required"].Value)
+	assert.Equal(t, "second", ctx.Options["optional"].Value)
+}
+
+func TestCommandUnexpectedArgument(t *testing.T) {
+	ctx := getTestContext("@rocket test required={ayy} leftover text")
+	cmd := getTestCommand(testHandler)
+	_, _, err := cmd.Execute(ctx)
+	assert.NotNil(t, err)
+	assert.Equal(t, "Unexpected argument \"leftover\"", err.Error())
+
+	// Positional arguments can't also be set by key
+	cmd.Args = []string{"required"}
+	ctx = getTestContext("@rocket test ayy required=lmao")
+	_, _, err = cmd.Execute(ctx)
+	assert.NotNil(t, err)
+	assert.True(t, strings.Contains(err.Error(), "Duplicate option"))
+}
+
+func TestCommandEmptyOptionValue(t *testing.T) {
+	ctx := getTestContext("@rocket test required=")
+	_, _, err := getTestCommand(testHandler).Execute(ctx)
+	assert.NotNil(t, err)
+	assert.True(t, strings.Contains(err.Error(), "Missing value for option"))
+}
+
+func TestCommandHelpPositionalArgs(t *testing.T) {
+	cmd := getTestCommand(testHandler)
+	cmd.Args = []string{"required"}
+	res, _ := cmd.Help()
+	assert.True(t, strings.HasPrefix(res, "Usage: `@rocket test REQUIRED OPTIONS`"))
+	cmd.Args = []string{"required", "optional"}
+	res, _ = cmd.Help()
+	assert.True(t, strings.HasPrefix(res, "Usage: `@rocket test REQUIRED [OPTIONAL]`"))
+}
@@ -9,21 +9,41 @@ var (
 	AlphaRegex = regexp.MustCompile("[a-zA-Z]")
 	// EmailRegex matches email addresses
 	EmailRegex = regexp.MustCompile("[a-zA-Z0-9._+]+@[a-zA-Z0-9._]+")
+
+	// mentionRegex matches encoded Slack user mentions, which may optionally
+	// include the user's display name (e.g. <@U5RU9TB38> or <@U5RU9TB38|rocket>)
+	mentionRegex = regexp.MustCompile(`^<@([UW][A-Z0-9]+)(?:\|[^>]*)?>$`)
+	// channelRegex matches encoded Slack channel mentions
+	// (e.g. <#C024BE7LR|general>)
+	channelRegex = regexp.MustCompile(`^<#([CG][A-Z0-9]+)(?:\|[^>]*)?>$`)
 )
 
 // ToMention converts a Slack username to a mention.
 // Slack encodes user mentions slightly differently in the message objects
 // that we receive from the RTM than they appear in the app. This function
-// converts a plain username ID (a 12-character string) to a correctly
-// formatted mention.
+// converts a plain username ID (e.g. U5RU9TB38) to a correctly formatted
+// mention.
 func ToMention(username string) string {
 	return "<@" + username + ">"
 }
 
-// ParseMention parses a mention and returns the ID of the user that was mentioned.
+// ParseMention parses a mention and returns the ID of the user that was
+// mentioned, or an empty string if the given string is not a mention.
 func ParseMention(mention string) string {
-	if len(mention) != 12 {
+	matches := mentionRegex.FindStringSubmatch(mention)
+	if matches == nil {
+		return ""
+	}
+	return matches[1]
+}
+
+// ParseChannel parses a channel mention and returns the ID of the channel
+// that was mentioned, or an empty string if the given string is not a
+// channel mention.
+func ParseChannel(channel string) string {
+	matches := channelRegex.FindStringSubmatch(channel)
+	if matches == nil {
 		return ""
 	}
-	return mention[2:11]
+	return matches[1]
 }
@@ -2,31 +2,73 @@ package config
 
 import (
 	"os"
+	"time"
 )
 
+// defaultShutdownTimeout is how long Rocket waits for in-flight work to finish
+// when shutting down if ROCKET_SHUTDOWNTIMEOUT is not set
+const defaultShutdownTimeout = 30 * time.Second
+
 // Config represents configuration options for the app.
 type Config struct {
-	Host             string
-	Port             string
-	SlackToken       string
-	GithubToken      string
-	PostgresHost     string
-	PostgresPort     string
-	PostgresUser     string
-	PostgresPass     string
-	PostgresDatabase string
+	Host                 string
+	Port                 string
+	SlackToken           string
+	SlackTransport       string
+	SlackSigningSecret   string
+	GithubToken          string
+	GithubAuth           string
+	GithubAppID          string
+	GithubInstallationID string
+	GithubAppKeyFile     string
+	GithubWebhookSecret  string
+	PostgresHost         string
+	PostgresPort         string
+	PostgresUser         string
+	PostgresPass         string
+	PostgresDatabase     string
+	Database             string
+	SQLitePath           string
+	ShutdownTimeout      time.Duration
+	AdminToken           string
+	SyncInterval         time.Duration
+	SyncChannel          string
+	SyncFix              string
 }
 
 // FromEnv creates and returns a configuration object from the environment.
 func FromEnv() *Config {
+	shutdownTimeout, err := time.ParseDuration(os.Getenv("ROCKET_SHUTDOWNTIMEOUT"))
+	if err != nil {
+		shutdownTimeout = defaultShutdownTimeout
+	}
+	// Reconciling GitHub memberships is disabled unless an interval is set
+	syncInterval, err := time.ParseDuration(os.Getenv("ROCKET_SYNCINTERVAL"))
+	if err != nil {
+		syncInterval = 0
+	}
 	return &Config{
-		Host:         os.Getenv("ROCKET_HOST"),
-		Port:         os.Getenv("ROCKET_PORT"),
-		SlackToken:   os.Getenv("ROCKET_SLACKTOKEN"),
-		GithubToken:  os.Getenv("ROCKET_GITHUBTOKEN"),
-		PostgresHost: os.Getenv("ROCKET_POSTGRESHOST"),
-		PostgresPort: os.Getenv("ROCKET_POSTGRESPORT"),
-		PostgresUser: os.Getenv("ROCKET_POSTGRESUSER"),
-		PostgresPass: os.Getenv("ROCKET_POSTGRESPASS"),
+		Host:                 os.Getenv("ROCKET_HOST"),
+		Port:                 os.Getenv("ROCKET_PORT"),
+		SlackToken:           os.Getenv("ROCKET_SLACKTOKEN"),
+		SlackTransport:       os.Getenv("ROCKET_SLACKTRANSPORT"),
+		SlackSigningSecret:   os.Getenv("ROCKET_SLACKSIGNINGSECRET"),
+		GithubToken:          os.Getenv("ROCKET_GITHUBTOKEN"),
+		GithubAuth:           os.Getenv("ROCKET_GITHUBAUTH"),
+		GithubAppID:          os.Getenv("ROCKET_GITHUBAPPID"),
+		GithubInstallationID: os.Getenv("ROCKET_GITHUBINSTALLATIONID"),
+		GithubAppKeyFile:     os.Getenv("ROCKET_GITHUBAPPKEYFILE"),
+		GithubWebhookSecret:  os.Getenv("ROCKET_GITHUBWEBHOOKSECRET"),
+		PostgresHost:         os.Getenv("ROCKET_POSTGRESHOST"),
+		PostgresPort:         os.Getenv("ROCKET_POSTGRESPORT"),
+		PostgresUser:         os.Getenv("ROCKET_POSTGRESUSER"),
+		PostgresPass:         os.Getenv("ROCKET_POSTGRESPASS"),
+		Database:             os.Getenv("ROCKET_DATABASE"),
+		SQLitePath:           os.Getenv("ROCKET_SQLITEPATH"),
+		ShutdownTimeout:      shutdownTimeout,
+		AdminToken:           os.Getenv("ROCKET_ADMINTOKEN"),
+		SyncInterval:         syncInterval,
+		SyncChannel:          os.Getenv("ROCKET_SYNCCHANNEL"),
+		SyncFix:              os.Getenv("ROCKET_SYNCFIX"),
 	}
 }
@@ -0,0 +1,44 @@
+package data
+
+import (
+	"time"
+
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// CreateAuditEntry appends the given entry to the audit log.
+func (dal *DAL) CreateAuditEntry(entry *model.AuditEntry) error {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now().UTC()
+	}
+	_, err := dal.db.Model(entry).
+		Returning("id").
+		Insert()
+	return err
+}
+
+// GetAuditEntries populates the given entries with the entries in the audit
+// log that match the given filter, newest first.
+func (dal *DAL) GetAuditEntries(filter AuditFilter, entries *model.AuditEntries) error {
+	q := dal.db.Model(entries).
+		Order("created_at DESC", "id DESC")
+	if filter.ActorSlackID != "" {
+		q = q.Where("actor_slack_id = ?", filter.ActorSlackID)
+	}
+	if filter.Action != "" {
+		q = q.Where("action = ?", filter.Action)
+	}
+	if filter.Target != "" {
+		q = q.Where("target = ?", filter.Target)
+	}
+	if !filter.Since.IsZero() {
+		q = q.Where("created_at >= ?", filter.Since)
+	}
+	if !filter.Until.IsZero() {
+		q = q.Where("created_at < ?", filter.Until)
+	}
+	if filter.Limit > 0 {
+		q = q.Limit(filter.Limit)
+	}
+	return q.Select()
+}
@@ -0,0 +1,457 @@
+package data
+
+import (
+	"encoding/json"
+	"fmt"
+
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// AuditedStore is a Store that records every change made through it in the
+// audit log, on behalf of the member running a command. Changes that don't
+// change anything aren't recorded.
+type AuditedStore struct {
+	Store
+	actorSlackID string
+	command      string
+}
+
+// NewAuditedStore returns a store that makes changes to the given store and
+// records them as made by the member with the given Slack ID running the
+// given command. The Slack ID is empty for changes Rocket makes on its own.
+func NewAuditedStore(s Store, actorSlackID, command string) *AuditedStore {
+	return &AuditedStore{
+		Store:        s,
+		actorSlackID: actorSlackID,
+		command:      command,
+	}
+}
+
+// RunInTransaction calls the given function with an AuditedStore whose
+// changes, and the entries recording them, are made in a transaction.
+func (s *AuditedStore) RunInTransaction(fn func(Store) error) error {
+	return s.Transaction(func(tx *AuditedStore) error { return fn(tx) })
+}
+
+// Transaction is like RunInTransaction, but calls the given function with an
+// AuditedStore.
+func (s *AuditedStore) Transaction(fn func(*AuditedStore) error) error {
+	return s.Store.RunInTransaction(func(tx Store) error {
+		return fn(NewAuditedStore(tx, s.actorSlackID, s.command))
+	})
+}
+
+// Record appends an entry for a change made outside of the store, e.g. on
+// GitHub, to the audit log. Before and after are stored as JSON, and should
+// be nil if the target didn't exist before or after the change.
+func (s *AuditedStore) Record(action, target string, before, after interface{}) error {
+	entry := &model.AuditEntry{
+		ActorSlackID: s.actorSlackID,
+		Command:      s.command,
+		Action:       action,
+		Target:       target,
+	}
+	var err error
+	if entry.Before, err = snapshot(before); err != nil {
+		return err
+	}
+	if entry.After, err = snapshot(after); err != nil {
+		return err
+	}
+	if entry.Before == entry.After {
+		return nil
+	}
+	if err := s.Store.CreateAuditEntry(entry); err != nil {
+		return fmt.Errorf("failed to record %s of %s in the audit log: %s",
+			action, target, err)
+	}
+	return nil
+}
+
+// snapshot returns the given value as JSON, or an empty string if it is nil.
+func snapshot(v interface{}) (string, error) {
+	if v == nil {
+		return "", nil
+	}
+	b, err := json.Marshal(v)
+	return string(b), err
+}
+
+// CreateMember adds the given member and records it.
+func (s *AuditedStore) CreateMember(member *model.Member) error {
+	return s.changeMember("member.create", member, s.Store.CreateMember)
+}
+
+// UpdateMember updates the given member and records it.
+func (s *AuditedStore) UpdateMember(member *model.Member) error {
+	return s.changeMember("member.update", member, s.Store.UpdateMember)
+}
+
+// DeleteMember deletes the given member and records it.
+func (s *AuditedStore) DeleteMember(member *model.Member) error {
+	return s.changeMember("member.delete", member, s.Store.DeleteMember)
+}
+
+// SetMemberName updates the name of the given member and records it.
+func (s *AuditedStore) SetMemberName(member *model.Member) error {
+	return s.changeMember("member.update", member, s.Store.SetMemberName)
+}
+
+// SetMemberEmail updates the email of the given member and records it.
+func (s *AuditedStore) SetMemberEmail(member *model.Member) error {
+	return s.changeMember("member.update", member, s.Store.SetMemberEmail)
+}
+
+// SetMemberGitHubUsername updates the GitHub username of the given member
+// and records it.
+func (s *AuditedStore) SetMemberGitHubUsername(member *model.Member) error {
+	return s.changeMember("member.update", member, s.Store.SetMemberGitHubUsername)
+}
+
+// SetMemberMajor updates the major of the given member and records it.
+func (s *AuditedStore) SetMemberMajor(member *model.Member) error {
+	return s.changeMember("member.update", member, s.Store.SetMemberMajor)
+}
+
+// SetMemberPosition updates the position of the given member and records it.
+func (s *AuditedStore) SetMemberPosition(member *model.Member) error {
+	return s.changeMember("member.update", member, s.Store.SetMemberPosition)
+}
+
+// SetMemberBiography updates the bio of the given member and records it.
+func (s *AuditedStore) SetMemberBiography(member *model.Member) error {
+	return s.changeMember("member.update", member, s.Store.SetMemberBiography)
+}
+
+// SetMemberImageURL updates the image URL of the given member and records
+// it.
+func (s *AuditedStore) SetMemberImageURL(member *model.Member) error {
+	return s.changeMember("member.update", member, s.Store.SetMemberImageURL)
+}
+
+// SetMemberIsAdmin updates whether the given member is an admin and records
+// it.
+func (s *AuditedStore) SetMemberIsAdmin(member *model.Member) error {
+	return s.changeMember("member.update", member, s.Store.SetMemberIsAdmin)
+}
+
+// SetMemberIsTechLead updates whether the given member is a tech lead and
+// records it.
+func (s *AuditedStore) SetMemberIsTechLead(member *model.Member) error {
+	return s.changeMember("member.update", member, s.Store.SetMemberIsTechLead)
+}
+
+// SetMemberLeftAt updates when the given member left Launch Pad and records
+// it.
+func (s *AuditedStore) SetMemberLeftAt(member *model.Member) error {
+	return s.changeMember("member.update", member, s.Store.SetMemberLeftAt)
+}
+
+// SetMemberSkills replaces the skills of the given member and records it.
+func (s *AuditedStore) SetMemberSkills(member *model.Member) error {
+	return s.changeMember("member.update", member, s.Store.SetMemberSkills)
+}
+
+// SetMemberInterests replaces the interests of the given member and records
+// it.
+func (s *AuditedStore) SetMemberInterests(member *model.Member) error {
+	return s.changeMember("member.update", member, s.Store.SetMemberInterests)
+}
+
+// changeMember makes the given change to the given member and records it.
+func (s *AuditedStore) changeMember(action string, member *model.Member, change func(*model.Member) error) error {
+	before := s.memberSnapshot(member.SlackID)
+	if err := change(member); err != nil {
+		return err
+	}
+	return s.Record(action, member.SlackID, before, s.memberSnapshot(member.SlackID))
+}
+
+// memberSnapshot returns the fields of the member with the given Slack ID,
+// or nil if there is no such member.
+func (s *AuditedStore) memberSnapshot(slackID string) interface{} {
+	m := &model.Member{SlackID: slackID}
+	if err := s.Store.GetMemberBySlackID(m); err != nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"slackId":        m.SlackID,
+		"name":           m.Name,
+		"email":          m.Email,
+		"githubUsername": m.GithubUsername,
+		"major":          m.Major,
+		"position":       m.Position,
+		"biography":      m.Biography,
+		"imageUrl":       m.ImageURL,
+		"isAdmin":        m.IsAdmin,
+		"isTechLead":     m.IsTechLead,
+		"leftAt":         m.LeftAt,
+		"skills":         m.Skills,
+		"interests":      m.Interests,
+	}
+}
+
+// CreateTeam adds the given team and records it.
+func (s *AuditedStore) CreateTeam(team *model.Team) error {
+	before := s.teamSnapshot(team.GithubTeamID)
+	if err := s.Store.CreateTeam(team); err != nil {
+		return err
+	}
+	return s.Record("team.create", team.Name, before, s.teamSnapshot(team.GithubTeamID))
+}
+
+// UpdateTeam updates the current team and records it.
+func (s *AuditedStore) UpdateTeam(currentTeam, newTeam *model.Team) error {
+	name := currentTeam.Name
+	before := s.teamSnapshot(currentTeam.GithubTeamID)
+	if err := s.Store.UpdateTeam(currentTeam, newTeam); err != nil {
+		return err
+	}
+	return s.Record("team.update", name, before, s.teamSnapshot(currentTeam.GithubTeamID))
+}
+
+// DeleteTeamByName deletes the team with the given team's name and records
+// it.
+func (s *AuditedStore) DeleteTeamByName(team *model.Team) error {
+	existing := &model.Team{Name: team.Name}
+	if err := s.Store.GetTeamByName(existing); err != nil {
+		if err == ErrNotFound {
+			return s.Store.DeleteTeamByName(team)
+		}
+		return err
+	}
+	before := s.teamSnapshot(existing.GithubTeamID)
+	if err := s.Store.DeleteTeamByName(team); err != nil {
+		return err
+	}
+	return s.Record("team.delete", team.Name, before, s.teamSnapshot(existing.GithubTeamID))
+}
+
+// UnlinkTeam detaches the team with the given team's GitHub team ID from its
+// GitHub team and records it.
+func (s *AuditedStore) UnlinkTeam(team *model.Team) error {
+	existing := &model.Team{GithubTeamID: team.GithubTeamID}
+	if err := s.Store.GetTeamByGithubID(existing); err != nil {
+		return err
+	}
+	before := s.teamSnapshot(existing.GithubTeamID)
+	if err := s.Store.UnlinkTeam(team); err != nil {
+		return err
+	}
+	return s.Record("team.unlink", existing.Name, before, s.teamSnapshot(team.GithubTeamID))
+}
+
+// teamSnapshot returns the fields of the team with the given GitHub team ID,
+// or nil if there is no such team.
+func (s *AuditedStore) teamSnapshot(githubTeamID int) interface{} {
+	t := &model.Team{GithubTeamID: githubTeamID}
+	if err := s.Store.GetTeamByGithubID(t); err != nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"name":         t.Name,
+		"githubTeamId": t.GithubTeamID,
+		"platform":     t.Platform,
+	}
+}
+
+// CreateTeamMember adds a member to a team and records it.
+func (s *AuditedStore) CreateTeamMember(member *model.TeamMember) error {
+	return s.changeTeamMember("team_member.create", member, s.Store.CreateTeamMember)
+}
+
+// SetTeamMemberRole updates the role of a member on a team and records it.
+func (s *AuditedStore) SetTeamMemberRole(member *model.TeamMember) error {
+	return s.changeTeamMember("team_member.update", member, s.Store.SetTeamMemberRole)
+}
+
+// DeleteTeamMember removes a member from a team and records it.
+func (s *AuditedStore) DeleteTeamMember(member *model.TeamMember) error {
+	return s.changeTeamMember("team_member.delete", member, s.Store.DeleteTeamMember)
+}
+
+// changeTeamMember makes the given change to the given team member and
+// records it. Team memberships are recorded with the member's Slack ID as
+// their target.
+func (s *AuditedStore) changeTeamMember(action string, member *model.TeamMember, change func(*model.TeamMember) error) error {
+	before := s.teamMemberSnapshot(member)
+	if err := change(member); err != nil {
+		return err
+	}
+	return s.Record(action, member.MemberSlackID, before, s.teamMemberSnapshot(member))
+}
+
+// teamMemberSnapshot returns the team, term and role of the given team
+// member, or nil if the member isn't on the team in the term.
+func (s *AuditedStore) teamMemberSnapshot(member *model.TeamMember) interface{} {
+	t := s.teamInTerm(member.GithubTeamID, member.Term)
+	if t == nil {
+		return nil
+	}
+	for _, m := range t.Members {
+		if m.SlackID == member.MemberSlackID {
+			return map[string]interface{}{
+				"team":         t.Name,
+				"githubTeamId": t.GithubTeamID,
+				"term":         t.term,
+				"role":         m.TeamRole,
+			}
+		}
+	}
+	return nil
+}
+
+// CreateTerm adds the given term and records it.
+func (s *AuditedStore) CreateTerm(term *model.Term) error {
+	before := s.termSnapshot(term.Code)
+	if err := s.Store.CreateTerm(term); err != nil {
+		return err
+	}
+	return s.Record("term.create", term.Code, before, s.termSnapshot(term.Code))
+}
+
+// termSnapshot returns the fields of the term with the given code, or nil if
+// there is no such term.
+func (s *AuditedStore) termSnapshot(code string) interface{} {
+	terms := model.Terms{}
+	if err := s.Store.GetTerms(&terms); err != nil {
+		return nil
+	}
+	for _, t := range terms {
+		if t.Code == code {
+			return map[string]interface{}{
+				"code":      t.Code,
+				"startedAt": t.StartedAt,
+			}
+		}
+	}
+	return nil
+}
+
+// AddTeamToTerm runs the given team in the given term and records it.
+func (s *AuditedStore) AddTeamToTerm(team *model.Team, term string) error {
+	before := s.teamTermSnapshot(team.GithubTeamID, term)
+	if err := s.Store.AddTeamToTerm(team, term); err != nil {
+		return err
+	}
+	return s.Record("team_term.create", team.Name, before,
+		s.teamTermSnapshot(team.GithubTeamID, term))
+}
+
+// RemoveTeamFromTerm stops running the given team in the given term and
+// records it.
+func (s *AuditedStore) RemoveTeamFromTerm(team *model.Team, term string) error {
+	before := s.teamTermSnapshot(team.GithubTeamID, term)
+	if err := s.Store.RemoveTeamFromTerm(team, term); err != nil {
+		return err
+	}
+	return s.Record("team_term.delete", team.Name, before,
+		s.teamTermSnapshot(team.GithubTeamID, term))
+}
+
+// teamTermSnapshot returns the given term and the team with the given GitHub
+// team ID's members in it and their roles, or nil if the team isn't running
+// in the term.
+func (s *AuditedStore) teamTermSnapshot(githubTeamID int, term string) interface{} {
+	t := s.teamInTerm(githubTeamID, term)
+	if t == nil {
+		return nil
+	}
+	roles := map[string]model.TeamRole{}
+	for _, m := range t.Members {
+		roles[m.SlackID] = m.TeamRole
+	}
+	return map[string]interface{}{
+		"team":         t.Name,
+		"githubTeamId": t.GithubTeamID,
+		"term":         t.term,
+		"members":      roles,
+	}
+}
+
+// termTeam is a team with its members in a term.
+type termTeam struct {
+	*model.Team
+	term string
+}
+
+// teamInTerm returns the team with the given GitHub team ID and its members
+// in the given term, or in the current term if it's empty, or nil if the
+// team isn't running in the term.
+func (s *AuditedStore) teamInTerm(githubTeamID int, term string) *termTeam {
+	if term == "" {
+		current := &model.Term{}
+		if err := s.Store.GetCurrentTerm(current); err != nil {
+			return nil
+		}
+		term = current.Code
+	}
+	teams := model.Teams{}
+	if err := s.Store.GetTeamsByTerm(term, &teams); err != nil {
+		return nil
+	}
+	for _, t := range teams {
+		if t.GithubTeamID == githubTeamID {
+			return &termTeam{Team: t, term: term}
+		}
+	}
+	return nil
+}
+
+// SaveProject adds or replaces the given project and records it. Projects
+// are recorded with the name of their team as their target.
+func (s *AuditedStore) SaveProject(project *model.Project) error {
+	before := s.project(project.GithubTeamID)
+	if err := s.Store.SaveProject(project); err != nil {
+		return err
+	}
+	after := s.project(project.GithubTeamID)
+	if after == nil {
+		return fmt.Errorf("failed to record project of team %d in the audit log: "+
+			"it can't be found", project.GithubTeamID)
+	}
+	action := "project.update"
+	if before == nil {
+		action = "project.create"
+	}
+	return s.Record(action, after.Team, projectSnapshot(before), projectSnapshot(after))
+}
+
+// DeleteProject deletes the project of the given project's team and records
+// it.
+func (s *AuditedStore) DeleteProject(project *model.Project) error {
+	before := s.project(project.GithubTeamID)
+	if err := s.Store.DeleteProject(project); err != nil || before == nil {
+		return err
+	}
+	return s.Record("project.delete", before.Team, projectSnapshot(before),
+		projectSnapshot(s.project(project.GithubTeamID)))
+}
+
+// project returns the project of the team with the given GitHub team ID, or
+// nil if it doesn't have one.
+func (s *AuditedStore) project(githubTeamID int) *model.Project {
+	p := &model.Project{GithubTeamID: githubTeamID}
+	if err := s.Store.GetProjectByTeam(p); err != nil {
+		return nil
+	}
+	return p
+}
+
+// projectSnapshot returns the fields of the given project except when it was
+// last updated, or nil if it's nil.
+func projectSnapshot(p *model.Project) interface{} {
+	if p == nil {
+		return nil
+	}
+	return map[string]interface{}{
+		"team":         p.Team,
+		"githubTeamId": p.GithubTeamID,
+		"name":         p.Name,
+		"description":  p.Description,
+		"repos":        p.Repos,
+		"website":      p.Website,
+		"status":       p.Status,
+		"techStack":    p.TechStack,
+	}
+}
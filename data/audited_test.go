@@ -0,0 +1,100 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+func TestAuditedStore(t *testing.T) {
+	store := NewMemoryStore()
+	assert.Nil(t, store.CreateTerm(&model.Term{Code: "2030W"}))
+	s := NewAuditedStore(store, "U1", "team add Rocket")
+
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U2", Name: "Bruno"}))
+	assert.Nil(t, s.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1}))
+	assert.Nil(t, s.CreateTeamMember(&model.TeamMember{GithubTeamID: 1, MemberSlackID: "U2"}))
+	assert.Nil(t, s.SetMemberIsAdmin(&model.Member{SlackID: "U2", IsAdmin: true}))
+	// Changes that don't change anything aren't recorded
+	assert.Nil(t, s.SetMemberName(&model.Member{SlackID: "U2", Name: "Bruno"}))
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U2", Name: "Bruno"}))
+	assert.Nil(t, s.UpdateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1},
+		&model.Team{Name: "Rocket 2"}))
+	assert.Nil(t, s.DeleteTeamByName(&model.Team{Name: "Rocket 2"}))
+	assert.Nil(t, s.Record("github.remove_team", "Rocket 2", map[string]int{"githubTeamId": 1}, nil))
+
+	var entries model.AuditEntries
+	assert.Nil(t, store.GetAuditEntries(AuditFilter{}, &entries))
+	actions := []string{}
+	for _, e := range entries {
+		assert.Equal(t, "U1", e.ActorSlackID)
+		assert.Equal(t, "team add Rocket", e.Command)
+		actions = append(actions, e.Action)
+	}
+	assert.Equal(t, []string{
+		"github.remove_team",
+		"team.delete",
+		"team.update",
+		"member.update",
+		"team_member.create",
+		"team.create",
+		"member.create",
+	}, actions)
+	if len(entries) != 7 {
+		return
+	}
+
+	assert.Equal(t, "U2", entries[3].Target)
+	assert.Contains(t, entries[3].Before, `"isAdmin":false`)
+	assert.Contains(t, entries[3].After, `"isAdmin":true`)
+	assert.Equal(t, "Rocket", entries[2].Target)
+	assert.Contains(t, entries[2].After, `"name":"Rocket 2"`)
+	assert.Equal(t, "", entries[1].After)
+	assert.Equal(t, `{"githubTeamId":1,"role":"developer","team":"Rocket","term":"2030W"}`, entries[4].After)
+	assert.Equal(t, "", entries[6].Before)
+}
+
+func TestAuditedStoreProjects(t *testing.T) {
+	store := NewMemoryStore()
+	s := NewAuditedStore(store, "U1", "project edit Rocket")
+	assert.Nil(t, store.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1}))
+
+	project := &model.Project{GithubTeamID: 1, Name: "Rocket"}
+	assert.Nil(t, s.SaveProject(project))
+	// Saving a project without changing it isn't recorded
+	assert.Nil(t, s.SaveProject(project))
+	project.Status = model.ProjectStatusActive
+	assert.Nil(t, s.SaveProject(project))
+	assert.Nil(t, s.DeleteProject(project))
+	assert.Nil(t, s.DeleteProject(project))
+
+	var entries model.AuditEntries
+	assert.Nil(t, store.GetAuditEntries(AuditFilter{Target: "Rocket"}, &entries))
+	actions := []string{}
+	for _, e := range entries {
+		actions = append(actions, e.Action)
+	}
+	assert.Equal(t, []string{"project.delete", "project.update", "project.create"}, actions)
+	if len(entries) == 3 {
+		assert.Contains(t, entries[1].After, `"status":"active"`)
+	}
+}
+
+func TestAuditedStoreTags(t *testing.T) {
+	store := NewMemoryStore()
+	s := NewAuditedStore(store, "U1", "set skills={React}")
+	assert.Nil(t, store.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno"}))
+
+	assert.Nil(t, s.SetMemberSkills(&model.Member{SlackID: "U1", Skills: []string{"React"}}))
+	// Setting the same tags again isn't recorded
+	assert.Nil(t, s.SetMemberSkills(&model.Member{SlackID: "U1", Skills: []string{"react"}}))
+
+	var entries model.AuditEntries
+	assert.Nil(t, store.GetAuditEntries(AuditFilter{}, &entries))
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "member.update", entries[0].Action)
+		assert.Contains(t, entries[0].Before, `"skills":[]`)
+		assert.Contains(t, entries[0].After, `"skills":["react"]`)
+	}
+}
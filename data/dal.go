@@ -16,8 +16,22 @@ type DAL struct {
 	db orm.DB
 }
 
-// New returns a new DAL instance based on a configuration object.
+var _ Store = &DAL{}
+
+// New returns a new DAL instance based on a configuration object, and
+// applies any migrations that have not been applied to the database yet.
+// This will exit if the database schema is newer than this version of Rocket.
 func New(c *config.Config) *DAL {
+	dal := Connect(c)
+	if err := migrate(dal); err != nil {
+		log.WithError(err).Fatal("Error migrating the database")
+	}
+	return dal
+}
+
+// Connect returns a new DAL instance based on a configuration object without
+// migrating the database.
+func Connect(c *config.Config) *DAL {
 	opts := &pg.Options{
 		Addr:            c.PostgresHost + ":" + c.PostgresPort,
 		User:            c.PostgresUser,
@@ -54,3 +68,42 @@ func (dal *DAL) Close() error {
 	}
 	return database.Close()
 }
+
+// RunInTransaction calls the given function with a DAL whose queries run in
+// a transaction, which is committed if the function returns nil and rolled
+// back otherwise. If the DAL is already in a transaction, the function's
+// changes are made in a savepoint instead.
+func (dal *DAL) RunInTransaction(fn func(Store) error) error {
+	db, ok := dal.db.(*pg.DB)
+	if !ok {
+		return dal.runInSavepoint(fn)
+	}
+	return db.RunInTransaction(func(tx *pg.Tx) error {
+		return fn(&DAL{tx})
+	})
+}
+
+// runInSavepoint calls the given function with the DAL, and rolls back the
+// changes it made if it returns an error. The DAL must be in a transaction.
+func (dal *DAL) runInSavepoint(fn func(Store) error) error {
+	if _, err := dal.db.Exec("SAVEPOINT rocket"); err != nil {
+		return err
+	}
+	if err := fn(dal); err != nil {
+		if _, rbErr := dal.db.Exec("ROLLBACK TO SAVEPOINT rocket"); rbErr != nil {
+			log.WithError(rbErr).Error("Failed to roll back to savepoint")
+		}
+		return err
+	}
+	_, err := dal.db.Exec("RELEASE SAVEPOINT rocket")
+	return err
+}
+
+// notFound returns ErrNotFound if the given error means that a query found no
+// rows, and the given error otherwise.
+func notFound(err error) error {
+	if err == pg.ErrNoRows {
+		return ErrNotFound
+	}
+	return err
+}
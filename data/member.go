@@ -5,34 +5,65 @@ import "github.com/ubclaunchpad/rocket/model"
 // GetMemberBySlackID populates the given member with information from the DB
 // or returns an error.
 func (dal *DAL) GetMemberBySlackID(member *model.Member) error {
-	return dal.db.Model(member).
+	err := dal.db.Model(member).
 		Where("slack_id = ?slack_id").
 		Select()
+	if err != nil {
+		return notFound(err)
+	}
+	return dal.setTags(member)
 }
 
 // GetMembers populates the given members with information for all members from
 // the DB or returns an error.
 func (dal *DAL) GetMembers(members *model.Members) error {
-	return dal.db.Model(members).
+	err := dal.db.Model(members).
+		Where("left_at IS NULL").
+		Order("name ASC").
+		Select()
+	if err != nil {
+		return err
+	}
+	return dal.setTags(*members...)
+}
+
+// GetAllMembers populates the given members with information for all members
+// including alumni from the DB or returns an error.
+func (dal *DAL) GetAllMembers(members *model.Members) error {
+	err := dal.db.Model(members).
 		Order("name ASC").
 		Select()
+	if err != nil {
+		return err
+	}
+	return dal.setTags(*members...)
 }
 
 // GetTechLeads populates given members with all current tech leads
 func (dal *DAL) GetTechLeads(members *model.Members) error {
-	return dal.db.Model(members).
+	err := dal.db.Model(members).
 		Where("is_tech_lead = 't'").
+		Where("left_at IS NULL").
 		Order("name ASC").
 		Select()
+	if err != nil {
+		return err
+	}
+	return dal.setTags(*members...)
 }
 
 // GetAdmins populates the given members with information for all admin members
 // or returns an error.
 func (dal *DAL) GetAdmins(members *model.Members) error {
-	return dal.db.Model(members).
+	err := dal.db.Model(members).
 		Where("is_admin = 't'").
+		Where("left_at IS NULL").
 		Order("name ASC").
 		Select()
+	if err != nil {
+		return err
+	}
+	return dal.setTags(*members...)
 }
 
 // CreateMember adds the given member to the DB or returns an error.
@@ -179,3 +210,14 @@ func (dal *DAL) SetMemberIsTechLead(member *model.Member) error {
 
 	return err
 }
+
+// SetMemberLeftAt updates when the given member left Launch Pad in the DB or
+// returns an error.
+func (dal *DAL) SetMemberLeftAt(member *model.Member) error {
+	_, err := dal.db.Model(member).
+		WherePK().
+		Set("left_at = ?left_at").
+		Update()
+
+	return err
+}
@@ -0,0 +1,894 @@
+package data
+
+import (
+	"errors"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// MemoryStore is a Store that keeps members and teams in memory. It is used
+// to run and test Rocket without a database.
+type MemoryStore struct {
+	mu sync.RWMutex
+	// txMu is held while a transaction runs, so that transactions run one at
+	// a time
+	txMu    sync.Mutex
+	members map[string]model.Member
+	teams   map[int]model.Team
+	terms   map[string]model.Term
+	// teamMembers maps the teams running in each term to the Slack IDs of
+	// their members in the term and their roles on the team
+	teamMembers map[teamTerm]map[string]model.TeamRole
+	// projects maps GitHub team IDs to the projects of the teams
+	projects map[int]model.Project
+	// tags maps Slack IDs to the names of the tags of each kind on the
+	// members' profiles, sorted by name
+	tags  map[string]map[model.TagKind][]string
+	audit []model.AuditEntry
+}
+
+var _ Store = &MemoryStore{}
+
+// NewMemoryStore returns a MemoryStore with no members or teams. Like a
+// freshly migrated database, it has one term, the term it was created in.
+func NewMemoryStore() *MemoryStore {
+	now := time.Now()
+	code := model.TermAt(now)
+	return &MemoryStore{
+		members:     map[string]model.Member{},
+		teams:       map[int]model.Team{},
+		terms:       map[string]model.Term{code: model.Term{Code: code, StartedAt: now}},
+		teamMembers: map[teamTerm]map[string]model.TeamRole{},
+		projects:    map[int]model.Project{},
+		tags:        map[string]map[model.TagKind][]string{},
+	}
+}
+
+// Ping does nothing, because memory can always be reached.
+func (s *MemoryStore) Ping() error {
+	return nil
+}
+
+// Close does nothing.
+func (s *MemoryStore) Close() error {
+	return nil
+}
+
+// RunInTransaction calls the given function with the store, and restores
+// what was stored before it was called if it returns an error. Transactions
+// run one at a time, but changes made outside a transaction while it runs
+// are discarded with its own changes if it fails.
+func (s *MemoryStore) RunInTransaction(fn func(Store) error) error {
+	s.txMu.Lock()
+	defer s.txMu.Unlock()
+	return memoryTx{s}.RunInTransaction(fn)
+}
+
+// memoryTx is a MemoryStore in a transaction. Transactions nested in it are
+// run without waiting for it to finish.
+type memoryTx struct {
+	*MemoryStore
+}
+
+// RunInTransaction calls the given function with the transaction's store,
+// and restores what was stored before it was called if it returns an error.
+func (tx memoryTx) RunInTransaction(fn func(Store) error) error {
+	snapshot := tx.snapshot()
+	if err := fn(tx); err != nil {
+		tx.mu.Lock()
+		tx.restore(snapshot)
+		tx.mu.Unlock()
+		return err
+	}
+	return nil
+}
+
+// snapshot returns a copy of everything stored that shares no maps with the
+// store.
+func (s *MemoryStore) snapshot() *MemoryStore {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	c := &MemoryStore{
+		members:     map[string]model.Member{},
+		teams:       map[int]model.Team{},
+		terms:       map[string]model.Term{},
+		teamMembers: map[teamTerm]map[string]model.TeamRole{},
+		projects:    map[int]model.Project{},
+		tags:        map[string]map[model.TagKind][]string{},
+		audit:       append([]model.AuditEntry{}, s.audit...),
+	}
+	for k, v := range s.members {
+		c.members[k] = v
+	}
+	for k, v := range s.teams {
+		c.teams[k] = v
+	}
+	for k, v := range s.terms {
+		c.terms[k] = v
+	}
+	for k, v := range s.teamMembers {
+		c.teamMembers[k] = map[string]model.TeamRole{}
+		for slackID, role := range v {
+			c.teamMembers[k][slackID] = role
+		}
+	}
+	for k, v := range s.projects {
+		c.projects[k] = v
+	}
+	for k, v := range s.tags {
+		c.tags[k] = map[model.TagKind][]string{}
+		for kind, names := range v {
+			c.tags[k][kind] = names
+		}
+	}
+	return c
+}
+
+// restore replaces everything stored with the given snapshot. The store must
+// be locked.
+func (s *MemoryStore) restore(snapshot *MemoryStore) {
+	s.members = snapshot.members
+	s.teams = snapshot.teams
+	s.terms = snapshot.terms
+	s.teamMembers = snapshot.teamMembers
+	s.projects = snapshot.projects
+	s.tags = snapshot.tags
+	s.audit = snapshot.audit
+}
+
+// GetMemberBySlackID populates the given member with the stored member with
+// the same Slack ID.
+func (s *MemoryStore) GetMemberBySlackID(member *model.Member) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	m, ok := s.members[member.SlackID]
+	if !ok {
+		return ErrNotFound
+	}
+	*member = s.withTags(m)
+	return nil
+}
+
+// GetMembers populates the given members with all stored members who aren't
+// alumni.
+func (s *MemoryStore) GetMembers(members *model.Members) error {
+	return s.getMembers(members, func(m *model.Member) bool { return !m.IsAlumni() })
+}
+
+// GetAllMembers populates the given members with all stored members.
+func (s *MemoryStore) GetAllMembers(members *model.Members) error {
+	return s.getMembers(members, func(*model.Member) bool { return true })
+}
+
+// GetTechLeads populates the given members with all stored tech leads who
+// aren't alumni.
+func (s *MemoryStore) GetTechLeads(members *model.Members) error {
+	return s.getMembers(members, func(m *model.Member) bool {
+		return m.IsTechLead && !m.IsAlumni()
+	})
+}
+
+// GetAdmins populates the given members with all stored admins who aren't
+// alumni.
+func (s *MemoryStore) GetAdmins(members *model.Members) error {
+	return s.getMembers(members, func(m *model.Member) bool {
+		return m.IsAdmin && !m.IsAlumni()
+	})
+}
+
+// getMembers populates the given members with the stored members that match
+// the given filter, ordered by name.
+func (s *MemoryStore) getMembers(members *model.Members, filter func(*model.Member) bool) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	*members = model.Members{}
+	for _, m := range s.members {
+		m := s.withTags(m)
+		if filter(&m) {
+			*members = append(*members, &m)
+		}
+	}
+	sortMembers(*members)
+	return nil
+}
+
+// CreateMember stores the given member unless a member with the same Slack ID
+// or email is already stored.
+func (s *MemoryStore) CreateMember(member *model.Member) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.members[member.SlackID]; ok {
+		return nil
+	}
+	if member.Email != "" {
+		for _, m := range s.members {
+			if m.Email == member.Email {
+				return nil
+			}
+		}
+	}
+	if member.CreatedAt.IsZero() {
+		member.CreatedAt = time.Now()
+	}
+	m := *member
+	m.Skills = nil
+	m.Interests = nil
+	s.members[member.SlackID] = m
+	return nil
+}
+
+// UpdateMember updates the stored member with the given member's Slack ID the
+// same way DAL.UpdateMember does.
+func (s *MemoryStore) UpdateMember(member *model.Member) error {
+	return s.setMember(member, true, func(existing *model.Member) error {
+		if member.Name != "" {
+			existing.Name = member.Name
+		}
+		if member.Email != "" {
+			if err := s.checkEmail(existing.SlackID, member.Email); err != nil {
+				return err
+			}
+			existing.Email = member.Email
+		}
+		if existing.Position == "" {
+			existing.Position = member.Position
+		}
+		return nil
+	})
+}
+
+// DeleteMember deletes the stored member with the given member's Slack ID and
+// removes them from their teams.
+func (s *MemoryStore) DeleteMember(member *model.Member) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.members, member.SlackID)
+	delete(s.tags, member.SlackID)
+	for _, members := range s.teamMembers {
+		delete(members, member.SlackID)
+	}
+	return nil
+}
+
+// SetMemberName updates the name of the given member.
+func (s *MemoryStore) SetMemberName(member *model.Member) error {
+	return s.setMember(member, false, func(m *model.Member) error {
+		m.Name = member.Name
+		return nil
+	})
+}
+
+// SetMemberEmail updates the email of the given member.
+func (s *MemoryStore) SetMemberEmail(member *model.Member) error {
+	return s.setMember(member, false, func(m *model.Member) error {
+		if err := s.checkEmail(m.SlackID, member.Email); err != nil {
+			return err
+		}
+		m.Email = member.Email
+		return nil
+	})
+}
+
+// SetMemberGitHubUsername updates the GitHub username of the given member.
+func (s *MemoryStore) SetMemberGitHubUsername(member *model.Member) error {
+	return s.setMember(member, false, func(m *model.Member) error {
+		m.GithubUsername = member.GithubUsername
+		return nil
+	})
+}
+
+// SetMemberMajor updates the major of the given member.
+func (s *MemoryStore) SetMemberMajor(member *model.Member) error {
+	return s.setMember(member, false, func(m *model.Member) error {
+		m.Major = member.Major
+		return nil
+	})
+}
+
+// SetMemberPosition updates the position of the given member.
+func (s *MemoryStore) SetMemberPosition(member *model.Member) error {
+	return s.setMember(member, false, func(m *model.Member) error {
+		m.Position = member.Position
+		return nil
+	})
+}
+
+// SetMemberBiography updates the bio of the given member.
+func (s *MemoryStore) SetMemberBiography(member *model.Member) error {
+	return s.setMember(member, false, func(m *model.Member) error {
+		m.Biography = member.Biography
+		return nil
+	})
+}
+
+// SetMemberImageURL updates the image URL of the given member.
+func (s *MemoryStore) SetMemberImageURL(member *model.Member) error {
+	return s.setMember(member, false, func(m *model.Member) error {
+		m.ImageURL = member.ImageURL
+		return nil
+	})
+}
+
+// SetMemberIsAdmin updates whether the given member is an admin.
+func (s *MemoryStore) SetMemberIsAdmin(member *model.Member) error {
+	return s.setMember(member, false, func(m *model.Member) error {
+		m.IsAdmin = member.IsAdmin
+		return nil
+	})
+}
+
+// SetMemberIsTechLead updates whether the given member is a tech lead.
+func (s *MemoryStore) SetMemberIsTechLead(member *model.Member) error {
+	return s.setMember(member, false, func(m *model.Member) error {
+		m.IsTechLead = member.IsTechLead
+		return nil
+	})
+}
+
+// SetMemberLeftAt updates when the given member left Launch Pad.
+func (s *MemoryStore) SetMemberLeftAt(member *model.Member) error {
+	return s.setMember(member, false, func(m *model.Member) error {
+		m.LeftAt = nil
+		if member.LeftAt != nil {
+			leftAt := *member.LeftAt
+			m.LeftAt = &leftAt
+		}
+		return nil
+	})
+}
+
+// SetMemberSkills replaces the skills of the given member.
+func (s *MemoryStore) SetMemberSkills(member *model.Member) error {
+	return s.setMemberTags(member, model.TagKindSkill, member.Skills)
+}
+
+// SetMemberInterests replaces the interests of the given member.
+func (s *MemoryStore) SetMemberInterests(member *model.Member) error {
+	return s.setMemberTags(member, model.TagKindInterest, member.Interests)
+}
+
+// GetMembersByTag populates the given members with all stored members who
+// aren't alumni and have the given tag.
+func (s *MemoryStore) GetMembersByTag(tag *model.Tag, members *model.Members) error {
+	name := model.NormalizeTag(tag.Name)
+	return s.getMembers(members, func(m *model.Member) bool {
+		if m.IsAlumni() {
+			return false
+		}
+		for kind, names := range s.tags[m.SlackID] {
+			if tag.Kind != "" && kind != tag.Kind {
+				continue
+			}
+			for _, n := range names {
+				if n == name {
+					return true
+				}
+			}
+		}
+		return false
+	})
+}
+
+// setMemberTags replaces the tags of the given kind on the stored member with
+// the given member's Slack ID with tags with the given names.
+func (s *MemoryStore) setMemberTags(member *model.Member, kind model.TagKind, names []string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.members[member.SlackID]; !ok {
+		return nil
+	}
+	if s.tags[member.SlackID] == nil {
+		s.tags[member.SlackID] = map[model.TagKind][]string{}
+	}
+	s.tags[member.SlackID][kind] = model.NormalizeTags(names)
+	return nil
+}
+
+// withTags returns a copy of the given member with its skills and interests.
+// The store must be locked.
+func (s *MemoryStore) withTags(m model.Member) model.Member {
+	m.Skills = append([]string{}, s.tags[m.SlackID][model.TagKindSkill]...)
+	m.Interests = append([]string{}, s.tags[m.SlackID][model.TagKindInterest]...)
+	return m
+}
+
+// Search populates the given results with the stored members and teams that
+// match the given query.
+func (s *MemoryStore) Search(query string, results *model.SearchResults) error {
+	return search(s, query, results)
+}
+
+// setMember applies the given change to the stored member with the given
+// member's Slack ID. If there is no such member, ErrNotFound is returned if
+// mustExist is true and nothing happens otherwise.
+func (s *MemoryStore) setMember(member *model.Member, mustExist bool, change func(*model.Member) error) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	m, ok := s.members[member.SlackID]
+	if !ok {
+		if mustExist {
+			return ErrNotFound
+		}
+		return nil
+	}
+	if err := change(&m); err != nil {
+		return err
+	}
+	s.members[m.SlackID] = m
+	return nil
+}
+
+// checkEmail returns an error if a member other than the one with the given
+// Slack ID has the given email. The store must be locked.
+func (s *MemoryStore) checkEmail(slackID, email string) error {
+	if email == "" {
+		return nil
+	}
+	for _, m := range s.members {
+		if m.SlackID != slackID && m.Email == email {
+			return errors.New("a member with email " + email + " already exists")
+		}
+	}
+	return nil
+}
+
+// GetTeamByName populates the given team with the stored team with the same
+// name, and its members in the current term.
+func (s *MemoryStore) GetTeamByName(team *model.Team) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	for _, t := range s.teams {
+		if t.Name == team.Name {
+			*team = s.withCurrentMembers(t)
+			return nil
+		}
+	}
+	return ErrNotFound
+}
+
+// GetTeamByGithubID populates the given team with the stored team with the
+// same GitHub team ID, and its members in the current term.
+func (s *MemoryStore) GetTeamByGithubID(team *model.Team) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	t, ok := s.teams[team.GithubTeamID]
+	if !ok {
+		return ErrNotFound
+	}
+	*team = s.withCurrentMembers(t)
+	return nil
+}
+
+// GetTeams populates the given teams with the stored teams running in the
+// current term and their members.
+func (s *MemoryStore) GetTeams(teams *model.Teams) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	*teams = s.teamsInTerm(s.currentTerm())
+	return nil
+}
+
+// GetTeamsByTerm populates the given teams with the stored teams that ran in
+// the given term and their members in it.
+func (s *MemoryStore) GetTeamsByTerm(term string, teams *model.Teams) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	*teams = s.teamsInTerm(term)
+	return nil
+}
+
+// GetTeamNames populates the given teams with the names of the stored teams
+// running in the current term.
+func (s *MemoryStore) GetTeamNames(teams *model.Teams) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	*teams = model.Teams{}
+	for _, t := range s.teamsInTerm(s.currentTerm()) {
+		*teams = append(*teams, &model.Team{Name: t.Name})
+	}
+	return nil
+}
+
+// CreateTeam stores the given team and runs it in the current term. Returns
+// ErrTeamExists if a team with the same name or GitHub team ID is already
+// stored.
+func (s *MemoryStore) CreateTeam(team *model.Team) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.teams[team.GithubTeamID]; ok {
+		return ErrTeamExists
+	}
+	for _, t := range s.teams {
+		if t.Name == team.Name {
+			return ErrTeamExists
+		}
+	}
+	if team.CreatedAt.IsZero() {
+		team.CreatedAt = time.Now()
+	}
+	t := *team
+	t.Members = nil
+	s.teams[t.GithubTeamID] = t
+	s.teamMembers[teamTerm{GithubTeamID: t.GithubTeamID, Term: s.currentTerm()}] =
+		map[string]model.TeamRole{}
+	return nil
+}
+
+// UpdateTeam updates the current team with the new team's name and platform
+// where they are set.
+func (s *MemoryStore) UpdateTeam(currentTeam, newTeam *model.Team) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if newTeam.Name != "" {
+		for _, t := range s.teams {
+			if t.Name == newTeam.Name && t.GithubTeamID != currentTeam.GithubTeamID {
+				return errors.New("a team named " + newTeam.Name + " already exists")
+			}
+		}
+		currentTeam.Name = newTeam.Name
+	}
+	if newTeam.Platform != "" {
+		currentTeam.Platform = newTeam.Platform
+	}
+	t, ok := s.teams[currentTeam.GithubTeamID]
+	if !ok {
+		return nil
+	}
+	t.Name = currentTeam.Name
+	t.Platform = currentTeam.Platform
+	s.teams[t.GithubTeamID] = t
+	return nil
+}
+
+// DeleteTeamByName deletes the stored team with the given team's name and its
+// memberships.
+func (s *MemoryStore) DeleteTeamByName(team *model.Team) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	for id, t := range s.teams {
+		if t.Name == team.Name {
+			delete(s.teams, id)
+			delete(s.projects, id)
+			for tt := range s.teamMembers {
+				if tt.GithubTeamID == id {
+					delete(s.teamMembers, tt)
+				}
+			}
+		}
+	}
+	return nil
+}
+
+// UnlinkTeam moves the stored team with the given team's GitHub team ID, its
+// terms, memberships and project to the negation of its GitHub team ID.
+func (s *MemoryStore) UnlinkTeam(team *model.Team) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	from, to := team.GithubTeamID, -team.GithubTeamID
+	t, ok := s.teams[from]
+	if !ok {
+		return ErrNotFound
+	}
+	delete(s.teams, from)
+	t.GithubTeamID = to
+	s.teams[to] = t
+	if p, ok := s.projects[from]; ok {
+		delete(s.projects, from)
+		p.GithubTeamID = to
+		s.projects[to] = p
+	}
+	for tt, members := range s.teamMembers {
+		if tt.GithubTeamID == from {
+			delete(s.teamMembers, tt)
+			s.teamMembers[teamTerm{GithubTeamID: to, Term: tt.Term}] = members
+		}
+	}
+	team.GithubTeamID = to
+	return nil
+}
+
+// CreateTeamMember adds a member to a team.
+func (s *MemoryStore) CreateTeamMember(member *model.TeamMember) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.teams[member.GithubTeamID]; !ok {
+		return errors.New("team does not exist")
+	}
+	if _, ok := s.members[member.MemberSlackID]; !ok {
+		return errors.New("member does not exist")
+	}
+	s.setTerm(member)
+	members, ok := s.teamMembers[teamTermOf(member)]
+	if !ok {
+		return errors.New("team is not running in term " + member.Term)
+	}
+	if member.Role == "" {
+		member.Role = model.TeamRoleDeveloper
+	}
+	if _, ok := members[member.MemberSlackID]; !ok {
+		members[member.MemberSlackID] = member.Role
+	}
+	return nil
+}
+
+// SetTeamMemberRole updates the role of a member on a team.
+func (s *MemoryStore) SetTeamMemberRole(member *model.TeamMember) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.setTerm(member)
+	members := s.teamMembers[teamTermOf(member)]
+	if _, ok := members[member.MemberSlackID]; !ok {
+		return ErrNotFound
+	}
+	members[member.MemberSlackID] = member.Role
+	return nil
+}
+
+// DeleteTeamMember removes a member from a team.
+func (s *MemoryStore) DeleteTeamMember(member *model.TeamMember) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.setTerm(member)
+	delete(s.teamMembers[teamTermOf(member)], member.MemberSlackID)
+	return nil
+}
+
+// setTerm puts the given team member in the current term if their term isn't
+// set. The store must be locked.
+func (s *MemoryStore) setTerm(member *model.TeamMember) {
+	if member.Term == "" {
+		member.Term = s.currentTerm()
+	}
+}
+
+// teamTermOf returns the team and term of the given team member.
+func teamTermOf(member *model.TeamMember) teamTerm {
+	return teamTerm{GithubTeamID: member.GithubTeamID, Term: member.Term}
+}
+
+// CreateTerm stores the given term unless a term with the same code is
+// already stored.
+func (s *MemoryStore) CreateTerm(term *model.Term) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.terms[term.Code]; ok {
+		return nil
+	}
+	if !model.TermCodeRegex.MatchString(term.Code) {
+		return errors.New("invalid term code " + term.Code)
+	}
+	if term.StartedAt.IsZero() {
+		term.StartedAt = time.Now()
+	}
+	s.terms[term.Code] = *term
+	return nil
+}
+
+// GetTerms populates the given terms with all stored terms, newest first.
+func (s *MemoryStore) GetTerms(terms *model.Terms) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	*terms = s.sortedTerms()
+	return nil
+}
+
+// GetCurrentTerm populates the given term with the stored term that started
+// most recently.
+func (s *MemoryStore) GetCurrentTerm(term *model.Term) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	terms := s.sortedTerms()
+	if len(terms) == 0 {
+		return ErrNotFound
+	}
+	*term = *terms[0]
+	return nil
+}
+
+// AddTeamToTerm runs the given team in the given term.
+func (s *MemoryStore) AddTeamToTerm(team *model.Team, term string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.teams[team.GithubTeamID]; !ok {
+		return errors.New("team does not exist")
+	}
+	if _, ok := s.terms[term]; !ok {
+		return errors.New("term does not exist")
+	}
+	tt := teamTerm{GithubTeamID: team.GithubTeamID, Term: term}
+	if _, ok := s.teamMembers[tt]; !ok {
+		s.teamMembers[tt] = map[string]model.TeamRole{}
+	}
+	return nil
+}
+
+// RemoveTeamFromTerm stops running the given team in the given term, and
+// removes its members in the term.
+func (s *MemoryStore) RemoveTeamFromTerm(team *model.Team, term string) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.teamMembers, teamTerm{GithubTeamID: team.GithubTeamID, Term: term})
+	return nil
+}
+
+// sortedTerms returns the stored terms, newest first. The store must be
+// locked.
+func (s *MemoryStore) sortedTerms() model.Terms {
+	terms := model.Terms{}
+	for _, t := range s.terms {
+		t := t
+		terms = append(terms, &t)
+	}
+	sort.Slice(terms, func(i, j int) bool {
+		if terms[i].StartedAt.Equal(terms[j].StartedAt) {
+			return terms[i].Code > terms[j].Code
+		}
+		return terms[i].StartedAt.After(terms[j].StartedAt)
+	})
+	return terms
+}
+
+// currentTerm returns the code of the current term, or an empty string if
+// there are no terms. The store must be locked.
+func (s *MemoryStore) currentTerm() string {
+	terms := s.sortedTerms()
+	if len(terms) == 0 {
+		return ""
+	}
+	return terms[0].Code
+}
+
+// GetProjectByTeam populates the given project with the stored project of
+// the team with the same GitHub team ID.
+func (s *MemoryStore) GetProjectByTeam(project *model.Project) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	p, ok := s.projects[project.GithubTeamID]
+	if !ok {
+		return ErrNotFound
+	}
+	*project = s.withTeam(p)
+	return nil
+}
+
+// GetProjects populates the given projects with all stored projects.
+func (s *MemoryStore) GetProjects(projects *model.Projects) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	*projects = model.Projects{}
+	for _, p := range s.projects {
+		project := s.withTeam(p)
+		*projects = append(*projects, &project)
+	}
+	sort.Slice(*projects, func(i, j int) bool {
+		a, b := (*projects)[i], (*projects)[j]
+		if a.Name == b.Name {
+			return a.GithubTeamID < b.GithubTeamID
+		}
+		return a.Name < b.Name
+	})
+	return nil
+}
+
+// SaveProject stores the given project, replacing the stored project of its
+// team.
+func (s *MemoryStore) SaveProject(project *model.Project) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if _, ok := s.teams[project.GithubTeamID]; !ok {
+		return errors.New("team does not exist")
+	}
+	if p, ok := s.projects[project.GithubTeamID]; ok {
+		project.CreatedAt = p.CreatedAt
+	}
+	setProjectDefaults(project)
+	p := *project
+	p.Team = ""
+	p.Repos = append([]string{}, project.Repos...)
+	p.TechStack = append([]string{}, project.TechStack...)
+	s.projects[p.GithubTeamID] = p
+	return nil
+}
+
+// DeleteProject deletes the stored project of the team with the given
+// project's GitHub team ID.
+func (s *MemoryStore) DeleteProject(project *model.Project) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	delete(s.projects, project.GithubTeamID)
+	return nil
+}
+
+// withTeam returns a copy of the given project with the name of its team
+// set. The store must be locked.
+func (s *MemoryStore) withTeam(p model.Project) model.Project {
+	p.Team = s.teams[p.GithubTeamID].Name
+	p.Repos = append([]string{}, p.Repos...)
+	p.TechStack = append([]string{}, p.TechStack...)
+	return p
+}
+
+// CreateAuditEntry appends the given entry to the audit log.
+func (s *MemoryStore) CreateAuditEntry(entry *model.AuditEntry) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now()
+	}
+	entry.ID = len(s.audit) + 1
+	s.audit = append(s.audit, *entry)
+	return nil
+}
+
+// GetAuditEntries populates the given entries with the entries in the audit
+// log that match the given filter, newest first.
+func (s *MemoryStore) GetAuditEntries(filter AuditFilter, entries *model.AuditEntries) error {
+	s.mu.RLock()
+	defer s.mu.RUnlock()
+	*entries = model.AuditEntries{}
+	for i := len(s.audit) - 1; i >= 0; i-- {
+		if filter.Limit > 0 && len(*entries) == filter.Limit {
+			break
+		}
+		entry := s.audit[i]
+		if filter.matches(&entry) {
+			*entries = append(*entries, &entry)
+		}
+	}
+	return nil
+}
+
+// teamsInTerm returns the teams running in the given term and their members
+// in it, ordered by name. The store must be locked.
+func (s *MemoryStore) teamsInTerm(term string) model.Teams {
+	teams := model.Teams{}
+	for _, t := range s.teams {
+		if _, ok := s.teamMembers[teamTerm{GithubTeamID: t.GithubTeamID, Term: term}]; ok {
+			team := s.withMembers(t, term)
+			teams = append(teams, &team)
+		}
+	}
+	sortTeams(teams)
+	return teams
+}
+
+// withCurrentMembers returns the given team with its members in the current
+// term populated, and whether it's archived set. The store must be locked.
+func (s *MemoryStore) withCurrentMembers(t model.Team) model.Team {
+	term := s.currentTerm()
+	_, running := s.teamMembers[teamTerm{GithubTeamID: t.GithubTeamID, Term: term}]
+	t = s.withMembers(t, term)
+	t.Archived = !running
+	return t
+}
+
+// withMembers returns the given team with its members in the given term and
+// their roles populated. The store must be locked.
+func (s *MemoryStore) withMembers(t model.Team, term string) model.Team {
+	t.Members = []*model.Member{}
+	for slackID, role := range s.teamMembers[teamTerm{GithubTeamID: t.GithubTeamID, Term: term}] {
+		m := s.members[slackID]
+		m.TeamRole = role
+		t.Members = append(t.Members, &m)
+	}
+	sortMembers(t.Members)
+	return t
+}
+
+func sortMembers(members []*model.Member) {
+	sort.Slice(members, func(i, j int) bool {
+		if members[i].Name == members[j].Name {
+			return members[i].SlackID < members[j].SlackID
+		}
+		return members[i].Name < members[j].Name
+	})
+}
+
+func sortTeams(teams []*model.Team) {
+	sort.Slice(teams, func(i, j int) bool {
+		return teams[i].Name < teams[j].Name
+	})
+}
@@ -0,0 +1,295 @@
+package data
+
+import (
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/go-pg/pg"
+	"github.com/go-pg/pg/orm"
+	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/schema"
+)
+
+// baselineVersion is the version of the schema in databases created by hand
+// from schema/tables.sql before Rocket applied its own migrations.
+const baselineVersion = 6
+
+// ErrSchemaAhead is returned when the database has migrations applied that
+// this version of Rocket doesn't know about, e.g. because it was migrated by
+// a newer version of Rocket.
+var ErrSchemaAhead = errors.New("the database schema is newer than this version of Rocket")
+
+// appliedMigration is a row in the table that tracks which migrations have
+// been applied to the database.
+type appliedMigration struct {
+	TableName struct{} `sql:"schema_migrations"`
+
+	Version   int `sql:",pk"`
+	Name      string
+	AppliedAt time.Time
+}
+
+// MigrationStatus is a migration and whether it has been applied.
+type MigrationStatus struct {
+	schema.Migration
+	Applied   bool
+	AppliedAt time.Time
+}
+
+// Migrator manages a database's schema with migrations.
+type Migrator interface {
+	// MigrateUp applies the migrations that have not been applied yet.
+	MigrateUp() ([]schema.Migration, error)
+	// MigrateDown reverts the last migration that was applied.
+	MigrateDown() (*schema.Migration, error)
+	// MigrationStatus returns every migration and whether it was applied.
+	MigrationStatus() ([]MigrationStatus, error)
+	// Baseline records migrations as applied without running them.
+	Baseline(version int) error
+}
+
+// Database is a Store whose schema is managed with migrations.
+type Database interface {
+	Store
+	Migrator
+}
+
+var _ Database = &DAL{}
+
+// migrate applies the migrations that have not been applied to the given
+// database yet, and logs the ones it applied.
+func migrate(db Migrator) error {
+	migrations, err := db.MigrateUp()
+	for _, m := range migrations {
+		log.Infof("Applied migration %d_%s", m.Version, m.Name)
+	}
+	return err
+}
+
+// MigrateUp applies the migrations that have not been applied to the database
+// yet, in order. Returns the migrations that were applied, and
+// ErrSchemaAhead if the database has migrations applied that Rocket doesn't
+// know about.
+func (dal *DAL) MigrateUp() ([]schema.Migration, error) {
+	migrations, applied, err := dal.migrations()
+	if err != nil {
+		return nil, err
+	}
+	if len(applied) > len(migrations) {
+		return nil, ErrSchemaAhead
+	}
+
+	done := []schema.Migration{}
+	for _, m := range migrations[len(applied):] {
+		m := m
+		err := dal.runInTransaction(func(db orm.DB) error {
+			if _, err := db.Exec(m.Up); err != nil {
+				return err
+			}
+			return db.Insert(&appliedMigration{
+				Version:   m.Version,
+				Name:      m.Name,
+				AppliedAt: time.Now().UTC(),
+			})
+		})
+		if err != nil {
+			return done, fmt.Errorf("failed to apply migration %d_%s: %s",
+				m.Version, m.Name, err)
+		}
+		done = append(done, m)
+	}
+	return done, nil
+}
+
+// MigrateDown reverts the last migration that was applied to the database.
+// Returns the migration that was reverted, or nil if no migrations have been
+// applied.
+func (dal *DAL) MigrateDown() (*schema.Migration, error) {
+	migrations, applied, err := dal.migrations()
+	if err != nil {
+		return nil, err
+	}
+	if len(applied) > len(migrations) {
+		return nil, ErrSchemaAhead
+	}
+	if len(applied) == 0 {
+		return nil, nil
+	}
+
+	m := migrations[len(applied)-1]
+	err = dal.runInTransaction(func(db orm.DB) error {
+		if _, err := db.Exec(m.Down); err != nil {
+			return err
+		}
+		return db.Delete(&appliedMigration{Version: m.Version})
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to revert migration %d_%s: %s",
+			m.Version, m.Name, err)
+	}
+	return &m, nil
+}
+
+// MigrationStatus returns every migration Rocket knows about and whether it
+// has been applied to the database.
+func (dal *DAL) MigrationStatus() ([]MigrationStatus, error) {
+	migrations, applied, err := dal.migrations()
+	if err != nil {
+		return nil, err
+	}
+	return migrationStatus(migrations, applied)
+}
+
+// migrationStatus returns whether each of the given migrations is one of the
+// given applied migrations.
+func migrationStatus(migrations []schema.Migration, applied []appliedMigration) ([]MigrationStatus, error) {
+	status := make([]MigrationStatus, len(migrations))
+	for i, m := range migrations {
+		status[i].Migration = m
+		if i < len(applied) {
+			status[i].Applied = true
+			status[i].AppliedAt = applied[i].AppliedAt
+		}
+	}
+	if len(applied) > len(migrations) {
+		return status, ErrSchemaAhead
+	}
+	return status, nil
+}
+
+// Baseline records that every migration up to and including the given
+// version has been applied to the database without running them. Use this
+// for databases whose schema was created or migrated by hand.
+func (dal *DAL) Baseline(version int) error {
+	migrations, applied, err := dal.migrations()
+	if err != nil {
+		return err
+	}
+	if len(applied) > 0 {
+		return errors.New("the database already has migrations applied")
+	}
+	if version < 0 || version >= len(migrations) {
+		return fmt.Errorf("there is no migration with version %d", version)
+	}
+	return dal.runInTransaction(func(db orm.DB) error {
+		return baseline(db, migrations[:version+1])
+	})
+}
+
+// migrations returns the migrations Rocket knows about and the migrations
+// that have been applied to the database, in order. The table that tracks
+// applied migrations is created if it doesn't exist yet.
+func (dal *DAL) migrations() ([]schema.Migration, []appliedMigration, error) {
+	migrations, err := schema.Migrations()
+	if err != nil {
+		return nil, nil, err
+	}
+	if err := dal.createMigrationsTable(migrations); err != nil {
+		return nil, nil, err
+	}
+	var applied []appliedMigration
+	if err := dal.db.Model(&applied).Order("version").Select(); err != nil {
+		return nil, nil, err
+	}
+	if err := checkApplied(applied); err != nil {
+		return nil, nil, err
+	}
+	return migrations, applied, nil
+}
+
+// checkApplied returns an error unless the given applied migrations, ordered
+// by version, are the first migrations with no gaps between them.
+func checkApplied(applied []appliedMigration) error {
+	for i, m := range applied {
+		if m.Version != i {
+			return fmt.Errorf("migration %d is recorded as applied but %d is not",
+				m.Version, i)
+		}
+	}
+	return nil
+}
+
+// createMigrationsTable creates the table that tracks applied migrations if
+// it doesn't exist. Databases created from schema/tables.sql before Rocket
+// tracked migrations are baselined at the version tables.sql was at.
+func (dal *DAL) createMigrationsTable(migrations []schema.Migration) error {
+	exists, err := dal.tableExists("schema_migrations", "")
+	if err != nil || exists {
+		return err
+	}
+	legacy, err := dal.tableExists("members", "")
+	if err != nil {
+		return err
+	}
+	if legacy {
+		// Only a schema made from the last version of tables.sql is known
+		current, err := dal.tableExists("members", "is_tech_lead")
+		if err != nil {
+			return err
+		}
+		if !current {
+			return errors.New("the database schema predates migrations and " +
+				"does not match a known version - run `rocket migrate baseline VERSION` " +
+				"with the version of the last migration applied to it by hand")
+		}
+	}
+
+	return dal.runInTransaction(func(db orm.DB) error {
+		_, err := db.Exec(`CREATE TABLE schema_migrations (
+			version INTEGER PRIMARY KEY,
+			name TEXT NOT NULL,
+			applied_at TIMESTAMP WITH TIME ZONE NOT NULL
+		)`)
+		if err != nil || !legacy {
+			return err
+		}
+		return baseline(db, migrations[:baselineVersion+1])
+	})
+}
+
+// tableExists returns true if the given table exists in the database and, if
+// column is not empty, has the given column.
+func (dal *DAL) tableExists(table, column string) (bool, error) {
+	query := "SELECT EXISTS (SELECT 1 FROM information_schema.columns " +
+		"WHERE table_schema = current_schema() AND table_name = ?"
+	params := []interface{}{table}
+	if column != "" {
+		query += " AND column_name = ?"
+		params = append(params, column)
+	}
+	query += ")"
+
+	exists := false
+	_, err := dal.db.QueryOne(pg.Scan(&exists), query, params...)
+	return exists, err
+}
+
+// baseline records the given migrations as applied.
+func baseline(db orm.DB, migrations []schema.Migration) error {
+	now := time.Now().UTC()
+	for _, m := range migrations {
+		err := db.Insert(&appliedMigration{
+			Version:   m.Version,
+			Name:      m.Name,
+			AppliedAt: now,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// runInTransaction calls the given function with a transaction that is
+// committed if the function succeeds and rolled back if it returns an error.
+// If the DAL is already using a transaction, the function is called with it.
+func (dal *DAL) runInTransaction(fn func(orm.DB) error) error {
+	db, ok := dal.db.(*pg.DB)
+	if !ok {
+		return fn(dal.db)
+	}
+	return db.RunInTransaction(func(tx *pg.Tx) error {
+		return fn(tx)
+	})
+}
@@ -0,0 +1,47 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ubclaunchpad/rocket/schema"
+)
+
+func TestMigrations(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	dal, cleanupFunc, err := newTestDBConnection()
+	assert.Nil(t, err)
+	defer cleanupFunc()
+	latest, err := schema.Latest()
+	assert.Nil(t, err)
+
+	// New applies every migration
+	status, err := dal.MigrationStatus()
+	assert.Nil(t, err)
+	assert.Len(t, status, latest+1)
+	for _, m := range status {
+		assert.True(t, m.Applied, m.Name)
+	}
+
+	// Revert the last migration and apply it again
+	reverted, err := dal.MigrateDown()
+	assert.Nil(t, err)
+	assert.Equal(t, latest, reverted.Version)
+	status, err = dal.MigrationStatus()
+	assert.Nil(t, err)
+	assert.False(t, status[latest].Applied)
+
+	applied, err := dal.MigrateUp()
+	assert.Nil(t, err)
+	if assert.Len(t, applied, 1) {
+		assert.Equal(t, latest, applied[0].Version)
+	}
+
+	// Migrations Rocket doesn't know about are refused
+	err = dal.db.Insert(&appliedMigration{Version: latest + 1, Name: "from_the_future"})
+	assert.Nil(t, err)
+	_, err = dal.MigrateUp()
+	assert.Equal(t, ErrSchemaAhead, err)
+}
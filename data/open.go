@@ -0,0 +1,53 @@
+package data
+
+import (
+	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/config"
+)
+
+const (
+	// BackendPostgres stores data in Postgres. This is the default.
+	BackendPostgres = "postgres"
+	// BackendSQLite stores data in a SQLite database file.
+	BackendSQLite = "sqlite"
+
+	// defaultSQLitePath is the SQLite database file used if none is configured
+	defaultSQLitePath = "rocket.db"
+)
+
+// Open connects to the database backend chosen in the given config, and
+// applies any migrations that have not been applied to it yet. This will
+// exit if we fail to connect to or migrate the database.
+func Open(c *config.Config) Database {
+	if c.Database == BackendSQLite {
+		s, err := NewSQLite(sqlitePath(c))
+		if err != nil {
+			log.WithError(err).Fatal("Error initializing the database")
+		}
+		return s
+	}
+	return New(c)
+}
+
+// OpenWithoutMigrating connects to the database backend chosen in the given
+// config without migrating it. This will exit if we fail to connect to the
+// database.
+func OpenWithoutMigrating(c *config.Config) Database {
+	if c.Database == BackendSQLite {
+		s, err := ConnectSQLite(sqlitePath(c))
+		if err != nil {
+			log.WithError(err).Fatal("Error initializing the database")
+		}
+		return s
+	}
+	return Connect(c)
+}
+
+// sqlitePath returns the path of the SQLite database file in the given
+// config.
+func sqlitePath(c *config.Config) string {
+	if c.SQLitePath == "" {
+		return defaultSQLitePath
+	}
+	return c.SQLitePath
+}
@@ -0,0 +1,104 @@
+package data
+
+import (
+	"time"
+
+	"github.com/go-pg/pg"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// GetProjectByTeam provides the project of the team with the given project's
+// GitHub team ID
+func (dal *DAL) GetProjectByTeam(project *model.Project) error {
+	err := dal.db.Model(project).
+		Where("team_github_team_id = ?team_github_team_id").
+		Select()
+	if err != nil {
+		return notFound(err)
+	}
+	return dal.setProjectTeams(project)
+}
+
+// GetProjects gets all projects
+func (dal *DAL) GetProjects(projects *model.Projects) error {
+	err := dal.db.Model(projects).
+		Order("name ASC", "team_github_team_id ASC").
+		Select()
+	if err != nil {
+		return err
+	}
+	return dal.setProjectTeams(*projects...)
+}
+
+// SaveProject inserts the given project into the database, or replaces the
+// project of its team
+func (dal *DAL) SaveProject(project *model.Project) error {
+	setProjectDefaults(project)
+	_, err := dal.db.Model(project).
+		OnConflict("(team_github_team_id) DO UPDATE").
+		Set("name = EXCLUDED.name").
+		Set("description = EXCLUDED.description").
+		Set("repos = EXCLUDED.repos").
+		Set("website = EXCLUDED.website").
+		Set("status = EXCLUDED.status").
+		Set("tech_stack = EXCLUDED.tech_stack").
+		Set("updated_at = EXCLUDED.updated_at").
+		Returning("created_at").
+		Insert()
+	return err
+}
+
+// DeleteProject deletes the project of the team with the given project's
+// GitHub team ID from the database
+func (dal *DAL) DeleteProject(project *model.Project) error {
+	_, err := dal.db.Model(project).
+		Where("team_github_team_id = ?team_github_team_id").
+		Delete()
+	return err
+}
+
+// setProjectTeams sets the names of the teams building the given projects.
+func (dal *DAL) setProjectTeams(projects ...*model.Project) error {
+	ids := []int{}
+	for _, project := range projects {
+		ids = append(ids, project.GithubTeamID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	var teams model.Teams
+	err := dal.db.Model(&teams).
+		Column("name", "github_team_id").
+		Where("github_team_id IN (?)", pg.In(ids)).
+		Select()
+	if err != nil {
+		return err
+	}
+	names := map[int]string{}
+	for _, team := range teams {
+		names[team.GithubTeamID] = team.Name
+	}
+	for _, project := range projects {
+		project.Team = names[project.GithubTeamID]
+	}
+	return nil
+}
+
+// setProjectDefaults sets the fields of the given project that every store
+// sets before saving it.
+func setProjectDefaults(project *model.Project) {
+	if project.Status == "" {
+		project.Status = model.ProjectStatusPlanning
+	}
+	if project.Repos == nil {
+		project.Repos = []string{}
+	}
+	if project.TechStack == nil {
+		project.TechStack = []string{}
+	}
+	now := time.Now().UTC()
+	if project.CreatedAt.IsZero() {
+		project.CreatedAt = now
+	}
+	project.UpdatedAt = now
+}
@@ -0,0 +1,225 @@
+package data
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/go-pg/pg"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// memberSearchVector and teamSearchVector are the documents members and teams
+// are searched by in Postgres, weighted so that matching names ranks highest.
+// They must match the expressions indexed by schema/migrations/13_add_search.sql.
+const (
+	memberSearchVector = `(setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+		setweight(to_tsvector('english', coalesce("position", '')), 'B') ||
+		setweight(to_tsvector('english', coalesce(program, '')), 'B') ||
+		setweight(to_tsvector('english', coalesce(biography, '')), 'C'))`
+	teamSearchVector = `(setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+		setweight(to_tsvector('english', coalesce(platform, '')), 'B'))`
+)
+
+// fuzzyThreshold is how similar a team's name must be to a search query for
+// the team to match it when none of its words do. It is pg_trgm's default
+// similarity threshold.
+const fuzzyThreshold = 0.3
+
+// Search populates the given results with the members who aren't alumni and
+// the current teams that match the given query, ranked by Postgres full-text
+// search. Teams whose names are similar to the query match too, if the
+// pg_trgm extension is installed.
+func (dal *DAL) Search(query string, results *model.SearchResults) error {
+	*results = model.SearchResults{
+		Query:   query,
+		Members: model.Members{},
+		Teams:   model.Teams{},
+	}
+	terms := model.SearchTerms(query)
+	if len(terms) == 0 {
+		return nil
+	}
+	tsquery := prefixQuery(terms)
+
+	err := dal.db.Model(&results.Members).
+		Where("left_at IS NULL").
+		Where(memberSearchVector+" @@ to_tsquery('english', ?)", tsquery).
+		OrderExpr("ts_rank("+memberSearchVector+", to_tsquery('english', ?)) DESC", tsquery).
+		Order("name ASC").
+		Select()
+	if err != nil {
+		return err
+	}
+	if err := dal.setTags(results.Members...); err != nil {
+		return err
+	}
+
+	term, err := dal.currentTerm()
+	if err != nil {
+		return err
+	}
+	fuzzy, err := dal.hasTrigrams()
+	if err != nil {
+		return err
+	}
+	q := dal.db.Model(&results.Teams).
+		Where("github_team_id IN (?)", dal.inTerm(term))
+	if fuzzy {
+		q = q.Where("("+teamSearchVector+" @@ to_tsquery('english', ?) OR name % ?)",
+			tsquery, query).
+			OrderExpr("greatest(ts_rank("+teamSearchVector+", to_tsquery('english', ?)), "+
+				"similarity(name, ?)) DESC", tsquery, query)
+	} else {
+		q = q.Where(teamSearchVector+" @@ to_tsquery('english', ?)", tsquery).
+			OrderExpr("ts_rank("+teamSearchVector+", to_tsquery('english', ?)) DESC", tsquery)
+	}
+	if err := q.Order("name ASC").Select(); err != nil {
+		return err
+	}
+	return dal.setTeamMembers(term, results.Teams...)
+}
+
+// hasTrigrams returns true if the pg_trgm extension is installed. The
+// 13_add_search migration only installs it if the database user is allowed
+// to.
+func (dal *DAL) hasTrigrams() (bool, error) {
+	installed := false
+	_, err := dal.db.QueryOne(pg.Scan(&installed),
+		"SELECT EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm')")
+	return installed, err
+}
+
+// prefixQuery returns a tsquery that matches documents with words starting
+// with every one of the given search terms. The terms must only have letters
+// and digits, like the terms returned by model.SearchTerms.
+func prefixQuery(terms []string) string {
+	prefixes := make([]string, len(terms))
+	for i, term := range terms {
+		prefixes[i] = term + ":*"
+	}
+	return strings.Join(prefixes, " & ")
+}
+
+// search populates the given results with the members and teams of the given
+// store that match the given query, ranked the way DAL.Search ranks them. It
+// is used by stores without full-text search of their own.
+func search(s Store, query string, results *model.SearchResults) error {
+	*results = model.SearchResults{
+		Query:   query,
+		Members: model.Members{},
+		Teams:   model.Teams{},
+	}
+	terms := model.SearchTerms(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	var members model.Members
+	if err := s.GetMembers(&members); err != nil {
+		return err
+	}
+	memberRanks := map[*model.Member]float64{}
+	for _, m := range members {
+		rank := matchRank(terms,
+			searchField{m.Name, 1},
+			searchField{m.Position, 0.4},
+			searchField{m.Major, 0.4},
+			searchField{m.Biography, 0.2})
+		if rank > 0 {
+			memberRanks[m] = rank
+			results.Members = append(results.Members, m)
+		}
+	}
+	sort.SliceStable(results.Members, func(i, j int) bool {
+		return memberRanks[results.Members[i]] > memberRanks[results.Members[j]]
+	})
+
+	var teams model.Teams
+	if err := s.GetTeams(&teams); err != nil {
+		return err
+	}
+	teamRanks := map[*model.Team]float64{}
+	for _, t := range teams {
+		rank := matchRank(terms, searchField{t.Name, 1}, searchField{t.Platform, 0.4})
+		similarity := trigramSimilarity(t.Name, query)
+		if rank == 0 && similarity < fuzzyThreshold {
+			continue
+		}
+		if similarity > rank {
+			rank = similarity
+		}
+		teamRanks[t] = rank
+		results.Teams = append(results.Teams, t)
+	}
+	sort.SliceStable(results.Teams, func(i, j int) bool {
+		return teamRanks[results.Teams[i]] > teamRanks[results.Teams[j]]
+	})
+	return nil
+}
+
+// searchField is text that is searched, and how much matching it counts.
+type searchField struct {
+	text   string
+	weight float64
+}
+
+// matchRank returns how well the given fields match the given search terms
+// between 0 and 1, or 0 if they don't have a word starting with each term.
+// Each term counts with the weight of the heaviest field that matches it.
+func matchRank(terms []string, fields ...searchField) float64 {
+	total := 0.0
+	for _, term := range terms {
+		best := 0.0
+		for _, field := range fields {
+			if field.weight > best && hasPrefixWord(field.text, term) {
+				best = field.weight
+			}
+		}
+		if best == 0 {
+			return 0
+		}
+		total += best
+	}
+	return total / float64(len(terms))
+}
+
+// hasPrefixWord returns true if the given text has a word that starts with
+// the given search term.
+func hasPrefixWord(text, term string) bool {
+	for _, word := range model.SearchTerms(text) {
+		if strings.HasPrefix(word, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// trigramSimilarity returns how similar the given strings are between 0 and
+// 1, the way pg_trgm's similarity function does: the number of trigrams they
+// share divided by the number of distinct trigrams in either.
+func trigramSimilarity(a, b string) float64 {
+	ta, tb := trigrams(a), trigrams(b)
+	if len(ta) == 0 || len(tb) == 0 {
+		return 0
+	}
+	shared := 0
+	for t := range ta {
+		if tb[t] {
+			shared++
+		}
+	}
+	return float64(shared) / float64(len(ta)+len(tb)-shared)
+}
+
+// trigrams returns the set of trigrams in the words of the given string. Like
+// pg_trgm, each word is padded with two spaces before it and one after it.
+func trigrams(s string) map[string]bool {
+	set := map[string]bool{}
+	for _, word := range model.SearchTerms(s) {
+		padded := []rune("  " + word + " ")
+		for i := 0; i+3 <= len(padded); i++ {
+			set[string(padded[i:i+3])] = true
+		}
+	}
+	return set
+}
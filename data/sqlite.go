@@ -0,0 +1,912 @@
+package data
+
+import (
+	"database/sql"
+	"encoding/json"
+	"time"
+
+	"github.com/ubclaunchpad/rocket/model"
+
+	// Registers the "sqlite" database/sql driver
+	_ "modernc.org/sqlite"
+)
+
+// memberColumns are the columns of the members table in the order
+// scanMember reads them.
+const memberColumns = `members.slack_id, members.name, members.email,
+	members.github_username, members.program, members.position,
+	members.biography, members.image_url, members.is_tech_lead,
+	members.is_admin, members.created_at, members.left_at`
+
+// SQLiteStore is a Store that keeps members and teams in a SQLite database.
+// It is used to run Rocket locally without Postgres.
+type SQLiteStore struct {
+	conn *sql.DB
+	// db runs the store's queries. It is the connection, or the transaction
+	// the store makes its changes in.
+	db querier
+}
+
+// querier runs queries on a SQLite database or in a transaction.
+type querier interface {
+	Exec(query string, args ...interface{}) (sql.Result, error)
+	Query(query string, args ...interface{}) (*sql.Rows, error)
+	QueryRow(query string, args ...interface{}) *sql.Row
+}
+
+var _ Database = &SQLiteStore{}
+
+// ConnectSQLite opens the SQLite database in the file at the given path,
+// creating it if it doesn't exist, without migrating it. Use ":memory:" for
+// a database that only lives in memory.
+func ConnectSQLite(path string) (*SQLiteStore, error) {
+	db, err := sql.Open("sqlite", "file:"+path+
+		"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)")
+	if err != nil {
+		return nil, err
+	}
+	// SQLite only allows one writer at a time, and each connection to an
+	// in-memory database gets a database of its own
+	db.SetMaxOpenConns(1)
+	s := &SQLiteStore{conn: db, db: db}
+	if err := s.Ping(); err != nil {
+		db.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// NewSQLite opens the SQLite database in the file at the given path like
+// ConnectSQLite does, and applies any migrations that have not been applied
+// to it yet.
+func NewSQLite(path string) (*SQLiteStore, error) {
+	s, err := ConnectSQLite(path)
+	if err != nil {
+		return nil, err
+	}
+	if err := migrate(s); err != nil {
+		s.Close()
+		return nil, err
+	}
+	return s, nil
+}
+
+// Ping checks that we can reach the database.
+func (s *SQLiteStore) Ping() error {
+	return s.conn.Ping()
+}
+
+// Close closes the database.
+func (s *SQLiteStore) Close() error {
+	return s.conn.Close()
+}
+
+// RunInTransaction calls the given function with a store whose queries run
+// in a transaction, which is committed if the function returns nil and rolled
+// back otherwise. If the store is already in a transaction, the function's
+// changes are made in a savepoint instead.
+func (s *SQLiteStore) RunInTransaction(fn func(Store) error) error {
+	return s.transaction(func(tx *SQLiteStore) error { return fn(tx) })
+}
+
+// transaction is like RunInTransaction, but calls the given function with a
+// SQLiteStore.
+func (s *SQLiteStore) transaction(fn func(*SQLiteStore) error) error {
+	if _, ok := s.db.(*sql.Tx); ok {
+		return s.savepoint(fn)
+	}
+	tx, err := s.conn.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(&SQLiteStore{conn: s.conn, db: tx}); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
+
+// savepoint calls the given function with the store, and rolls back the
+// changes it made if it returns an error. The store must be in a transaction.
+func (s *SQLiteStore) savepoint(fn func(*SQLiteStore) error) error {
+	if _, err := s.db.Exec("SAVEPOINT rocket"); err != nil {
+		return err
+	}
+	if err := fn(s); err != nil {
+		s.db.Exec("ROLLBACK TO SAVEPOINT rocket")
+		s.db.Exec("RELEASE SAVEPOINT rocket")
+		return err
+	}
+	_, err := s.db.Exec("RELEASE SAVEPOINT rocket")
+	return err
+}
+
+// scanner is a row or rows that can be scanned.
+type scanner interface {
+	Scan(dest ...interface{}) error
+}
+
+// scanMember populates the given member with the columns listed in
+// memberColumns, after scanning any columns before them into the given
+// destinations.
+func scanMember(row scanner, m *model.Member, before ...interface{}) error {
+	var name, email, github, major, position, bio, image sql.NullString
+	var leftAt sql.NullTime
+	dest := append(before, &m.SlackID, &name, &email, &github, &major,
+		&position, &bio, &image, &m.IsTechLead, &m.IsAdmin, &m.CreatedAt, &leftAt)
+	err := row.Scan(dest...)
+	if err == sql.ErrNoRows {
+		return ErrNotFound
+	}
+	m.Name = name.String
+	m.Email = email.String
+	m.GithubUsername = github.String
+	m.Major = major.String
+	m.Position = position.String
+	m.Biography = bio.String
+	m.ImageURL = image.String
+	m.LeftAt = nil
+	if leftAt.Valid {
+		m.LeftAt = &leftAt.Time
+	}
+	return err
+}
+
+// nullable returns nil for empty strings, so that they are stored as NULL
+// like go-pg stores them.
+func nullable(s string) interface{} {
+	if s == "" {
+		return nil
+	}
+	return s
+}
+
+// GetMemberBySlackID populates the given member with information from the DB
+// or returns an error.
+func (s *SQLiteStore) GetMemberBySlackID(member *model.Member) error {
+	row := s.db.QueryRow("SELECT "+memberColumns+" FROM members WHERE slack_id = ?",
+		member.SlackID)
+	if err := scanMember(row, member); err != nil {
+		return err
+	}
+	return s.setTags(member)
+}
+
+// GetMembers populates the given members with information for all members
+// from the DB or returns an error.
+func (s *SQLiteStore) GetMembers(members *model.Members) error {
+	return s.queryMembers(members, "SELECT "+memberColumns+
+		" FROM members WHERE left_at IS NULL ORDER BY name")
+}
+
+// GetAllMembers populates the given members with information for all members
+// including alumni from the DB or returns an error.
+func (s *SQLiteStore) GetAllMembers(members *model.Members) error {
+	return s.queryMembers(members, "SELECT "+memberColumns+" FROM members ORDER BY name")
+}
+
+// GetTechLeads populates given members with all current tech leads.
+func (s *SQLiteStore) GetTechLeads(members *model.Members) error {
+	return s.queryMembers(members, "SELECT "+memberColumns+
+		" FROM members WHERE is_tech_lead AND left_at IS NULL ORDER BY name")
+}
+
+// GetAdmins populates the given members with information for all admin
+// members or returns an error.
+func (s *SQLiteStore) GetAdmins(members *model.Members) error {
+	return s.queryMembers(members, "SELECT "+memberColumns+
+		" FROM members WHERE is_admin AND left_at IS NULL ORDER BY name")
+}
+
+// queryMembers populates the given members with the members returned by the
+// given query, which must select memberColumns.
+func (s *SQLiteStore) queryMembers(members *model.Members, query string, args ...interface{}) error {
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	*members = model.Members{}
+	for rows.Next() {
+		m := &model.Member{}
+		if err := scanMember(rows, m); err != nil {
+			return err
+		}
+		*members = append(*members, m)
+	}
+	if err := rows.Err(); err != nil {
+		return err
+	}
+	// Only one connection is open, so the rows must be closed before tags
+	// can be queried
+	rows.Close()
+	return s.setTags(*members...)
+}
+
+// CreateMember adds the given member to the DB or returns an error.
+func (s *SQLiteStore) CreateMember(member *model.Member) error {
+	if member.CreatedAt.IsZero() {
+		member.CreatedAt = time.Now().UTC()
+	}
+	_, err := s.db.Exec(`INSERT INTO members (slack_id, name, email,
+		github_username, program, position, biography, image_url,
+		is_tech_lead, is_admin, created_at, left_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT DO NOTHING`,
+		member.SlackID, nullable(member.Name), nullable(member.Email),
+		nullable(member.GithubUsername), nullable(member.Major),
+		nullable(member.Position), nullable(member.Biography),
+		nullable(member.ImageURL), member.IsTechLead, member.IsAdmin,
+		member.CreatedAt, member.LeftAt)
+	return err
+}
+
+// UpdateMember updates the entry in the DB for the given member the same way
+// DAL.UpdateMember does.
+func (s *SQLiteStore) UpdateMember(member *model.Member) error {
+	existing := &model.Member{SlackID: member.SlackID}
+	if err := s.GetMemberBySlackID(existing); err != nil {
+		return err
+	}
+	if member.Name != "" {
+		existing.Name = member.Name
+	}
+	if member.Email != "" {
+		existing.Email = member.Email
+	}
+	if existing.Position == "" {
+		existing.Position = member.Position
+	}
+	_, err := s.db.Exec(
+		"UPDATE members SET name = ?, email = ?, position = ? WHERE slack_id = ?",
+		nullable(existing.Name), nullable(existing.Email),
+		nullable(existing.Position), existing.SlackID)
+	return err
+}
+
+// DeleteMember deletes a member from the DB or returns an error.
+func (s *SQLiteStore) DeleteMember(member *model.Member) error {
+	_, err := s.db.Exec("DELETE FROM members WHERE slack_id = ?", member.SlackID)
+	return err
+}
+
+// SetMemberName updates the name of the given member in the DB or returns
+// an error.
+func (s *SQLiteStore) SetMemberName(member *model.Member) error {
+	return s.setMember(member, "name", nullable(member.Name))
+}
+
+// SetMemberEmail updates the email of the given member in the DB or returns
+// an error.
+func (s *SQLiteStore) SetMemberEmail(member *model.Member) error {
+	return s.setMember(member, "email", nullable(member.Email))
+}
+
+// SetMemberGitHubUsername updates the GitHub username of the given member in
+// the DB or returns an error.
+func (s *SQLiteStore) SetMemberGitHubUsername(member *model.Member) error {
+	return s.setMember(member, "github_username", nullable(member.GithubUsername))
+}
+
+// SetMemberMajor updates the major of the given member in the DB or returns
+// an error.
+func (s *SQLiteStore) SetMemberMajor(member *model.Member) error {
+	return s.setMember(member, "program", nullable(member.Major))
+}
+
+// SetMemberPosition updates the position of the given member in the DB or
+// returns an error.
+func (s *SQLiteStore) SetMemberPosition(member *model.Member) error {
+	return s.setMember(member, "position", nullable(member.Position))
+}
+
+// SetMemberBiography updates the bio of the given member in the DB or
+// returns an error.
+func (s *SQLiteStore) SetMemberBiography(member *model.Member) error {
+	return s.setMember(member, "biography", nullable(member.Biography))
+}
+
+// SetMemberImageURL updates the image URL of the given member in the DB or
+// returns an error.
+func (s *SQLiteStore) SetMemberImageURL(member *model.Member) error {
+	return s.setMember(member, "image_url", nullable(member.ImageURL))
+}
+
+// SetMemberIsAdmin updates whether the given member is an admin in the DB
+// or returns an error.
+func (s *SQLiteStore) SetMemberIsAdmin(member *model.Member) error {
+	return s.setMember(member, "is_admin", member.IsAdmin)
+}
+
+// SetMemberIsTechLead updates whether the given member is a tech lead in
+// the DB or returns an error.
+func (s *SQLiteStore) SetMemberIsTechLead(member *model.Member) error {
+	return s.setMember(member, "is_tech_lead", member.IsTechLead)
+}
+
+// SetMemberLeftAt updates when the given member left Launch Pad in the DB
+// or returns an error.
+func (s *SQLiteStore) SetMemberLeftAt(member *model.Member) error {
+	return s.setMember(member, "left_at", member.LeftAt)
+}
+
+// SetMemberSkills replaces the skills of the given member in the DB or
+// returns an error.
+func (s *SQLiteStore) SetMemberSkills(member *model.Member) error {
+	return s.setMemberTags(member, model.TagKindSkill, member.Skills)
+}
+
+// SetMemberInterests replaces the interests of the given member in the DB or
+// returns an error.
+func (s *SQLiteStore) SetMemberInterests(member *model.Member) error {
+	return s.setMemberTags(member, model.TagKindInterest, member.Interests)
+}
+
+// GetMembersByTag populates the given members with the members who aren't
+// alumni and have the given tag, or returns an error.
+func (s *SQLiteStore) GetMembersByTag(tag *model.Tag, members *model.Members) error {
+	name := model.NormalizeTag(tag.Name)
+	return s.queryMembers(members, "SELECT "+memberColumns+
+		" FROM members WHERE left_at IS NULL AND slack_id IN ("+
+		taggedMembersQuery+") ORDER BY name", name, tag.Kind, tag.Kind)
+}
+
+// setMemberTags replaces the tags of the given kind on the given member's
+// profile with tags with the given names, creating tags that don't exist yet.
+func (s *SQLiteStore) setMemberTags(member *model.Member, kind model.TagKind, names []string) error {
+	var n int
+	err := s.db.QueryRow("SELECT COUNT(*) FROM members WHERE slack_id = ?",
+		member.SlackID).Scan(&n)
+	if err != nil || n == 0 {
+		return err
+	}
+	return s.transaction(func(tx *SQLiteStore) error {
+		_, err := tx.db.Exec(`DELETE FROM member_tags WHERE member_slack_id = ?
+			AND tag_id IN (SELECT id FROM tags WHERE kind = ?)`, member.SlackID, kind)
+		if err != nil {
+			return err
+		}
+		for _, name := range model.NormalizeTags(names) {
+			_, err := tx.db.Exec("INSERT INTO tags (kind, name) VALUES (?, ?) ON CONFLICT DO NOTHING",
+				kind, name)
+			if err != nil {
+				return err
+			}
+			_, err = tx.db.Exec(`INSERT INTO member_tags (member_slack_id, tag_id)
+				SELECT ?, id FROM tags WHERE kind = ? AND name = ?`, member.SlackID, kind, name)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// setTags sets the skills and interests of the given members.
+func (s *SQLiteStore) setTags(members ...*model.Member) error {
+	bySlackID := map[string]*model.Member{}
+	for _, member := range members {
+		member.Skills = []string{}
+		member.Interests = []string{}
+		bySlackID[member.SlackID] = member
+	}
+	if len(members) == 0 {
+		return nil
+	}
+	rows, err := s.db.Query(`SELECT member_tags.member_slack_id, tags.kind, tags.name
+		FROM member_tags JOIN tags ON tags.id = member_tags.tag_id
+		ORDER BY tags.name`)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var slackID, name string
+		var kind model.TagKind
+		if err := rows.Scan(&slackID, &kind, &name); err != nil {
+			return err
+		}
+		member, ok := bySlackID[slackID]
+		if !ok {
+			continue
+		}
+		switch kind {
+		case model.TagKindSkill:
+			member.Skills = append(member.Skills, name)
+		case model.TagKindInterest:
+			member.Interests = append(member.Interests, name)
+		}
+	}
+	return rows.Err()
+}
+
+// Search populates the given results with the members and teams that match
+// the given query. SQLite databases are only used locally, so they are
+// searched in Go rather than with a full-text index.
+func (s *SQLiteStore) Search(query string, results *model.SearchResults) error {
+	return search(s, query, results)
+}
+
+// setMember sets the given column of the given member to the given value.
+func (s *SQLiteStore) setMember(member *model.Member, column string, value interface{}) error {
+	_, err := s.db.Exec("UPDATE members SET "+column+" = ? WHERE slack_id = ?",
+		value, member.SlackID)
+	return err
+}
+
+// GetTeamByName provides team with corresponding name.
+func (s *SQLiteStore) GetTeamByName(team *model.Team) error {
+	return s.getTeam(team, "name = ?", team.Name)
+}
+
+// GetTeamByGithubID provides team with corresponding GitHub ID.
+func (s *SQLiteStore) GetTeamByGithubID(team *model.Team) error {
+	return s.getTeam(team, "github_team_id = ?", team.GithubTeamID)
+}
+
+// getTeam populates the given team with the team that matches the given
+// condition, its members in the current term, and whether it's archived.
+func (s *SQLiteStore) getTeam(team *model.Team, where string, args ...interface{}) error {
+	term, err := s.currentTerm()
+	if err != nil {
+		return err
+	}
+	teams := model.Teams{}
+	if err := s.queryTeams(&teams, term, true, "WHERE "+where, args...); err != nil {
+		return err
+	}
+	if len(teams) == 0 {
+		return ErrNotFound
+	}
+	*team = *teams[0]
+	var n int
+	err = s.db.QueryRow(
+		"SELECT COUNT(*) FROM team_terms WHERE team_github_team_id = ? AND term = ?",
+		team.GithubTeamID, term).Scan(&n)
+	team.Archived = n == 0
+	return err
+}
+
+// GetTeams gets all current teams.
+func (s *SQLiteStore) GetTeams(teams *model.Teams) error {
+	term, err := s.currentTerm()
+	if err != nil {
+		return err
+	}
+	return s.GetTeamsByTerm(term, teams)
+}
+
+// GetTeamsByTerm gets all teams that ran in the given term.
+func (s *SQLiteStore) GetTeamsByTerm(term string, teams *model.Teams) error {
+	return s.queryTeams(teams, term, true, inTermClause, term)
+}
+
+// GetTeamNames gets all names of current teams.
+func (s *SQLiteStore) GetTeamNames(teams *model.Teams) error {
+	term, err := s.currentTerm()
+	if err != nil {
+		return err
+	}
+	if err := s.queryTeams(teams, term, false, inTermClause, term); err != nil {
+		return err
+	}
+	for i, t := range *teams {
+		(*teams)[i] = &model.Team{Name: t.Name}
+	}
+	return nil
+}
+
+// inTermClause selects the teams that run in the term given as its argument.
+const inTermClause = "WHERE github_team_id IN " +
+	"(SELECT team_github_team_id FROM team_terms WHERE term = ?)"
+
+// queryTeams populates the given teams with the teams that match the given
+// where clause, ordered by name, and with their members in the given term if
+// withMembers is true.
+func (s *SQLiteStore) queryTeams(teams *model.Teams, term string, withMembers bool, where string, args ...interface{}) error {
+	rows, err := s.db.Query("SELECT name, github_team_id, platform, created_at "+
+		"FROM teams "+where+" ORDER BY name", args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	*teams = model.Teams{}
+	byID := map[int]*model.Team{}
+	for rows.Next() {
+		t := &model.Team{}
+		var name, platform sql.NullString
+		if err := rows.Scan(&name, &t.GithubTeamID, &platform, &t.CreatedAt); err != nil {
+			return err
+		}
+		t.Name = name.String
+		t.Platform = platform.String
+		t.Members = []*model.Member{}
+		*teams = append(*teams, t)
+		byID[t.GithubTeamID] = t
+	}
+	if err := rows.Err(); err != nil || !withMembers || len(byID) == 0 {
+		return err
+	}
+	rows.Close()
+
+	memberRows, err := s.db.Query("SELECT team_members.team_github_team_id, "+
+		"team_members.role, "+memberColumns+" FROM members JOIN team_members "+
+		"ON team_members.member_slack_id = members.slack_id "+
+		"WHERE team_members.term = ? ORDER BY members.name", term)
+	if err != nil {
+		return err
+	}
+	defer memberRows.Close()
+	for memberRows.Next() {
+		var teamID int
+		m := &model.Member{}
+		if err := scanMember(memberRows, m, &teamID, &m.TeamRole); err != nil {
+			return err
+		}
+		if t, ok := byID[teamID]; ok {
+			t.Members = append(t.Members, m)
+		}
+	}
+	return memberRows.Err()
+}
+
+// CreateTeam inserts given team into the database and runs it in the
+// current term.
+func (s *SQLiteStore) CreateTeam(team *model.Team) error {
+	if team.CreatedAt.IsZero() {
+		team.CreatedAt = time.Now().UTC()
+	}
+	res, err := s.db.Exec(`INSERT INTO teams (name, github_team_id, platform, created_at)
+		VALUES (?, ?, ?, ?) ON CONFLICT DO NOTHING`,
+		nullable(team.Name), team.GithubTeamID, nullable(team.Platform), team.CreatedAt)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrTeamExists
+	}
+	term, err := s.currentTerm()
+	if err != nil {
+		return err
+	}
+	return s.AddTeamToTerm(team, term)
+}
+
+// UpdateTeam updates given team with new team.
+func (s *SQLiteStore) UpdateTeam(currentTeam, newTeam *model.Team) error {
+	if newTeam.Name != "" {
+		currentTeam.Name = newTeam.Name
+	}
+	if newTeam.Platform != "" {
+		currentTeam.Platform = newTeam.Platform
+	}
+	_, err := s.db.Exec("UPDATE teams SET name = ?, platform = ? WHERE github_team_id = ?",
+		nullable(currentTeam.Name), nullable(currentTeam.Platform), currentTeam.GithubTeamID)
+	return err
+}
+
+// DeleteTeamByName deletes team with given name from the database.
+func (s *SQLiteStore) DeleteTeamByName(team *model.Team) error {
+	_, err := s.db.Exec("DELETE FROM teams WHERE name = ?", team.Name)
+	return err
+}
+
+// UnlinkTeam negates the GitHub team ID of the team with given team's GitHub
+// team ID, moving its terms, memberships and project with it.
+func (s *SQLiteStore) UnlinkTeam(team *model.Team) error {
+	return s.transaction(func(tx *SQLiteStore) error {
+		existing := &model.Team{GithubTeamID: team.GithubTeamID}
+		if err := tx.GetTeamByGithubID(existing); err != nil {
+			return err
+		}
+		for _, q := range unlinkTeamQueries(existing) {
+			if _, err := tx.db.Exec(q.query, q.params...); err != nil {
+				return err
+			}
+		}
+		team.GithubTeamID = -existing.GithubTeamID
+		return nil
+	})
+}
+
+// CreateTeamMember inserts a team member into the database.
+func (s *SQLiteStore) CreateTeamMember(member *model.TeamMember) error {
+	if err := s.setTerm(member); err != nil {
+		return err
+	}
+	if member.Role == "" {
+		member.Role = model.TeamRoleDeveloper
+	}
+	_, err := s.db.Exec(`INSERT INTO team_members
+		(team_github_team_id, member_slack_id, term, role)
+		VALUES (?, ?, ?, ?) ON CONFLICT DO NOTHING`,
+		member.GithubTeamID, member.MemberSlackID, member.Term, member.Role)
+	return err
+}
+
+// SetTeamMemberRole updates the role of a team member in the database.
+func (s *SQLiteStore) SetTeamMemberRole(member *model.TeamMember) error {
+	if err := s.setTerm(member); err != nil {
+		return err
+	}
+	res, err := s.db.Exec(`UPDATE team_members SET role = ?
+		WHERE team_github_team_id = ? AND member_slack_id = ? AND term = ?`,
+		member.Role, member.GithubTeamID, member.MemberSlackID, member.Term)
+	if err != nil {
+		return err
+	}
+	n, err := res.RowsAffected()
+	if err != nil {
+		return err
+	}
+	if n == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
+// DeleteTeamMember removes team member from database.
+func (s *SQLiteStore) DeleteTeamMember(member *model.TeamMember) error {
+	if err := s.setTerm(member); err != nil {
+		return err
+	}
+	_, err := s.db.Exec(`DELETE FROM team_members
+		WHERE team_github_team_id = ? AND member_slack_id = ? AND term = ?`,
+		member.GithubTeamID, member.MemberSlackID, member.Term)
+	return err
+}
+
+// setTerm puts the given team member in the current term if their term isn't
+// set.
+func (s *SQLiteStore) setTerm(member *model.TeamMember) error {
+	if member.Term != "" {
+		return nil
+	}
+	term, err := s.currentTerm()
+	member.Term = term
+	return err
+}
+
+// CreateTerm inserts the given term into the database.
+func (s *SQLiteStore) CreateTerm(term *model.Term) error {
+	if term.StartedAt.IsZero() {
+		term.StartedAt = time.Now().UTC()
+	}
+	_, err := s.db.Exec(
+		"INSERT INTO terms (code, started_at) VALUES (?, ?) ON CONFLICT DO NOTHING",
+		term.Code, term.StartedAt)
+	return err
+}
+
+// GetTerms populates the given terms with all terms, newest first.
+func (s *SQLiteStore) GetTerms(terms *model.Terms) error {
+	rows, err := s.db.Query(
+		"SELECT code, started_at FROM terms ORDER BY started_at DESC, code DESC")
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	*terms = model.Terms{}
+	for rows.Next() {
+		t := &model.Term{}
+		if err := rows.Scan(&t.Code, &t.StartedAt); err != nil {
+			return err
+		}
+		*terms = append(*terms, t)
+	}
+	return rows.Err()
+}
+
+// GetCurrentTerm populates the given term with the term that started most
+// recently.
+func (s *SQLiteStore) GetCurrentTerm(term *model.Term) error {
+	err := s.db.QueryRow("SELECT code, started_at FROM terms "+
+		"ORDER BY started_at DESC, code DESC LIMIT 1").Scan(&term.Code, &term.StartedAt)
+	if err == sql.ErrNoRows {
+		return ErrNotFound
+	}
+	return err
+}
+
+// AddTeamToTerm records that the given team runs in the given term.
+func (s *SQLiteStore) AddTeamToTerm(team *model.Team, term string) error {
+	_, err := s.db.Exec(`INSERT INTO team_terms (team_github_team_id, term)
+		VALUES (?, ?) ON CONFLICT DO NOTHING`, team.GithubTeamID, term)
+	return err
+}
+
+// RemoveTeamFromTerm records that the given team doesn't run in the given
+// term. Its memberships in the term are removed with it.
+func (s *SQLiteStore) RemoveTeamFromTerm(team *model.Team, term string) error {
+	_, err := s.db.Exec("DELETE FROM team_terms WHERE team_github_team_id = ? AND term = ?",
+		team.GithubTeamID, term)
+	return err
+}
+
+// currentTerm returns the code of the current term.
+func (s *SQLiteStore) currentTerm() (string, error) {
+	term := &model.Term{}
+	if err := s.GetCurrentTerm(term); err != nil {
+		return "", err
+	}
+	return term.Code, nil
+}
+
+// projectColumns are the columns of the projects table, and the name of the
+// project's team, in the order queryProjects reads them.
+const projectColumns = `projects.team_github_team_id, teams.name, projects.name,
+	projects.description, projects.repos, projects.website, projects.status,
+	projects.tech_stack, projects.created_at, projects.updated_at`
+
+// GetProjectByTeam provides the project of the team with the given project's
+// GitHub team ID.
+func (s *SQLiteStore) GetProjectByTeam(project *model.Project) error {
+	projects := model.Projects{}
+	err := s.queryProjects(&projects, "WHERE projects.team_github_team_id = ?",
+		project.GithubTeamID)
+	if err != nil {
+		return err
+	}
+	if len(projects) == 0 {
+		return ErrNotFound
+	}
+	*project = *projects[0]
+	return nil
+}
+
+// GetProjects gets all projects.
+func (s *SQLiteStore) GetProjects(projects *model.Projects) error {
+	return s.queryProjects(projects, "")
+}
+
+// queryProjects populates the given projects with the projects that match
+// the given where clause, ordered by name.
+func (s *SQLiteStore) queryProjects(projects *model.Projects, where string, args ...interface{}) error {
+	rows, err := s.db.Query("SELECT "+projectColumns+" FROM projects JOIN teams "+
+		"ON teams.github_team_id = projects.team_github_team_id "+where+
+		" ORDER BY projects.name, projects.team_github_team_id", args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	*projects = model.Projects{}
+	for rows.Next() {
+		p := &model.Project{}
+		var team, name, description, website sql.NullString
+		var repos, techStack string
+		err := rows.Scan(&p.GithubTeamID, &team, &name, &description, &repos,
+			&website, &p.Status, &techStack, &p.CreatedAt, &p.UpdatedAt)
+		if err != nil {
+			return err
+		}
+		p.Team = team.String
+		p.Name = name.String
+		p.Description = description.String
+		p.Website = website.String
+		if err := json.Unmarshal([]byte(repos), &p.Repos); err != nil {
+			return err
+		}
+		if err := json.Unmarshal([]byte(techStack), &p.TechStack); err != nil {
+			return err
+		}
+		*projects = append(*projects, p)
+	}
+	return rows.Err()
+}
+
+// SaveProject inserts the given project into the database, or replaces the
+// project of its team.
+func (s *SQLiteStore) SaveProject(project *model.Project) error {
+	setProjectDefaults(project)
+	repos, err := json.Marshal(project.Repos)
+	if err != nil {
+		return err
+	}
+	techStack, err := json.Marshal(project.TechStack)
+	if err != nil {
+		return err
+	}
+	_, err = s.db.Exec(`INSERT INTO projects (team_github_team_id, name,
+		description, repos, website, status, tech_stack, created_at, updated_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?, ?, ?)
+		ON CONFLICT (team_github_team_id) DO UPDATE SET
+		name = excluded.name, description = excluded.description,
+		repos = excluded.repos, website = excluded.website,
+		status = excluded.status, tech_stack = excluded.tech_stack,
+		updated_at = excluded.updated_at`,
+		project.GithubTeamID, nullable(project.Name), nullable(project.Description),
+		string(repos), nullable(project.Website), project.Status,
+		string(techStack), project.CreatedAt, project.UpdatedAt)
+	if err != nil {
+		return err
+	}
+	return s.db.QueryRow("SELECT created_at FROM projects WHERE team_github_team_id = ?",
+		project.GithubTeamID).Scan(&project.CreatedAt)
+}
+
+// DeleteProject deletes the project of the team with the given project's
+// GitHub team ID from the database.
+func (s *SQLiteStore) DeleteProject(project *model.Project) error {
+	_, err := s.db.Exec("DELETE FROM projects WHERE team_github_team_id = ?",
+		project.GithubTeamID)
+	return err
+}
+
+// CreateAuditEntry appends the given entry to the audit log.
+func (s *SQLiteStore) CreateAuditEntry(entry *model.AuditEntry) error {
+	if entry.CreatedAt.IsZero() {
+		entry.CreatedAt = time.Now().UTC()
+	}
+	res, err := s.db.Exec(`INSERT INTO audit_log
+		(actor_slack_id, command, action, target, before, after, created_at)
+		VALUES (?, ?, ?, ?, ?, ?, ?)`,
+		nullable(entry.ActorSlackID), nullable(entry.Command), entry.Action,
+		nullable(entry.Target), nullable(entry.Before), nullable(entry.After),
+		entry.CreatedAt)
+	if err != nil {
+		return err
+	}
+	id, err := res.LastInsertId()
+	entry.ID = int(id)
+	return err
+}
+
+// GetAuditEntries populates the given entries with the entries in the audit
+// log that match the given filter, newest first.
+func (s *SQLiteStore) GetAuditEntries(filter AuditFilter, entries *model.AuditEntries) error {
+	query := "SELECT id, actor_slack_id, command, action, target, before, after, " +
+		"created_at FROM audit_log WHERE 1 = 1"
+	args := []interface{}{}
+	if filter.ActorSlackID != "" {
+		query += " AND actor_slack_id = ?"
+		args = append(args, filter.ActorSlackID)
+	}
+	if filter.Action != "" {
+		query += " AND action = ?"
+		args = append(args, filter.Action)
+	}
+	if filter.Target != "" {
+		query += " AND target = ?"
+		args = append(args, filter.Target)
+	}
+	if !filter.Since.IsZero() {
+		query += " AND created_at >= ?"
+		args = append(args, filter.Since.UTC())
+	}
+	if !filter.Until.IsZero() {
+		query += " AND created_at < ?"
+		args = append(args, filter.Until.UTC())
+	}
+	query += " ORDER BY created_at DESC, id DESC"
+	if filter.Limit > 0 {
+		query += " LIMIT ?"
+		args = append(args, filter.Limit)
+	}
+
+	rows, err := s.db.Query(query, args...)
+	if err != nil {
+		return err
+	}
+	defer rows.Close()
+	*entries = model.AuditEntries{}
+	for rows.Next() {
+		e := &model.AuditEntry{}
+		var actor, command, target, before, after sql.NullString
+		err := rows.Scan(&e.ID, &actor, &command, &e.Action, &target, &before,
+			&after, &e.CreatedAt)
+		if err != nil {
+			return err
+		}
+		e.ActorSlackID = actor.String
+		e.Command = command.String
+		e.Target = target.String
+		e.Before = before.String
+		e.After = after.String
+		*entries = append(*entries, e)
+	}
+	return rows.Err()
+}
@@ -0,0 +1,165 @@
+package data
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/ubclaunchpad/rocket/schema"
+)
+
+// MigrateUp applies the migrations that have not been applied to the database
+// yet, in order. Returns the migrations that were applied, and
+// ErrSchemaAhead if the database has migrations applied that Rocket doesn't
+// know about.
+func (s *SQLiteStore) MigrateUp() ([]schema.Migration, error) {
+	migrations, applied, err := s.migrations()
+	if err != nil {
+		return nil, err
+	}
+	if len(applied) > len(migrations) {
+		return nil, ErrSchemaAhead
+	}
+
+	done := []schema.Migration{}
+	for _, m := range migrations[len(applied):] {
+		m := m
+		err := s.runInTransaction(func(tx *sql.Tx) error {
+			if _, err := tx.Exec(m.Up); err != nil {
+				return err
+			}
+			_, err := tx.Exec(
+				"INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+				m.Version, m.Name, time.Now().UTC())
+			return err
+		})
+		if err != nil {
+			return done, fmt.Errorf("failed to apply migration %d_%s: %s",
+				m.Version, m.Name, err)
+		}
+		done = append(done, m)
+	}
+	return done, nil
+}
+
+// MigrateDown reverts the last migration that was applied to the database.
+// Returns the migration that was reverted, or nil if no migrations have been
+// applied.
+func (s *SQLiteStore) MigrateDown() (*schema.Migration, error) {
+	migrations, applied, err := s.migrations()
+	if err != nil {
+		return nil, err
+	}
+	if len(applied) > len(migrations) {
+		return nil, ErrSchemaAhead
+	}
+	if len(applied) == 0 {
+		return nil, nil
+	}
+
+	m := migrations[len(applied)-1]
+	err = s.runInTransaction(func(tx *sql.Tx) error {
+		if _, err := tx.Exec(m.Down); err != nil {
+			return err
+		}
+		_, err := tx.Exec("DELETE FROM schema_migrations WHERE version = ?", m.Version)
+		return err
+	})
+	if err != nil {
+		return nil, fmt.Errorf("failed to revert migration %d_%s: %s",
+			m.Version, m.Name, err)
+	}
+	return &m, nil
+}
+
+// MigrationStatus returns every migration Rocket knows about and whether it
+// has been applied to the database.
+func (s *SQLiteStore) MigrationStatus() ([]MigrationStatus, error) {
+	migrations, applied, err := s.migrations()
+	if err != nil {
+		return nil, err
+	}
+	return migrationStatus(migrations, applied)
+}
+
+// Baseline records that every migration up to and including the given
+// version has been applied to the database without running them.
+func (s *SQLiteStore) Baseline(version int) error {
+	migrations, applied, err := s.migrations()
+	if err != nil {
+		return err
+	}
+	if len(applied) > 0 {
+		return errors.New("the database already has migrations applied")
+	}
+	if version < 0 || version >= len(migrations) {
+		return fmt.Errorf("there is no migration with version %d", version)
+	}
+	now := time.Now().UTC()
+	return s.runInTransaction(func(tx *sql.Tx) error {
+		for _, m := range migrations[:version+1] {
+			_, err := tx.Exec(
+				"INSERT INTO schema_migrations (version, name, applied_at) VALUES (?, ?, ?)",
+				m.Version, m.Name, now)
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// migrations returns the migrations Rocket knows about and the migrations
+// that have been applied to the database, in order. The table that tracks
+// applied migrations is created if it doesn't exist yet.
+func (s *SQLiteStore) migrations() ([]schema.Migration, []appliedMigration, error) {
+	migrations, err := schema.SQLiteMigrations()
+	if err != nil {
+		return nil, nil, err
+	}
+	_, err = s.db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version INTEGER PRIMARY KEY,
+		name TEXT NOT NULL,
+		applied_at TIMESTAMP NOT NULL
+	)`)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	rows, err := s.db.Query(
+		"SELECT version, name, applied_at FROM schema_migrations ORDER BY version")
+	if err != nil {
+		return nil, nil, err
+	}
+	defer rows.Close()
+	applied := []appliedMigration{}
+	for rows.Next() {
+		var m appliedMigration
+		if err := rows.Scan(&m.Version, &m.Name, &m.AppliedAt); err != nil {
+			return nil, nil, err
+		}
+		applied = append(applied, m)
+	}
+	if err := rows.Err(); err != nil {
+		return nil, nil, err
+	}
+	if err := checkApplied(applied); err != nil {
+		return nil, nil, err
+	}
+	return migrations, applied, nil
+}
+
+// runInTransaction calls the given function with a transaction that is
+// committed if the function succeeds and rolled back if it returns an error.
+func (s *SQLiteStore) runInTransaction(fn func(*sql.Tx) error) error {
+	tx, err := s.conn.Begin()
+	if err != nil {
+		return err
+	}
+	if err := fn(tx); err != nil {
+		tx.Rollback()
+		return err
+	}
+	return tx.Commit()
+}
@@ -0,0 +1,48 @@
+package data
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ubclaunchpad/rocket/schema"
+)
+
+func TestSQLiteMigrations(t *testing.T) {
+	s, err := ConnectSQLite(":memory:")
+	if !assert.Nil(t, err) {
+		return
+	}
+	defer s.Close()
+	migrations, err := schema.SQLiteMigrations()
+	assert.Nil(t, err)
+
+	applied, err := s.MigrateUp()
+	assert.Nil(t, err)
+	assert.Len(t, applied, len(migrations))
+	status, err := s.MigrationStatus()
+	assert.Nil(t, err)
+	for _, m := range status {
+		assert.True(t, m.Applied, m.Name)
+	}
+
+	// Revert every migration, then apply them all again
+	for i := len(migrations) - 1; i >= 0; i-- {
+		reverted, err := s.MigrateDown()
+		if assert.Nil(t, err) && assert.NotNil(t, reverted) {
+			assert.Equal(t, i, reverted.Version)
+		}
+	}
+	reverted, err := s.MigrateDown()
+	assert.Nil(t, err)
+	assert.Nil(t, reverted)
+	applied, err = s.MigrateUp()
+	assert.Nil(t, err)
+	assert.Len(t, applied, len(migrations))
+
+	// Migrations Rocket doesn't know about are refused
+	_, err = s.db.Exec("INSERT INTO schema_migrations (version, name, applied_at) "+
+		"VALUES (?, 'from_the_future', CURRENT_TIMESTAMP)", len(migrations))
+	assert.Nil(t, err)
+	_, err = s.MigrateUp()
+	assert.Equal(t, ErrSchemaAhead, err)
+}
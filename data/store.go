@@ -0,0 +1,192 @@
+package data
+
+import (
+	"errors"
+	"time"
+
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// ErrNotFound is returned when a member, team or project that was asked for
+// doesn't exist.
+var ErrNotFound = errors.New("not found")
+
+// ErrTeamExists is returned when a team is created with the name or GitHub
+// team ID of a team that already exists.
+var ErrTeamExists = errors.New("team already exists")
+
+// Store is Rocket's storage for members, teams, terms, projects, and the
+// audit log. DAL stores them in Postgres, SQLiteStore in SQLite, and
+// MemoryStore in memory.
+//
+// Methods that get a member, team or project take one with the fields
+// used to find it set, populate the rest of its fields, and return
+// ErrNotFound if it doesn't exist. Methods that set a field of a member
+// update the member with the given member's Slack ID, and do nothing if
+// there is no such member. Members that are got on their own have their
+// skills and interests set, and members of teams that are got with them have
+// their role on the team set. Team memberships whose term isn't set are in
+// the current term.
+type Store interface {
+	// Ping checks that the store can be reached.
+	Ping() error
+	// Close releases the store's resources.
+	Close() error
+	// RunInTransaction calls the given function with a store whose changes
+	// are kept if the function returns nil, and discarded if it returns an
+	// error. Transactions can be nested. Changes made through other stores
+	// while the function runs may wait for it to return.
+	RunInTransaction(fn func(Store) error) error
+
+	// GetMemberBySlackID gets the member with the given member's Slack ID.
+	GetMemberBySlackID(member *model.Member) error
+	// GetMembers gets all members who aren't alumni, ordered by name.
+	GetMembers(members *model.Members) error
+	// GetAllMembers gets all members including alumni, ordered by name.
+	GetAllMembers(members *model.Members) error
+	// GetTechLeads gets all members who are tech leads and aren't alumni,
+	// ordered by name.
+	GetTechLeads(members *model.Members) error
+	// GetAdmins gets all members who are admins and aren't alumni, ordered
+	// by name.
+	GetAdmins(members *model.Members) error
+	// CreateMember adds the given member, unless a member with the same
+	// Slack ID or email already exists.
+	CreateMember(member *model.Member) error
+	// UpdateMember updates the given member's name and email, and their
+	// position if it isn't set yet. Returns ErrNotFound if the member doesn't
+	// exist.
+	UpdateMember(member *model.Member) error
+	// DeleteMember deletes the given member and their team memberships.
+	DeleteMember(member *model.Member) error
+	SetMemberName(member *model.Member) error
+	SetMemberEmail(member *model.Member) error
+	SetMemberGitHubUsername(member *model.Member) error
+	SetMemberMajor(member *model.Member) error
+	SetMemberPosition(member *model.Member) error
+	SetMemberBiography(member *model.Member) error
+	SetMemberImageURL(member *model.Member) error
+	SetMemberIsAdmin(member *model.Member) error
+	SetMemberIsTechLead(member *model.Member) error
+	// SetMemberLeftAt updates when the given member left Launch Pad, making
+	// them alumni, or makes them active again if it's nil.
+	SetMemberLeftAt(member *model.Member) error
+	// SetMemberSkills replaces the skills on the given member's profile with
+	// the given member's Skills, normalized with model.NormalizeTags.
+	SetMemberSkills(member *model.Member) error
+	// SetMemberInterests replaces the interests on the given member's profile
+	// with the given member's Interests, normalized with model.NormalizeTags.
+	SetMemberInterests(member *model.Member) error
+	// GetMembersByTag gets all members who aren't alumni and have the tag
+	// with the given tag's name, and its kind if it's set, ordered by name.
+	GetMembersByTag(tag *model.Tag, members *model.Members) error
+
+	// GetTeamByName gets the team with the given team's name, and its
+	// members in the current term including alumni ordered by name.
+	GetTeamByName(team *model.Team) error
+	// GetTeamByGithubID gets the team with the given team's GitHub team ID,
+	// and its members in the current term ordered by name.
+	GetTeamByGithubID(team *model.Team) error
+	// GetTeams gets the teams running in the current term and their members,
+	// ordered by name.
+	GetTeams(teams *model.Teams) error
+	// GetTeamsByTerm gets the teams that ran in the term with the given code
+	// and their members in that term, ordered by name.
+	GetTeamsByTerm(term string, teams *model.Teams) error
+	// GetTeamNames gets the names of the teams running in the current term,
+	// ordered by name.
+	GetTeamNames(teams *model.Teams) error
+	// CreateTeam adds the given team to the current term. Returns
+	// ErrTeamExists if a team with the same name or GitHub team ID already
+	// exists.
+	CreateTeam(team *model.Team) error
+	// UpdateTeam updates the name and platform of the current team to those
+	// of the new team, where they are set.
+	UpdateTeam(currentTeam, newTeam *model.Team) error
+	// DeleteTeamByName deletes the team with the given team's name and its
+	// memberships in every term.
+	DeleteTeamByName(team *model.Team) error
+	// UnlinkTeam detaches the team with the given team's GitHub team ID from
+	// its GitHub team, which was deleted, by negating the team's GitHub team
+	// ID. The team keeps its terms, members and project. Returns ErrNotFound
+	// if the team doesn't exist.
+	UnlinkTeam(team *model.Team) error
+
+	// CreateTeamMember adds a member to a team with the given role, or as a
+	// developer if the role isn't set, unless they are already on it.
+	// Returns an error if the team or member doesn't exist, or the team
+	// isn't running in the member's term.
+	CreateTeamMember(member *model.TeamMember) error
+	// SetTeamMemberRole updates the role of a member on a team. Returns
+	// ErrNotFound if the member isn't on the team.
+	SetTeamMemberRole(member *model.TeamMember) error
+	// DeleteTeamMember removes a member from a team.
+	DeleteTeamMember(member *model.TeamMember) error
+
+	// CreateTerm adds the given term, which becomes the current term, unless
+	// a term with the same code already exists. Its start time is set to now
+	// if it isn't set.
+	CreateTerm(term *model.Term) error
+	// GetTerms gets all terms, newest first.
+	GetTerms(terms *model.Terms) error
+	// GetCurrentTerm gets the term that started most recently. Returns
+	// ErrNotFound if there are no terms.
+	GetCurrentTerm(term *model.Term) error
+	// AddTeamToTerm runs the given team in the term with the given code,
+	// unless it's already running in it.
+	AddTeamToTerm(team *model.Team, term string) error
+	// RemoveTeamFromTerm stops running the given team in the term with the
+	// given code, and removes its members in that term.
+	RemoveTeamFromTerm(team *model.Team, term string) error
+
+	// GetProjectByTeam gets the project of the team with the given project's
+	// GitHub team ID.
+	GetProjectByTeam(project *model.Project) error
+	// GetProjects gets all projects, ordered by name.
+	GetProjects(projects *model.Projects) error
+	// SaveProject adds the given project, or replaces the project of its team
+	// if it already has one. The project is planning if its status isn't
+	// set. Returns an error if the team doesn't exist.
+	SaveProject(project *model.Project) error
+	// DeleteProject deletes the project of the team with the given project's
+	// GitHub team ID.
+	DeleteProject(project *model.Project) error
+
+	// Search gets the members who aren't alumni and the current teams that
+	// match the given query, best match first. Members match on their name,
+	// position, major and biography, and teams on their name and platform.
+	// Words in the query match words that start with them, and teams whose
+	// names are similar to the query also match, so that typos still find
+	// them.
+	Search(query string, results *model.SearchResults) error
+
+	// CreateAuditEntry appends the given entry to the audit log, setting its
+	// ID and, if it isn't set, its creation time.
+	CreateAuditEntry(entry *model.AuditEntry) error
+	// GetAuditEntries gets the entries in the audit log that match the given
+	// filter, newest first.
+	GetAuditEntries(filter AuditFilter, entries *model.AuditEntries) error
+}
+
+// AuditFilter selects entries in the audit log. Entries match if they match
+// every field that is set.
+type AuditFilter struct {
+	ActorSlackID string
+	Action       string
+	Target       string
+	// Since and Until select entries created at or after Since and before
+	// Until
+	Since time.Time
+	Until time.Time
+	// Limit is the most entries to get, or 0 for all of them
+	Limit int
+}
+
+// matches returns true if the given entry matches the filter.
+func (f AuditFilter) matches(entry *model.AuditEntry) bool {
+	return (f.ActorSlackID == "" || entry.ActorSlackID == f.ActorSlackID) &&
+		(f.Action == "" || entry.Action == f.Action) &&
+		(f.Target == "" || entry.Target == f.Target) &&
+		(f.Since.IsZero() || !entry.CreatedAt.Before(f.Since)) &&
+		(f.Until.IsZero() || entry.CreatedAt.Before(f.Until))
+}
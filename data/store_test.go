@@ -0,0 +1,766 @@
+package data
+
+import (
+	"errors"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// storeTests are run against every Store implementation. Each test gets an
+// empty store.
+var storeTests = map[string]func(*testing.T, Store){
+	"Members":        testStoreMembers,
+	"MemberFields":   testStoreMemberFields,
+	"MemberTags":     testStoreMemberTags,
+	"MemberRoles":    testStoreMemberRoles,
+	"Teams":          testStoreTeams,
+	"TeamMembers":    testStoreTeamMembers,
+	"TeamRoles":      testStoreTeamRoles,
+	"NotFound":       testStoreNotFound,
+	"UniqueMembers":  testStoreUniqueMembers,
+	"UniqueTeams":    testStoreUniqueTeams,
+	"DeleteCascades": testStoreDeleteCascades,
+	"UnlinkTeams":    testStoreUnlinkTeams,
+	"AuditLog":       testStoreAuditLog,
+	"Alumni":         testStoreAlumni,
+	"Terms":          testStoreTerms,
+	"Projects":       testStoreProjects,
+	"Search":         testStoreSearch,
+	"Transactions":   testStoreTransactions,
+}
+
+// runStoreTests runs the store tests against the stores returned by the
+// given function, which also returns a function that cleans up the store.
+func runStoreTests(t *testing.T, newStore func() (Store, func(), error)) {
+	for name, test := range storeTests {
+		t.Run(name, func(t *testing.T) {
+			store, cleanup, err := newStore()
+			if !assert.Nil(t, err) {
+				return
+			}
+			defer cleanup()
+			test(t, store)
+		})
+	}
+}
+
+func TestMemoryStore(t *testing.T) {
+	runStoreTests(t, func() (Store, func(), error) {
+		return NewMemoryStore(), func() {}, nil
+	})
+}
+
+func TestDALStore(t *testing.T) {
+	if testing.Short() {
+		t.Skip("skipping integration test")
+	}
+	runStoreTests(t, func() (Store, func(), error) {
+		return newTestDBConnection()
+	})
+}
+
+func testStoreMembers(t *testing.T, s Store) {
+	assert.Nil(t, s.Ping())
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U2", Name: "Little Bruno"}))
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U1", Name: "Big Bruno"}))
+
+	var members model.Members
+	assert.Nil(t, s.GetMembers(&members))
+	if assert.Len(t, members, 2) {
+		assert.Equal(t, "Big Bruno", members[0].Name)
+		assert.Equal(t, "Little Bruno", members[1].Name)
+		assert.False(t, members[0].CreatedAt.IsZero())
+	}
+
+	// Updating a member only sets their position if it isn't set yet
+	assert.Nil(t, s.UpdateMember(&model.Member{
+		SlackID:  "U1",
+		Name:     "Bruno",
+		Email:    "bruno@ubclaunchpad.com",
+		Position: "Mascot",
+	}))
+	assert.Nil(t, s.UpdateMember(&model.Member{SlackID: "U1", Position: "Rocket"}))
+	member := &model.Member{SlackID: "U1"}
+	assert.Nil(t, s.GetMemberBySlackID(member))
+	assert.Equal(t, "Bruno", member.Name)
+	assert.Equal(t, "bruno@ubclaunchpad.com", member.Email)
+	assert.Equal(t, "Mascot", member.Position)
+
+	assert.Nil(t, s.DeleteMember(&model.Member{SlackID: "U1"}))
+	assert.Equal(t, ErrNotFound, s.GetMemberBySlackID(&model.Member{SlackID: "U1"}))
+	assert.Nil(t, s.GetMembers(&members))
+	assert.Len(t, members, 1)
+}
+
+func testStoreMemberFields(t *testing.T, s Store) {
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U1"}))
+	expected := model.Member{
+		SlackID:        "U1",
+		Name:           "Bruno",
+		Email:          "bruno@ubclaunchpad.com",
+		GithubUsername: "bruno",
+		Major:          "Rocket Science",
+		Position:       "Mascot",
+		Biography:      "Likes rockets",
+		ImageURL:       "https://ubclaunchpad.com/bruno.png",
+		IsAdmin:        true,
+		IsTechLead:     true,
+		Skills:         []string{"go", "react"},
+		Interests:      []string{"machine learning"},
+	}
+	for _, set := range []func(*model.Member) error{
+		s.SetMemberName,
+		s.SetMemberEmail,
+		s.SetMemberGitHubUsername,
+		s.SetMemberMajor,
+		s.SetMemberPosition,
+		s.SetMemberBiography,
+		s.SetMemberImageURL,
+		s.SetMemberIsAdmin,
+		s.SetMemberIsTechLead,
+		s.SetMemberSkills,
+		s.SetMemberInterests,
+	} {
+		m := expected
+		assert.Nil(t, set(&m))
+	}
+
+	member := &model.Member{SlackID: "U1"}
+	assert.Nil(t, s.GetMemberBySlackID(member))
+	member.CreatedAt = expected.CreatedAt
+	assert.Equal(t, expected, *member)
+
+	// Setting fields of members that don't exist does nothing
+	assert.Nil(t, s.SetMemberName(&model.Member{SlackID: "U2", Name: "Nobody"}))
+	assert.Equal(t, ErrNotFound, s.GetMemberBySlackID(&model.Member{SlackID: "U2"}))
+}
+
+func testStoreMemberTags(t *testing.T, s Store) {
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno"}))
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U2", Name: "Alice"}))
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U3", Name: "Carol"}))
+
+	// Tags are normalized
+	assert.Nil(t, s.SetMemberSkills(&model.Member{SlackID: "U1",
+		Skills: []string{" React", "go", "react ", "UI  Design", ""}}))
+	assert.Nil(t, s.SetMemberInterests(&model.Member{SlackID: "U1",
+		Interests: []string{"React"}}))
+	assert.Nil(t, s.SetMemberSkills(&model.Member{SlackID: "U2",
+		Skills: []string{"react"}}))
+	member := &model.Member{SlackID: "U1"}
+	assert.Nil(t, s.GetMemberBySlackID(member))
+	assert.Equal(t, []string{"go", "react", "ui design"}, member.Skills)
+	assert.Equal(t, []string{"react"}, member.Interests)
+	var members model.Members
+	assert.Nil(t, s.GetMembers(&members))
+	if assert.Len(t, members, 3) {
+		assert.Equal(t, []string{"react"}, members[0].Skills)
+		assert.Equal(t, []string{}, members[0].Interests)
+		assert.Equal(t, []string{"go", "react", "ui design"}, members[1].Skills)
+		assert.Equal(t, []string{}, members[2].Skills)
+	}
+
+	names := func(tag *model.Tag) []string {
+		assert.Nil(t, s.GetMembersByTag(tag, &members))
+		names := []string{}
+		for _, m := range members {
+			names = append(names, m.Name)
+		}
+		return names
+	}
+	assert.Equal(t, []string{"Alice", "Bruno"},
+		names(&model.Tag{Kind: model.TagKindSkill, Name: "REACT"}))
+	assert.Equal(t, []string{"Bruno"},
+		names(&model.Tag{Kind: model.TagKindInterest, Name: "react"}))
+	assert.Equal(t, []string{"Alice", "Bruno"}, names(&model.Tag{Name: "react"}))
+	assert.Equal(t, []string{}, names(&model.Tag{Kind: model.TagKindInterest, Name: "go"}))
+
+	// Setting tags of one kind replaces only tags of that kind
+	assert.Nil(t, s.SetMemberSkills(&model.Member{SlackID: "U1", Skills: []string{"Go"}}))
+	assert.Nil(t, s.GetMemberBySlackID(member))
+	assert.Equal(t, []string{"go"}, member.Skills)
+	assert.Equal(t, []string{"react"}, member.Interests)
+	assert.Equal(t, []string{"Alice"},
+		names(&model.Tag{Kind: model.TagKindSkill, Name: "react"}))
+
+	// Alumni aren't found
+	leftAt := time.Now()
+	assert.Nil(t, s.SetMemberLeftAt(&model.Member{SlackID: "U2", LeftAt: &leftAt}))
+	assert.Equal(t, []string{}, names(&model.Tag{Kind: model.TagKindSkill, Name: "react"}))
+
+	// Deleted members lose their tags, and tagging members that don't exist
+	// does nothing
+	assert.Nil(t, s.DeleteMember(&model.Member{SlackID: "U1"}))
+	assert.Equal(t, []string{}, names(&model.Tag{Name: "go"}))
+	assert.Nil(t, s.SetMemberSkills(&model.Member{SlackID: "U4", Skills: []string{"go"}}))
+	assert.Equal(t, []string{}, names(&model.Tag{Name: "go"}))
+}
+
+func testStoreMemberRoles(t *testing.T, s Store) {
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U1", Name: "Admin", IsAdmin: true}))
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U2", Name: "Lead", IsTechLead: true}))
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U3", Name: "Member"}))
+
+	var admins, leads model.Members
+	assert.Nil(t, s.GetAdmins(&admins))
+	assert.Nil(t, s.GetTechLeads(&leads))
+	if assert.Len(t, admins, 1) {
+		assert.Equal(t, "U1", admins[0].SlackID)
+	}
+	if assert.Len(t, leads, 1) {
+		assert.Equal(t, "U2", leads[0].SlackID)
+	}
+}
+
+func testStoreTeams(t *testing.T, s Store) {
+	assert.Nil(t, s.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 2, Platform: "Go"}))
+	assert.Nil(t, s.CreateTeam(&model.Team{Name: "Buddy", GithubTeamID: 1}))
+
+	var teams model.Teams
+	assert.Nil(t, s.GetTeams(&teams))
+	if assert.Len(t, teams, 2) {
+		assert.Equal(t, "Buddy", teams[0].Name)
+		assert.Equal(t, "Rocket", teams[1].Name)
+		assert.Equal(t, "Go", teams[1].Platform)
+	}
+	assert.Nil(t, s.GetTeamNames(&teams))
+	if assert.Len(t, teams, 2) {
+		assert.Equal(t, "Buddy", teams[0].Name)
+	}
+
+	team := &model.Team{Name: "Rocket"}
+	assert.Nil(t, s.GetTeamByName(team))
+	assert.Equal(t, 2, team.GithubTeamID)
+
+	// Only the fields that are set are updated
+	assert.Nil(t, s.UpdateTeam(team, &model.Team{Name: "Rocket 2"}))
+	team = &model.Team{GithubTeamID: 2}
+	assert.Nil(t, s.GetTeamByGithubID(team))
+	assert.Equal(t, "Rocket 2", team.Name)
+	assert.Equal(t, "Go", team.Platform)
+
+	assert.Nil(t, s.DeleteTeamByName(&model.Team{Name: "Rocket 2"}))
+	assert.Equal(t, ErrNotFound, s.GetTeamByGithubID(&model.Team{GithubTeamID: 2}))
+}
+
+func testStoreTeamMembers(t *testing.T, s Store) {
+	assert.Nil(t, s.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1}))
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno"}))
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U2", Name: "Alice"}))
+
+	assert.Nil(t, s.CreateTeamMember(&model.TeamMember{GithubTeamID: 1, MemberSlackID: "U1"}))
+	assert.Nil(t, s.CreateTeamMember(&model.TeamMember{GithubTeamID: 1, MemberSlackID: "U2"}))
+	// Adding a member twice does nothing
+	assert.Nil(t, s.CreateTeamMember(&model.TeamMember{GithubTeamID: 1, MemberSlackID: "U2"}))
+
+	// The team and member have to exist
+	assert.NotNil(t, s.CreateTeamMember(&model.TeamMember{GithubTeamID: 2, MemberSlackID: "U1"}))
+	assert.NotNil(t, s.CreateTeamMember(&model.TeamMember{GithubTeamID: 1, MemberSlackID: "U3"}))
+
+	team := &model.Team{Name: "Rocket"}
+	assert.Nil(t, s.GetTeamByName(team))
+	if assert.Len(t, team.Members, 2) {
+		assert.Equal(t, "Alice", team.Members[0].Name)
+		assert.Equal(t, "Bruno", team.Members[1].Name)
+	}
+
+	assert.Nil(t, s.DeleteTeamMember(&model.TeamMember{GithubTeamID: 1, MemberSlackID: "U2"}))
+	var teams model.Teams
+	assert.Nil(t, s.GetTeams(&teams))
+	if assert.Len(t, teams, 1) && assert.Len(t, teams[0].Members, 1) {
+		assert.Equal(t, "Bruno", teams[0].Members[0].Name)
+	}
+}
+
+func testStoreTeamRoles(t *testing.T, s Store) {
+	assert.Nil(t, s.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1}))
+	assert.Nil(t, s.CreateTeam(&model.Team{Name: "Buddy", GithubTeamID: 2}))
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno"}))
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U2", Name: "Alice"}))
+
+	// Members are developers unless they're given another role
+	assert.Nil(t, s.CreateTeamMember(&model.TeamMember{
+		GithubTeamID: 1, MemberSlackID: "U1", Role: model.TeamRoleLead}))
+	assert.Nil(t, s.CreateTeamMember(&model.TeamMember{GithubTeamID: 1, MemberSlackID: "U2"}))
+	assert.Nil(t, s.CreateTeamMember(&model.TeamMember{
+		GithubTeamID: 2, MemberSlackID: "U1", Role: model.TeamRoleMentor}))
+
+	team := &model.Team{Name: "Rocket"}
+	assert.Nil(t, s.GetTeamByName(team))
+	if assert.Len(t, team.Members, 2) {
+		assert.Equal(t, model.TeamRoleDeveloper, team.Members[0].TeamRole)
+		assert.Equal(t, model.TeamRoleLead, team.Members[1].TeamRole)
+	}
+
+	// Roles are per team
+	assert.Nil(t, s.SetTeamMemberRole(&model.TeamMember{
+		GithubTeamID: 1, MemberSlackID: "U2", Role: model.TeamRoleDesigner}))
+	var teams model.Teams
+	assert.Nil(t, s.GetTeams(&teams))
+	if assert.Len(t, teams, 2) && assert.Len(t, teams[0].Members, 1) &&
+		assert.Len(t, teams[1].Members, 2) {
+		assert.Equal(t, model.TeamRoleMentor, teams[0].Members[0].TeamRole)
+		assert.Equal(t, model.TeamRoleDesigner, teams[1].Members[0].TeamRole)
+		assert.Equal(t, model.TeamRoleLead, teams[1].Members[1].TeamRole)
+	}
+	team = &model.Team{GithubTeamID: 2}
+	assert.Nil(t, s.GetTeamByGithubID(team))
+	if assert.Len(t, team.Members, 1) {
+		assert.Equal(t, model.TeamRoleMentor, team.Members[0].TeamRole)
+	}
+
+	// Adding a member again doesn't change their role
+	assert.Nil(t, s.CreateTeamMember(&model.TeamMember{GithubTeamID: 1, MemberSlackID: "U1"}))
+	team = &model.Team{Name: "Rocket"}
+	assert.Nil(t, s.GetTeamByName(team))
+	if assert.Len(t, team.Members, 2) {
+		assert.Equal(t, model.TeamRoleLead, team.Members[1].TeamRole)
+	}
+
+	// Members don't have roles outside of teams
+	member := &model.Member{SlackID: "U1"}
+	assert.Nil(t, s.GetMemberBySlackID(member))
+	assert.Equal(t, model.TeamRole(""), member.TeamRole)
+
+	assert.Equal(t, ErrNotFound, s.SetTeamMemberRole(&model.TeamMember{
+		GithubTeamID: 2, MemberSlackID: "U2", Role: model.TeamRoleLead}))
+}
+
+func testStoreNotFound(t *testing.T, s Store) {
+	assert.Equal(t, ErrNotFound, s.GetMemberBySlackID(&model.Member{SlackID: "U1"}))
+	assert.Equal(t, ErrNotFound, s.UpdateMember(&model.Member{SlackID: "U1", Name: "Bruno"}))
+	assert.Equal(t, ErrNotFound, s.GetTeamByName(&model.Team{Name: "Rocket"}))
+	assert.Equal(t, ErrNotFound, s.GetTeamByGithubID(&model.Team{GithubTeamID: 1}))
+
+	var members model.Members
+	assert.Nil(t, s.GetMembers(&members))
+	assert.Len(t, members, 0)
+	var teams model.Teams
+	assert.Nil(t, s.GetTeams(&teams))
+	assert.Len(t, teams, 0)
+}
+
+func testStoreUniqueMembers(t *testing.T, s Store) {
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno", Email: "bruno@ubclaunchpad.com"}))
+
+	// Creating a member that already exists does nothing
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U1", Name: "Impostor"}))
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U2", Email: "bruno@ubclaunchpad.com"}))
+	member := &model.Member{SlackID: "U1"}
+	assert.Nil(t, s.GetMemberBySlackID(member))
+	assert.Equal(t, "Bruno", member.Name)
+	assert.Equal(t, ErrNotFound, s.GetMemberBySlackID(&model.Member{SlackID: "U2"}))
+
+	// Members can't share an email
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U3"}))
+	assert.NotNil(t, s.SetMemberEmail(&model.Member{SlackID: "U3", Email: "bruno@ubclaunchpad.com"}))
+}
+
+func testStoreUniqueTeams(t *testing.T, s Store) {
+	assert.Nil(t, s.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1}))
+	assert.Nil(t, s.CreateTeam(&model.Team{Name: "Buddy", GithubTeamID: 2}))
+
+	// Creating a team that already exists fails
+	assert.Equal(t, ErrTeamExists, s.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 3}))
+	assert.Equal(t, ErrTeamExists, s.CreateTeam(&model.Team{Name: "Impostor", GithubTeamID: 1}))
+	var teams model.Teams
+	assert.Nil(t, s.GetTeams(&teams))
+	assert.Len(t, teams, 2)
+
+	// Teams can't share a name
+	team := &model.Team{Name: "Buddy"}
+	assert.Nil(t, s.GetTeamByName(team))
+	assert.NotNil(t, s.UpdateTeam(team, &model.Team{Name: "Rocket"}))
+}
+
+func testStoreDeleteCascades(t *testing.T, s Store) {
+	assert.Nil(t, s.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1}))
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno"}))
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U2", Name: "Alice"}))
+	assert.Nil(t, s.CreateTeamMember(&model.TeamMember{GithubTeamID: 1, MemberSlackID: "U1"}))
+	assert.Nil(t, s.CreateTeamMember(&model.TeamMember{GithubTeamID: 1, MemberSlackID: "U2"}))
+
+	// Deleting a member removes them from their teams
+	assert.Nil(t, s.DeleteMember(&model.Member{SlackID: "U1"}))
+	team := &model.Team{Name: "Rocket"}
+	assert.Nil(t, s.GetTeamByName(team))
+	if assert.Len(t, team.Members, 1) {
+		assert.Equal(t, "U2", team.Members[0].SlackID)
+	}
+
+	// Deleting a team keeps its members
+	assert.Nil(t, s.DeleteTeamByName(team))
+	assert.Nil(t, s.GetMemberBySlackID(&model.Member{SlackID: "U2"}))
+}
+
+func testStoreUnlinkTeams(t *testing.T, s Store) {
+	assert.Nil(t, s.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1, Platform: "Go"}))
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno"}))
+	assert.Nil(t, s.CreateTeamMember(&model.TeamMember{
+		GithubTeamID: 1, MemberSlackID: "U1", Role: model.TeamRoleLead}))
+	assert.Nil(t, s.SaveProject(&model.Project{GithubTeamID: 1, Name: "Rocket"}))
+
+	// Unlinking a team negates its GitHub team ID, and keeps everything else
+	team := &model.Team{GithubTeamID: 1}
+	assert.Nil(t, s.UnlinkTeam(team))
+	assert.Equal(t, -1, team.GithubTeamID)
+	assert.Equal(t, ErrNotFound, s.GetTeamByGithubID(&model.Team{GithubTeamID: 1}))
+	team = &model.Team{Name: "Rocket"}
+	assert.Nil(t, s.GetTeamByName(team))
+	assert.Equal(t, -1, team.GithubTeamID)
+	assert.Equal(t, "Go", team.Platform)
+	if assert.Len(t, team.Members, 1) {
+		assert.Equal(t, model.TeamRoleLead, team.Members[0].TeamRole)
+	}
+	project := &model.Project{GithubTeamID: -1}
+	assert.Nil(t, s.GetProjectByTeam(project))
+	assert.Equal(t, "Rocket", project.Name)
+
+	// A new team can be made with the GitHub team ID
+	assert.Nil(t, s.CreateTeam(&model.Team{Name: "Buddy", GithubTeamID: 1}))
+	assert.Equal(t, ErrNotFound, s.UnlinkTeam(&model.Team{GithubTeamID: 2}))
+}
+
+func TestSQLiteStore(t *testing.T) {
+	runStoreTests(t, func() (Store, func(), error) {
+		s, err := NewSQLite(":memory:")
+		if err != nil {
+			return nil, nil, err
+		}
+		return s, func() { s.Close() }, nil
+	})
+}
+
+func testStoreAuditLog(t *testing.T, s Store) {
+	start := time.Date(2018, time.September, 1, 12, 0, 0, 0, time.UTC)
+	for i, e := range []*model.AuditEntry{
+		{ActorSlackID: "U1", Action: "member.update", Target: "U2"},
+		{ActorSlackID: "U1", Action: "team.create", Target: "Rocket", After: `{"name":"Rocket"}`},
+		{ActorSlackID: "U2", Action: "member.update", Target: "U2", Command: "set name={Bruno}"},
+	} {
+		e.CreatedAt = start.Add(time.Duration(i) * time.Hour)
+		assert.Nil(t, s.CreateAuditEntry(e))
+		assert.NotZero(t, e.ID)
+	}
+
+	// Entries are newest first
+	var entries model.AuditEntries
+	assert.Nil(t, s.GetAuditEntries(AuditFilter{}, &entries))
+	if assert.Len(t, entries, 3) {
+		assert.Equal(t, "U2", entries[0].ActorSlackID)
+		assert.Equal(t, "set name={Bruno}", entries[0].Command)
+		assert.True(t, entries[0].CreatedAt.Equal(start.Add(2*time.Hour)))
+		assert.Equal(t, `{"name":"Rocket"}`, entries[1].After)
+		assert.Equal(t, "", entries[1].Before)
+	}
+
+	for _, test := range []struct {
+		filter   AuditFilter
+		expected []string
+	}{
+		{AuditFilter{ActorSlackID: "U1"}, []string{"team.create", "member.update"}},
+		{AuditFilter{Action: "member.update", Target: "U2"}, []string{"member.update", "member.update"}},
+		{AuditFilter{Target: "Rocket"}, []string{"team.create"}},
+		{AuditFilter{Since: start.Add(time.Hour)}, []string{"member.update", "team.create"}},
+		{AuditFilter{Until: start.Add(time.Hour)}, []string{"member.update"}},
+		{AuditFilter{Limit: 1}, []string{"member.update"}},
+		{AuditFilter{ActorSlackID: "U3"}, []string{}},
+	} {
+		assert.Nil(t, s.GetAuditEntries(test.filter, &entries))
+		actions := []string{}
+		for _, e := range entries {
+			actions = append(actions, e.Action)
+		}
+		assert.Equal(t, test.expected, actions, "%+v", test.filter)
+	}
+}
+
+func testStoreAlumni(t *testing.T, s Store) {
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno", IsAdmin: true, IsTechLead: true}))
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U2", Name: "Alice"}))
+	assert.Nil(t, s.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1}))
+	assert.Nil(t, s.CreateTeamMember(&model.TeamMember{GithubTeamID: 1, MemberSlackID: "U1"}))
+
+	leftAt := time.Date(2018, time.May, 1, 0, 0, 0, 0, time.UTC)
+	assert.Nil(t, s.SetMemberLeftAt(&model.Member{SlackID: "U1", LeftAt: &leftAt}))
+	member := &model.Member{SlackID: "U1"}
+	assert.Nil(t, s.GetMemberBySlackID(member))
+	if assert.True(t, member.IsAlumni()) {
+		assert.True(t, member.LeftAt.Equal(leftAt))
+	}
+
+	// Alumni are only listed with all members
+	var members model.Members
+	assert.Nil(t, s.GetMembers(&members))
+	if assert.Len(t, members, 1) {
+		assert.Equal(t, "U2", members[0].SlackID)
+		assert.False(t, members[0].IsAlumni())
+	}
+	assert.Nil(t, s.GetAllMembers(&members))
+	assert.Len(t, members, 2)
+	assert.Nil(t, s.GetAdmins(&members))
+	assert.Len(t, members, 0)
+	assert.Nil(t, s.GetTechLeads(&members))
+	assert.Len(t, members, 0)
+
+	// But they stay on their teams
+	team := &model.Team{Name: "Rocket"}
+	assert.Nil(t, s.GetTeamByName(team))
+	if assert.Len(t, team.Members, 1) {
+		assert.True(t, team.Members[0].IsAlumni())
+	}
+
+	// Alumni can be reactivated
+	assert.Nil(t, s.SetMemberLeftAt(&model.Member{SlackID: "U1"}))
+	assert.Nil(t, s.GetAdmins(&members))
+	assert.Len(t, members, 1)
+}
+
+func testStoreTerms(t *testing.T, s Store) {
+	// Stores start out with a current term
+	current := &model.Term{}
+	assert.Nil(t, s.GetCurrentTerm(current))
+	previous := current.Code
+	assert.NotEmpty(t, previous)
+
+	assert.Nil(t, s.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1}))
+	assert.Nil(t, s.CreateTeam(&model.Team{Name: "Buddy", GithubTeamID: 2}))
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno"}))
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U2", Name: "Alice"}))
+	assert.Nil(t, s.CreateTeamMember(&model.TeamMember{
+		GithubTeamID: 1, MemberSlackID: "U1", Role: model.TeamRoleLead}))
+	assert.Nil(t, s.CreateTeamMember(&model.TeamMember{GithubTeamID: 2, MemberSlackID: "U2"}))
+
+	// A new term becomes the current term, and no teams run in it yet
+	start := time.Date(2099, time.September, 1, 0, 0, 0, 0, time.UTC)
+	assert.Nil(t, s.CreateTerm(&model.Term{Code: "2099W", StartedAt: start}))
+	assert.Nil(t, s.GetCurrentTerm(current))
+	assert.Equal(t, "2099W", current.Code)
+	var teams model.Teams
+	assert.Nil(t, s.GetTeams(&teams))
+	assert.Len(t, teams, 0)
+	team := &model.Team{Name: "Rocket"}
+	assert.Nil(t, s.GetTeamByName(team))
+	assert.True(t, team.Archived)
+	assert.Len(t, team.Members, 0)
+
+	// Teams rolled forward start the term without members
+	assert.Nil(t, s.AddTeamToTerm(&model.Team{Name: "Rocket", GithubTeamID: 1}, "2099W"))
+	assert.Nil(t, s.CreateTeamMember(&model.TeamMember{GithubTeamID: 1, MemberSlackID: "U2"}))
+	assert.Nil(t, s.GetTeams(&teams))
+	if assert.Len(t, teams, 1) && assert.Len(t, teams[0].Members, 1) {
+		assert.Equal(t, "Alice", teams[0].Members[0].Name)
+		assert.Equal(t, model.TeamRoleDeveloper, teams[0].Members[0].TeamRole)
+	}
+	team = &model.Team{GithubTeamID: 1}
+	assert.Nil(t, s.GetTeamByGithubID(team))
+	assert.False(t, team.Archived)
+	assert.Nil(t, s.GetTeamNames(&teams))
+	assert.Len(t, teams, 1)
+
+	// Members can't join teams that aren't running in their term
+	assert.NotNil(t, s.CreateTeamMember(&model.TeamMember{GithubTeamID: 2, MemberSlackID: "U1"}))
+
+	// Past terms keep their teams and members
+	assert.Nil(t, s.GetTeamsByTerm(previous, &teams))
+	if assert.Len(t, teams, 2) && assert.Len(t, teams[1].Members, 1) {
+		assert.Equal(t, "Buddy", teams[0].Name)
+		assert.Equal(t, "Bruno", teams[1].Members[0].Name)
+		assert.Equal(t, model.TeamRoleLead, teams[1].Members[0].TeamRole)
+	}
+	assert.Nil(t, s.SetTeamMemberRole(&model.TeamMember{
+		GithubTeamID: 1, MemberSlackID: "U1", Term: previous, Role: model.TeamRoleMentor}))
+	assert.Equal(t, ErrNotFound, s.SetTeamMemberRole(&model.TeamMember{
+		GithubTeamID: 1, MemberSlackID: "U1", Role: model.TeamRoleMentor}))
+
+	// Archiving a team removes its members in the term only
+	assert.Nil(t, s.RemoveTeamFromTerm(&model.Team{Name: "Rocket", GithubTeamID: 1}, "2099W"))
+	assert.Nil(t, s.GetTeams(&teams))
+	assert.Len(t, teams, 0)
+	assert.Nil(t, s.GetTeamsByTerm(previous, &teams))
+	if assert.Len(t, teams, 2) && assert.Len(t, teams[1].Members, 1) {
+		assert.Equal(t, model.TeamRoleMentor, teams[1].Members[0].TeamRole)
+	}
+	assert.Nil(t, s.AddTeamToTerm(&model.Team{Name: "Rocket", GithubTeamID: 1}, "2099W"))
+	assert.Nil(t, s.GetTeams(&teams))
+	if assert.Len(t, teams, 1) {
+		assert.Len(t, teams[0].Members, 0)
+	}
+
+	// Terms are listed newest first, and creating one again changes nothing
+	assert.Nil(t, s.CreateTerm(&model.Term{Code: previous, StartedAt: start.Add(time.Hour)}))
+	var terms model.Terms
+	assert.Nil(t, s.GetTerms(&terms))
+	if assert.True(t, len(terms) >= 2) {
+		assert.Equal(t, "2099W", terms[0].Code)
+		assert.Equal(t, previous, terms[1].Code)
+		assert.True(t, terms[0].StartedAt.Equal(start))
+	}
+
+	// Deleting a team removes it from every term
+	assert.Nil(t, s.DeleteTeamByName(&model.Team{Name: "Rocket"}))
+	assert.Nil(t, s.GetTeamsByTerm(previous, &teams))
+	assert.Len(t, teams, 1)
+	assert.Nil(t, s.GetTeams(&teams))
+	assert.Len(t, teams, 0)
+}
+
+func testStoreProjects(t *testing.T, s Store) {
+	assert.Nil(t, s.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1}))
+	assert.Nil(t, s.CreateTeam(&model.Team{Name: "Buddy", GithubTeamID: 2}))
+	assert.Equal(t, ErrNotFound, s.GetProjectByTeam(&model.Project{GithubTeamID: 1}))
+
+	// Projects are planning unless they're given another status
+	project := &model.Project{
+		GithubTeamID: 1,
+		Name:         "Rocket",
+		Description:  "Launch Pad's Slack bot",
+		Repos:        []string{"https://github.com/ubclaunchpad/rocket"},
+		TechStack:    []string{"Go", "Postgres"},
+	}
+	assert.Nil(t, s.SaveProject(project))
+	assert.Nil(t, s.SaveProject(&model.Project{
+		GithubTeamID: 2, Name: "Buddy", Status: model.ProjectStatusLaunched}))
+	assert.NotNil(t, s.SaveProject(&model.Project{GithubTeamID: 3, Name: "Nope"}))
+
+	project = &model.Project{GithubTeamID: 1}
+	assert.Nil(t, s.GetProjectByTeam(project))
+	assert.Equal(t, "Rocket", project.Team)
+	assert.Equal(t, "Launch Pad's Slack bot", project.Description)
+	assert.Equal(t, []string{"https://github.com/ubclaunchpad/rocket"}, project.Repos)
+	assert.Equal(t, []string{"Go", "Postgres"}, project.TechStack)
+	assert.Equal(t, model.ProjectStatusPlanning, project.Status)
+	assert.False(t, project.CreatedAt.IsZero())
+	createdAt := project.CreatedAt
+
+	// Saving a project again replaces it, but keeps when it was created
+	project.Website = "https://ubclaunchpad.com"
+	project.TechStack = nil
+	assert.Nil(t, s.SaveProject(project))
+	project = &model.Project{GithubTeamID: 1}
+	assert.Nil(t, s.GetProjectByTeam(project))
+	assert.Equal(t, "https://ubclaunchpad.com", project.Website)
+	assert.Len(t, project.TechStack, 0)
+	assert.True(t, project.CreatedAt.Equal(createdAt))
+
+	var projects model.Projects
+	assert.Nil(t, s.GetProjects(&projects))
+	if assert.Len(t, projects, 2) {
+		assert.Equal(t, "Buddy", projects[0].Team)
+		assert.Equal(t, model.ProjectStatusLaunched, projects[0].Status)
+		assert.Equal(t, "Rocket", projects[1].Team)
+	}
+
+	// Projects are deleted with their teams
+	assert.Nil(t, s.DeleteProject(&model.Project{GithubTeamID: 2}))
+	assert.Nil(t, s.DeleteTeamByName(&model.Team{Name: "Rocket"}))
+	assert.Nil(t, s.GetProjects(&projects))
+	assert.Len(t, projects, 0)
+}
+
+func testStoreSearch(t *testing.T, s Store) {
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno Rocket",
+		Position: "Mascot", Major: "Rocket Science"}))
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U2", Name: "Alice",
+		Position: "Developer", Biography: "Builds rockets and robots"}))
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U3", Name: "Carol",
+		Position: "Designer"}))
+	leftAt := time.Now()
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U4", Name: "Rocket Alumni",
+		LeftAt: &leftAt}))
+	assert.Nil(t, s.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1, Platform: "Go"}))
+	assert.Nil(t, s.CreateTeam(&model.Team{Name: "Buddy", GithubTeamID: 2, Platform: "Rocket League"}))
+	assert.Nil(t, s.CreateTeam(&model.Team{Name: "Sleuth", GithubTeamID: 3, Platform: "Python"}))
+	assert.Nil(t, s.CreateMember(&model.Member{SlackID: "U5", Name: "Dave"}))
+	assert.Nil(t, s.CreateTeamMember(&model.TeamMember{GithubTeamID: 1, MemberSlackID: "U5"}))
+
+	names := func(query string) ([]string, []string) {
+		var results model.SearchResults
+		assert.Nil(t, s.Search(query, &results))
+		assert.Equal(t, query, results.Query)
+		members, teams := []string{}, []string{}
+		for _, m := range results.Members {
+			members = append(members, m.Name)
+		}
+		for _, team := range results.Teams {
+			teams = append(teams, team.Name)
+		}
+		return members, teams
+	}
+
+	// Names rank above other fields, and alumni aren't found
+	members, teams := names("rocket")
+	assert.Equal(t, []string{"Bruno Rocket", "Alice"}, members)
+	assert.Equal(t, []string{"Rocket", "Buddy"}, teams)
+
+	// Every word must match, and words match prefixes
+	members, _ = names("Rocket mas")
+	assert.Equal(t, []string{"Bruno Rocket"}, members)
+	members, teams = names("design")
+	assert.Equal(t, []string{"Carol"}, members)
+	assert.Equal(t, []string{}, teams)
+
+	// Team names match fuzzily
+	_, teams = names("sleth")
+	assert.Equal(t, []string{"Sleuth"}, teams)
+
+	// Found teams have their members
+	var results model.SearchResults
+	assert.Nil(t, s.Search("rocket", &results))
+	if assert.Len(t, results.Teams, 2) && assert.Len(t, results.Teams[0].Members, 1) {
+		assert.Equal(t, "Dave", results.Teams[0].Members[0].Name)
+	}
+
+	members, teams = names("?!")
+	assert.Equal(t, []string{}, members)
+	assert.Equal(t, []string{}, teams)
+}
+
+func testStoreTransactions(t *testing.T, s Store) {
+	// Changes are kept if the transaction succeeds
+	err := s.RunInTransaction(func(tx Store) error {
+		assert.Nil(t, tx.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno"}))
+		// Changes can be read in the transaction
+		member := &model.Member{SlackID: "U1"}
+		assert.Nil(t, tx.GetMemberBySlackID(member))
+		assert.Equal(t, "Bruno", member.Name)
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, s.GetMemberBySlackID(&model.Member{SlackID: "U1"}))
+
+	// And discarded if it fails
+	failed := errors.New("failed")
+	err = s.RunInTransaction(func(tx Store) error {
+		assert.Nil(t, tx.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1}))
+		assert.Nil(t, tx.SetMemberName(&model.Member{SlackID: "U1", Name: "Big Bruno"}))
+		assert.Nil(t, tx.SetMemberSkills(&model.Member{SlackID: "U1", Skills: []string{"go"}}))
+		assert.Nil(t, tx.CreateAuditEntry(&model.AuditEntry{Action: "team.create", Target: "Rocket"}))
+		return failed
+	})
+	assert.Equal(t, failed, err)
+	assert.Equal(t, ErrNotFound, s.GetTeamByName(&model.Team{Name: "Rocket"}))
+	member := &model.Member{SlackID: "U1"}
+	assert.Nil(t, s.GetMemberBySlackID(member))
+	assert.Equal(t, "Bruno", member.Name)
+	assert.Equal(t, []string{}, member.Skills)
+	var entries model.AuditEntries
+	assert.Nil(t, s.GetAuditEntries(AuditFilter{}, &entries))
+	assert.Len(t, entries, 0)
+
+	// Nested transactions that fail only discard their own changes
+	err = s.RunInTransaction(func(tx Store) error {
+		assert.Nil(t, tx.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1}))
+		assert.Equal(t, failed, tx.RunInTransaction(func(tx Store) error {
+			assert.Nil(t, tx.CreateTeam(&model.Team{Name: "Buddy", GithubTeamID: 2}))
+			return failed
+		}))
+		return nil
+	})
+	assert.Nil(t, err)
+	assert.Nil(t, s.GetTeamByName(&model.Team{Name: "Rocket"}))
+	assert.Equal(t, ErrNotFound, s.GetTeamByName(&model.Team{Name: "Buddy"}))
+}
@@ -0,0 +1,123 @@
+package data
+
+import (
+	"github.com/go-pg/pg"
+	"github.com/go-pg/pg/orm"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// memberTag records that a member has tagged themselves with a tag.
+type memberTag struct {
+	TableName struct{} `sql:"member_tags"`
+
+	MemberSlackID string `sql:",pk"`
+	TagID         int    `sql:",pk"`
+}
+
+// taggedMembersQuery selects the Slack IDs of members with the tag with the
+// given name, and the given kind unless it's empty.
+const taggedMembersQuery = `SELECT member_tags.member_slack_id FROM member_tags
+	JOIN tags ON tags.id = member_tags.tag_id
+	WHERE tags.name = ? AND (? = '' OR tags.kind = ?)`
+
+// SetMemberSkills replaces the skills of the given member in the DB or
+// returns an error.
+func (dal *DAL) SetMemberSkills(member *model.Member) error {
+	return dal.setMemberTags(member, model.TagKindSkill, member.Skills)
+}
+
+// SetMemberInterests replaces the interests of the given member in the DB or
+// returns an error.
+func (dal *DAL) SetMemberInterests(member *model.Member) error {
+	return dal.setMemberTags(member, model.TagKindInterest, member.Interests)
+}
+
+// GetMembersByTag populates the given members with the members who aren't
+// alumni and have the given tag, or returns an error.
+func (dal *DAL) GetMembersByTag(tag *model.Tag, members *model.Members) error {
+	name := model.NormalizeTag(tag.Name)
+	err := dal.db.Model(members).
+		Where("left_at IS NULL").
+		Where("slack_id IN ("+taggedMembersQuery+")", name, tag.Kind, tag.Kind).
+		Order("name ASC").
+		Select()
+	if err != nil {
+		return err
+	}
+	return dal.setTags(*members...)
+}
+
+// setMemberTags replaces the tags of the given kind on the given member's
+// profile with tags with the given names, creating tags that don't exist yet.
+func (dal *DAL) setMemberTags(member *model.Member, kind model.TagKind, names []string) error {
+	n, err := dal.db.Model((*model.Member)(nil)).
+		Where("slack_id = ?", member.SlackID).
+		Count()
+	if err != nil || n == 0 {
+		return err
+	}
+	return dal.runInTransaction(func(db orm.DB) error {
+		_, err := db.Model((*memberTag)(nil)).
+			Where("member_slack_id = ?", member.SlackID).
+			Where("tag_id IN (SELECT id FROM tags WHERE kind = ?)", kind).
+			Delete()
+		if err != nil {
+			return err
+		}
+		for _, name := range model.NormalizeTags(names) {
+			tag := &model.Tag{Kind: kind, Name: name}
+			_, err := db.Model(tag).
+				Where("kind = ?kind").
+				Where("name = ?name").
+				OnConflict("DO NOTHING").
+				SelectOrInsert()
+			if err != nil {
+				return err
+			}
+			err = db.Insert(&memberTag{MemberSlackID: member.SlackID, TagID: tag.ID})
+			if err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+// setTags sets the skills and interests of the given members.
+func (dal *DAL) setTags(members ...*model.Member) error {
+	ids := []string{}
+	for _, member := range members {
+		member.Skills = []string{}
+		member.Interests = []string{}
+		ids = append(ids, member.SlackID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	var tags []struct {
+		MemberSlackID string
+		Kind          model.TagKind
+		Name          string
+	}
+	_, err := dal.db.Query(&tags, `SELECT member_tags.member_slack_id, tags.kind, tags.name
+		FROM member_tags JOIN tags ON tags.id = member_tags.tag_id
+		WHERE member_tags.member_slack_id IN (?)
+		ORDER BY tags.name`, pg.In(ids))
+	if err != nil {
+		return err
+	}
+	bySlackID := map[string]*model.Member{}
+	for _, member := range members {
+		bySlackID[member.SlackID] = member
+	}
+	for _, tag := range tags {
+		member := bySlackID[tag.MemberSlackID]
+		switch tag.Kind {
+		case model.TagKindSkill:
+			member.Skills = append(member.Skills, tag.Name)
+		case model.TagKindInterest:
+			member.Interests = append(member.Interests, tag.Name)
+		}
+	}
+	return nil
+}
@@ -1,50 +1,84 @@
 package data
 
 import (
+	"github.com/go-pg/pg"
 	"github.com/go-pg/pg/orm"
 	"github.com/ubclaunchpad/rocket/model"
 )
 
 // GetTeamByName provides team with corresponding name
 func (dal *DAL) GetTeamByName(team *model.Team) error {
-	return dal.db.Model(team).
+	err := dal.db.Model(team).
 		Where("name = ?name").
-		Column("Members").
 		Select()
+	if err != nil {
+		return notFound(err)
+	}
+	return dal.setCurrentMembers(team)
 }
 
 // GetTeamByGithubID provides team with corresponding GitHub ID
 func (dal *DAL) GetTeamByGithubID(team *model.Team) error {
-	return dal.db.Model(team).
+	err := dal.db.Model(team).
 		Where("github_team_id = ?github_team_id").
-		Column("Members").
 		Select()
+	if err != nil {
+		return notFound(err)
+	}
+	return dal.setCurrentMembers(team)
 }
 
 // GetTeams gets all current teams
 func (dal *DAL) GetTeams(teams *model.Teams) error {
-	return dal.db.Model(teams).
-		Column("Members").
-		Relation("Members", func(q *orm.Query) (*orm.Query, error) {
-			return q.Order("name ASC"), nil
-		}).
+	term, err := dal.currentTerm()
+	if err != nil {
+		return err
+	}
+	return dal.GetTeamsByTerm(term, teams)
+}
+
+// GetTeamsByTerm gets all teams that ran in the given term
+func (dal *DAL) GetTeamsByTerm(term string, teams *model.Teams) error {
+	err := dal.db.Model(teams).
+		Where("github_team_id IN (?)", dal.inTerm(term)).
 		Order("name ASC").
 		Select()
+	if err != nil {
+		return err
+	}
+	return dal.setTeamMembers(term, *teams...)
 }
 
 // GetTeamNames gets all names of current teams
 func (dal *DAL) GetTeamNames(teams *model.Teams) error {
+	term, err := dal.currentTerm()
+	if err != nil {
+		return err
+	}
 	return dal.db.Model(teams).
 		Column("name").
+		Where("github_team_id IN (?)", dal.inTerm(term)).
+		Order("name ASC").
 		Select()
 }
 
-// CreateTeam inserts given team into the database
+// CreateTeam inserts given team into the database and runs it in the
+// current term
 func (dal *DAL) CreateTeam(team *model.Team) error {
-	_, err := dal.db.Model(team).
+	res, err := dal.db.Model(team).
 		OnConflict("DO NOTHING").
 		Insert()
-	return err
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrTeamExists
+	}
+	term, err := dal.currentTerm()
+	if err != nil {
+		return err
+	}
+	return dal.AddTeamToTerm(team, term)
 }
 
 // UpdateTeam updates given team with new team
@@ -71,3 +105,126 @@ func (dal *DAL) DeleteTeamByName(team *model.Team) error {
 		Delete()
 	return err
 }
+
+// UnlinkTeam negates the GitHub team ID of the team with given team's GitHub
+// team ID, moving its terms, memberships and project with it
+func (dal *DAL) UnlinkTeam(team *model.Team) error {
+	return dal.RunInTransaction(func(store Store) error {
+		tx := store.(*DAL)
+		existing := &model.Team{GithubTeamID: team.GithubTeamID}
+		if err := tx.GetTeamByGithubID(existing); err != nil {
+			return err
+		}
+		for _, q := range unlinkTeamQueries(existing) {
+			if _, err := tx.db.Exec(q.query, q.params...); err != nil {
+				return err
+			}
+		}
+		team.GithubTeamID = -existing.GithubTeamID
+		return nil
+	})
+}
+
+// query is a SQL query with its parameters.
+type query struct {
+	query  string
+	params []interface{}
+}
+
+// unlinkTeamQueries returns the queries that move the given team to the
+// negation of its GitHub team ID. Its terms are copied before its members and
+// project are moved, so that they always reference an existing team and term.
+// Team names are unique, so the moved team is only named once the old one is
+// deleted.
+func unlinkTeamQueries(team *model.Team) []query {
+	from, to := team.GithubTeamID, -team.GithubTeamID
+	return []query{
+		{`INSERT INTO teams (github_team_id, platform, created_at)
+			SELECT ?, platform, created_at FROM teams WHERE github_team_id = ?`,
+			[]interface{}{to, from}},
+		{`INSERT INTO team_terms (team_github_team_id, term)
+			SELECT ?, term FROM team_terms WHERE team_github_team_id = ?`,
+			[]interface{}{to, from}},
+		{"UPDATE team_members SET team_github_team_id = ? WHERE team_github_team_id = ?",
+			[]interface{}{to, from}},
+		{"UPDATE projects SET team_github_team_id = ? WHERE team_github_team_id = ?",
+			[]interface{}{to, from}},
+		{"DELETE FROM teams WHERE github_team_id = ?", []interface{}{from}},
+		{"UPDATE teams SET name = ? WHERE github_team_id = ?", []interface{}{team.Name, to}},
+	}
+}
+
+// setCurrentMembers sets the members the given team has in the current term
+// and whether it's archived.
+func (dal *DAL) setCurrentMembers(team *model.Team) error {
+	term, err := dal.currentTerm()
+	if err != nil {
+		return err
+	}
+	n, err := dal.db.Model((*teamTerm)(nil)).
+		Where("team_github_team_id = ?", team.GithubTeamID).
+		Where("term = ?", term).
+		Count()
+	if err != nil {
+		return err
+	}
+	team.Archived = n == 0
+	return dal.setTeamMembers(term, team)
+}
+
+// setTeamMembers sets the members the given teams have in the given term,
+// ordered by name, and their roles on the teams.
+func (dal *DAL) setTeamMembers(term string, teams ...*model.Team) error {
+	ids := []int{}
+	for _, team := range teams {
+		team.Members = []*model.Member{}
+		ids = append(ids, team.GithubTeamID)
+	}
+	if len(ids) == 0 {
+		return nil
+	}
+	var teamMembers []model.TeamMember
+	err := dal.db.Model(&teamMembers).
+		Where("team_github_team_id IN (?)", pg.In(ids)).
+		Where("term = ?", term).
+		Select()
+	if err != nil || len(teamMembers) == 0 {
+		return err
+	}
+
+	slackIDs := []string{}
+	roles := map[int]map[string]model.TeamRole{}
+	for _, tm := range teamMembers {
+		if roles[tm.GithubTeamID] == nil {
+			roles[tm.GithubTeamID] = map[string]model.TeamRole{}
+		}
+		roles[tm.GithubTeamID][tm.MemberSlackID] = tm.Role
+		slackIDs = append(slackIDs, tm.MemberSlackID)
+	}
+	var members model.Members
+	err = dal.db.Model(&members).
+		Where("slack_id IN (?)", pg.In(slackIDs)).
+		Order("name ASC").
+		Select()
+	if err != nil {
+		return err
+	}
+	for _, team := range teams {
+		for _, m := range members {
+			if role, ok := roles[team.GithubTeamID][m.SlackID]; ok {
+				member := *m
+				member.TeamRole = role
+				team.Members = append(team.Members, &member)
+			}
+		}
+	}
+	return nil
+}
+
+// inTerm returns a subquery that selects the GitHub team IDs of the teams
+// that run in the given term.
+func (dal *DAL) inTerm(term string) *orm.Query {
+	return dal.db.Model((*teamTerm)(nil)).
+		Column("team_github_team_id").
+		Where("term = ?", term)
+}
@@ -4,17 +4,56 @@ import "github.com/ubclaunchpad/rocket/model"
 
 // CreateTeamMember inserts a team member into the database
 func (dal *DAL) CreateTeamMember(member *model.TeamMember) error {
+	if err := dal.setTerm(member); err != nil {
+		return err
+	}
+	if member.Role == "" {
+		member.Role = model.TeamRoleDeveloper
+	}
 	_, err := dal.db.Model(member).
 		OnConflict("DO NOTHING").
 		Insert()
 	return err
 }
 
+// SetTeamMemberRole updates the role of a team member in the database
+func (dal *DAL) SetTeamMemberRole(member *model.TeamMember) error {
+	if err := dal.setTerm(member); err != nil {
+		return err
+	}
+	res, err := dal.db.Model(member).
+		Column("role").
+		WherePK().
+		Update()
+	if err != nil {
+		return err
+	}
+	if res.RowsAffected() == 0 {
+		return ErrNotFound
+	}
+	return nil
+}
+
 // DeleteTeamMember removes team member from database
 func (dal *DAL) DeleteTeamMember(member *model.TeamMember) error {
+	if err := dal.setTerm(member); err != nil {
+		return err
+	}
 	_, err := dal.db.Model(member).
 		Where("team_github_team_id = ?team_github_team_id").
 		Where("member_slack_id = ?member_slack_id").
+		Where("term = ?term").
 		Delete()
 	return err
 }
+
+// setTerm puts the given team member in the current term if their term isn't
+// set.
+func (dal *DAL) setTerm(member *model.TeamMember) error {
+	if member.Term != "" {
+		return nil
+	}
+	term, err := dal.currentTerm()
+	member.Term = term
+	return err
+}
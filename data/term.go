@@ -0,0 +1,68 @@
+package data
+
+import (
+	"time"
+
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// teamTerm records that a team runs in a term.
+type teamTerm struct {
+	TableName struct{} `sql:"team_terms"`
+
+	GithubTeamID int    `sql:"team_github_team_id,pk"`
+	Term         string `sql:",pk"`
+}
+
+// CreateTerm inserts the given term into the database.
+func (dal *DAL) CreateTerm(term *model.Term) error {
+	if term.StartedAt.IsZero() {
+		term.StartedAt = time.Now().UTC()
+	}
+	_, err := dal.db.Model(term).
+		OnConflict("DO NOTHING").
+		Insert()
+	return err
+}
+
+// GetTerms populates the given terms with all terms, newest first.
+func (dal *DAL) GetTerms(terms *model.Terms) error {
+	return dal.db.Model(terms).
+		Order("started_at DESC", "code DESC").
+		Select()
+}
+
+// GetCurrentTerm populates the given term with the term that started most
+// recently.
+func (dal *DAL) GetCurrentTerm(term *model.Term) error {
+	return notFound(dal.db.Model(term).
+		Order("started_at DESC", "code DESC").
+		Limit(1).
+		Select())
+}
+
+// AddTeamToTerm records that the given team runs in the given term.
+func (dal *DAL) AddTeamToTerm(team *model.Team, term string) error {
+	_, err := dal.db.Model(&teamTerm{GithubTeamID: team.GithubTeamID, Term: term}).
+		OnConflict("DO NOTHING").
+		Insert()
+	return err
+}
+
+// RemoveTeamFromTerm records that the given team doesn't run in the given
+// term. Its memberships in the term are removed with it.
+func (dal *DAL) RemoveTeamFromTerm(team *model.Team, term string) error {
+	_, err := dal.db.Model(&teamTerm{GithubTeamID: team.GithubTeamID, Term: term}).
+		WherePK().
+		Delete()
+	return err
+}
+
+// currentTerm returns the code of the current term.
+func (dal *DAL) currentTerm() (string, error) {
+	term := &model.Term{}
+	if err := dal.GetCurrentTerm(term); err != nil {
+		return "", err
+	}
+	return term.Code, nil
+}
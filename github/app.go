@@ -0,0 +1,208 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/ubclaunchpad/rocket/config"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// AuthToken authenticates with the personal access token in
+	// ROCKET_GITHUBTOKEN. This is the default.
+	AuthToken = "token"
+	// AuthApp authenticates as an installation of a GitHub App on the
+	// organization, with installation tokens minted with the App's private key
+	AuthApp = "app"
+
+	// appJWTLifetime is how long the JWTs Rocket authenticates as a GitHub
+	// App with are valid for. GitHub allows at most 10 minutes.
+	appJWTLifetime = 9 * time.Minute
+	// appClockSkew is how far in the past JWTs are issued, in case GitHub's
+	// clock is behind ours
+	appClockSkew = time.Minute
+	// tokenRefreshMargin is how long before installation tokens expire they
+	// are replaced, so that requests don't fail with a token that expired
+	// while they were being sent
+	tokenRefreshMargin = 5 * time.Minute
+	// appTokenTimeout is how long minting an installation token may take
+	appTokenTimeout = 30 * time.Second
+
+	// defaultBaseURL is the URL of the GitHub API
+	defaultBaseURL = "https://api.github.com/"
+)
+
+// tokenSource returns the source of the tokens Rocket authenticates with
+// GitHub with, as configured by the given config.
+func tokenSource(c *config.Config) (oauth2.TokenSource, error) {
+	switch c.GithubAuth {
+	case "", AuthToken:
+		return oauth2.StaticTokenSource(
+			&oauth2.Token{AccessToken: c.GithubToken},
+		), nil
+	case AuthApp:
+		key, err := ioutil.ReadFile(c.GithubAppKeyFile)
+		if err != nil {
+			return nil, fmt.Errorf("failed to read GitHub App private key: %s", err)
+		}
+		ts, err := newInstallationTokenSource(c.GithubAppID, c.GithubInstallationID, key)
+		if err != nil {
+			return nil, err
+		}
+		return appTokenSource(ts)
+	}
+	return nil, fmt.Errorf("unknown GitHub authentication %q", c.GithubAuth)
+}
+
+// appTokenSource mints the first installation token with the given source,
+// so that credentials GitHub doesn't accept are found right away instead of
+// on the first request, and returns a source that reuses each token until it
+// is about to expire.
+func appTokenSource(ts *installationTokenSource) (oauth2.TokenSource, error) {
+	token, err := ts.Token()
+	if err != nil {
+		return nil, err
+	}
+	return oauth2.ReuseTokenSource(token, ts), nil
+}
+
+// installationTokenSource mints installation access tokens for an
+// installation of a GitHub App. Each token is minted with a JWT signed with
+// the App's private key. It should be wrapped in oauth2.ReuseTokenSource so
+// that tokens are only minted when the previous one is about to expire.
+type installationTokenSource struct {
+	appID          string
+	installationID string
+	key            *rsa.PrivateKey
+	baseURL        string
+	client         *http.Client
+	now            func() time.Time
+}
+
+// newInstallationTokenSource returns a source of installation tokens for the
+// installation of the App with the given ID, and the App's PEM encoded
+// private key. Returns an error if the IDs or the key are invalid.
+func newInstallationTokenSource(appID, installationID string, key []byte) (*installationTokenSource, error) {
+	if _, err := strconv.ParseInt(appID, 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid GitHub App ID %q", appID)
+	}
+	if _, err := strconv.ParseInt(installationID, 10, 64); err != nil {
+		return nil, fmt.Errorf("invalid GitHub App installation ID %q", installationID)
+	}
+	privateKey, err := parsePrivateKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("invalid GitHub App private key: %s", err)
+	}
+	return &installationTokenSource{
+		appID:          appID,
+		installationID: installationID,
+		key:            privateKey,
+		baseURL:        defaultBaseURL,
+		client:         &http.Client{Timeout: appTokenTimeout},
+		now:            time.Now,
+	}, nil
+}
+
+// parsePrivateKey parses a PEM encoded RSA private key in PKCS #1 form, as
+// GitHub generates them, or in PKCS #8 form.
+func parsePrivateKey(key []byte) (*rsa.PrivateKey, error) {
+	block, _ := pem.Decode(key)
+	if block == nil {
+		return nil, fmt.Errorf("no PEM data found")
+	}
+	if k, err := x509.ParsePKCS1PrivateKey(block.Bytes); err == nil {
+		return k, nil
+	}
+	k, err := x509.ParsePKCS8PrivateKey(block.Bytes)
+	if err != nil {
+		return nil, err
+	}
+	rsaKey, ok := k.(*rsa.PrivateKey)
+	if !ok {
+		return nil, fmt.Errorf("not an RSA private key")
+	}
+	return rsaKey, nil
+}
+
+// Token mints a new installation token.
+func (s *installationTokenSource) Token() (*oauth2.Token, error) {
+	jwt, err := s.jwt()
+	if err != nil {
+		return nil, err
+	}
+	url := s.baseURL + "app/installations/" + s.installationID + "/access_tokens"
+	req, err := http.NewRequest("POST", url, nil)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/vnd.github+json")
+	req.Header.Set("Authorization", "Bearer "+jwt)
+	ctx, cancel := context.WithTimeout(context.Background(), appTokenTimeout)
+	defer cancel()
+	res, err := s.client.Do(req.WithContext(ctx))
+	if err != nil {
+		return nil, fmt.Errorf("failed to mint GitHub App installation token: %s", err)
+	}
+	defer res.Body.Close()
+	body, err := ioutil.ReadAll(res.Body)
+	if err != nil {
+		return nil, err
+	}
+	if res.StatusCode != http.StatusCreated {
+		return nil, fmt.Errorf("failed to mint GitHub App installation token: %s: %s",
+			res.Status, bytes.TrimSpace(body))
+	}
+
+	var token struct {
+		Token     string    `json:"token"`
+		ExpiresAt time.Time `json:"expires_at"`
+	}
+	if err := json.Unmarshal(body, &token); err != nil {
+		return nil, fmt.Errorf("failed to decode GitHub App installation token: %s", err)
+	}
+	return &oauth2.Token{
+		AccessToken: token.Token,
+		TokenType:   "token",
+		Expiry:      token.ExpiresAt.Add(-tokenRefreshMargin),
+	}, nil
+}
+
+// jwt returns a JWT that authenticates as the App, signed with its private
+// key.
+func (s *installationTokenSource) jwt() (string, error) {
+	now := s.now()
+	header, err := json.Marshal(map[string]string{"alg": "RS256", "typ": "JWT"})
+	if err != nil {
+		return "", err
+	}
+	claims, err := json.Marshal(map[string]interface{}{
+		"iat": now.Add(-appClockSkew).Unix(),
+		"exp": now.Add(appJWTLifetime).Unix(),
+		"iss": s.appID,
+	})
+	if err != nil {
+		return "", err
+	}
+	unsigned := base64.RawURLEncoding.EncodeToString(header) + "." +
+		base64.RawURLEncoding.EncodeToString(claims)
+	hash := sha256.Sum256([]byte(unsigned))
+	sig, err := rsa.SignPKCS1v15(rand.Reader, s.key, crypto.SHA256, hash[:])
+	if err != nil {
+		return "", err
+	}
+	return unsigned + "." + base64.RawURLEncoding.EncodeToString(sig), nil
+}
@@ -0,0 +1,176 @@
+package github
+
+import (
+	"context"
+	"crypto"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/sha256"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/json"
+	"encoding/pem"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/ubclaunchpad/rocket/config"
+	"golang.org/x/oauth2"
+)
+
+// fakeGitHubApp serves the GitHub API endpoint that mints installation
+// tokens, checking that requests are signed with the App's private key.
+func fakeGitHubApp(t *testing.T, key *rsa.PrivateKey, expiresIn time.Duration) (*httptest.Server, *int) {
+	minted := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/app/installations/42/access_tokens":
+			parts := strings.Split(strings.TrimPrefix(r.Header.Get("Authorization"), "Bearer "), ".")
+			if !assert.Len(t, parts, 3) {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			sig, _ := base64.RawURLEncoding.DecodeString(parts[2])
+			hash := sha256.Sum256([]byte(parts[0] + "." + parts[1]))
+			if err := rsa.VerifyPKCS1v15(&key.PublicKey, crypto.SHA256, hash[:], sig); err != nil {
+				w.WriteHeader(http.StatusUnauthorized)
+				return
+			}
+			claims, _ := base64.RawURLEncoding.DecodeString(parts[1])
+			var c struct {
+				Iss string `json:"iss"`
+				Iat int64  `json:"iat"`
+				Exp int64  `json:"exp"`
+			}
+			assert.Nil(t, json.Unmarshal(claims, &c))
+			assert.Equal(t, "7", c.Iss)
+			assert.True(t, c.Exp-c.Iat <= int64(10*time.Minute/time.Second))
+
+			minted++
+			w.WriteHeader(http.StatusCreated)
+			fmt.Fprintf(w, `{"token":"ghs_%d","expires_at":%q}`,
+				minted, time.Now().Add(expiresIn).Format(time.RFC3339))
+		default:
+			// Echo the credentials other requests are made with
+			w.Write([]byte(r.Header.Get("Authorization")))
+		}
+	}))
+	return srv, &minted
+}
+
+func generateKey(t *testing.T) (*rsa.PrivateKey, []byte) {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return key, pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+}
+
+// get makes a request with the given client and returns the credentials the
+// fake GitHub API received.
+func get(t *testing.T, client *http.Client, url string) string {
+	res, err := client.Get(url)
+	if !assert.Nil(t, err) {
+		return ""
+	}
+	defer res.Body.Close()
+	body, _ := ioutil.ReadAll(res.Body)
+	return string(body)
+}
+
+func TestInstallationTokenSource(t *testing.T) {
+	key, keyPEM := generateKey(t)
+	srv, minted := fakeGitHubApp(t, key, time.Hour)
+	defer srv.Close()
+
+	ts, err := newInstallationTokenSource("7", "42", keyPEM)
+	assert.Nil(t, err)
+	ts.baseURL = srv.URL + "/"
+	client := oauth2.NewClient(context.Background(), oauth2.ReuseTokenSource(nil, ts))
+
+	// Tokens are minted when they are first needed and then reused
+	assert.Equal(t, "token ghs_1", get(t, client, srv.URL+"/orgs/ubclaunchpad"))
+	assert.Equal(t, "token ghs_1", get(t, client, srv.URL+"/orgs/ubclaunchpad"))
+	assert.Equal(t, 1, *minted)
+
+	// Tokens are replaced before they expire
+	srv2, minted2 := fakeGitHubApp(t, key, tokenRefreshMargin)
+	defer srv2.Close()
+	ts.baseURL = srv2.URL + "/"
+	client = oauth2.NewClient(context.Background(), oauth2.ReuseTokenSource(nil, ts))
+	assert.Equal(t, "token ghs_1", get(t, client, srv2.URL+"/orgs/ubclaunchpad"))
+	assert.Equal(t, "token ghs_2", get(t, client, srv2.URL+"/orgs/ubclaunchpad"))
+	assert.Equal(t, 2, *minted2)
+
+	// Tokens can't be minted with the wrong key
+	other, _ := generateKey(t)
+	ts.key = other
+	_, err = ts.Token()
+	assert.NotNil(t, err)
+}
+
+func TestAppTokenSource(t *testing.T) {
+	key, keyPEM := generateKey(t)
+	srv, minted := fakeGitHubApp(t, key, time.Hour)
+	defer srv.Close()
+
+	// The first token is minted right away and then reused
+	ts, err := newInstallationTokenSource("7", "42", keyPEM)
+	assert.Nil(t, err)
+	ts.baseURL = srv.URL + "/"
+	source, err := appTokenSource(ts)
+	assert.Nil(t, err)
+	assert.Equal(t, 1, *minted)
+	client := oauth2.NewClient(context.Background(), source)
+	assert.Equal(t, "token ghs_1", get(t, client, srv.URL+"/orgs/ubclaunchpad"))
+	assert.Equal(t, 1, *minted)
+
+	// Credentials GitHub doesn't accept are found right away
+	_, otherPEM := generateKey(t)
+	ts, err = newInstallationTokenSource("7", "42", otherPEM)
+	assert.Nil(t, err)
+	ts.baseURL = srv.URL + "/"
+	_, err = appTokenSource(ts)
+	assert.NotNil(t, err)
+}
+
+func TestNewWithApp(t *testing.T) {
+	_, keyPEM := generateKey(t)
+	f, err := ioutil.TempFile("", "rocket-app-key")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.Remove(f.Name())
+	f.Write(keyPEM)
+	f.Close()
+
+	cfg := &config.Config{
+		GithubAuth:           AuthApp,
+		GithubAppID:          "rocket",
+		GithubInstallationID: "42",
+		GithubAppKeyFile:     f.Name(),
+	}
+	_, err = New("ubclaunchpad", cfg)
+	assert.NotNil(t, err)
+
+	cfg.GithubAppID = "7"
+	cfg.GithubAppKeyFile = f.Name() + ".missing"
+	_, err = New("ubclaunchpad", cfg)
+	assert.NotNil(t, err)
+
+	_, err = New("ubclaunchpad", &config.Config{GithubAuth: "password"})
+	assert.NotNil(t, err)
+
+	api, err := New("ubclaunchpad", &config.Config{GithubToken: "ghp_token"})
+	assert.Nil(t, err)
+	assert.NotNil(t, api)
+}
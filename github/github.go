@@ -4,6 +4,7 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+	"regexp"
 	"time"
 
 	"github.com/ubclaunchpad/rocket/config"
@@ -27,13 +28,24 @@ type cache struct {
 }
 
 // New creates and returns a GitHub API object based on a configuration object,
-// configured for use with the given organization
-func New(organization string, c *config.Config) *API {
-	ctx := context.Background()
-	ts := oauth2.StaticTokenSource(
-		&oauth2.Token{AccessToken: c.GithubToken},
-	)
-	tc := oauth2.NewClient(ctx, ts)
+// configured for use with the given organization. It authenticates with a
+// personal access token, or as a GitHub App installation if the config says
+// so. Requests respect GitHub's rate limits, and are made conditionally when
+// possible to save quota. Returns an error if the App's credentials are
+// invalid or GitHub doesn't accept them.
+func New(organization string, c *config.Config) (*API, error) {
+	ts, err := tokenSource(c)
+	if err != nil {
+		return nil, err
+	}
+	// Requests are authorized before they reach the transport, so that it
+	// caches the responses to each token separately
+	tc := &http.Client{
+		Transport: &oauth2.Transport{
+			Base:   newTransport(nil),
+			Source: oauth2.ReuseTokenSource(nil, ts),
+		},
+	}
 
 	client := gh.NewClient(tc)
 
@@ -42,7 +54,7 @@ func New(organization string, c *config.Config) *API {
 		tc,
 		client,
 		cache{validDuration: time.Duration(6 * time.Hour)},
-	}
+	}, nil
 }
 
 // OrgStats represents basic stats about the configured organization's
@@ -59,15 +71,14 @@ type OrgStats struct {
 
 // GetOrgStats collects basic stats about the configured organization's
 // repositories and activity
-func (api *API) GetOrgStats() (OrgStats, error) {
+func (api *API) GetOrgStats(ctx context.Context) (OrgStats, error) {
 	// Return cache while valid
 	if api.cache.statsExpiry.After(time.Now()) && api.cache.statsData != nil {
 		return *api.cache.statsData, nil
 	}
 
 	// Generate new stats
-	ctx := context.Background()
-	repos, _, err := api.Repositories.ListByOrg(ctx, api.organization, nil)
+	repos, err := api.listRepos(ctx)
 	if err != nil {
 		return OrgStats{}, err
 	}
@@ -104,8 +115,8 @@ func (api *API) GetOrgStats() (OrgStats, error) {
 }
 
 // UserExists checks if a given user exists in Github
-func (api *API) UserExists(username string) (bool, error) {
-	_, _, err := api.Users.Get(context.Background(), username)
+func (api *API) UserExists(ctx context.Context, username string) (bool, error) {
+	_, _, err := api.Users.Get(ctx, username)
 	if err != nil {
 		return false, err
 	}
@@ -113,40 +124,99 @@ func (api *API) UserExists(username string) (bool, error) {
 }
 
 // AddUserToTeam adds given user to given team
-func (api *API) AddUserToTeam(username string, teamID int) error {
+func (api *API) AddUserToTeam(ctx context.Context, username string, teamID int) error {
 	_, _, err := api.Organizations.AddTeamMembership(
-		context.Background(), teamID, username, nil,
+		ctx, teamID, username, nil,
 	)
 	return err
 }
 
+// IsTeamMember checks if given user is a member of given team, or has been
+// invited to it
+func (api *API) IsTeamMember(ctx context.Context, username string, teamID int) (bool, error) {
+	_, resp, err := api.Organizations.GetTeamMembership(ctx, teamID, username)
+	if resp != nil && resp.StatusCode == http.StatusNotFound {
+		return false, nil
+	}
+	if err != nil {
+		return false, err
+	}
+	return true, nil
+}
+
 // RemoveUserFromOrg removes given user from configured organization
-func (api *API) RemoveUserFromOrg(username string) error {
+func (api *API) RemoveUserFromOrg(ctx context.Context, username string) error {
 	_, err := api.Organizations.RemoveOrgMembership(
-		context.Background(), username, api.organization,
+		ctx, username, api.organization,
 	)
 	return err
 }
 
 // RemoveUserFromTeam removes given user from configured organization
-func (api *API) RemoveUserFromTeam(username string, teamID int) error {
+func (api *API) RemoveUserFromTeam(ctx context.Context, username string, teamID int) error {
 	_, err := api.Organizations.RemoveTeamMembership(
-		context.Background(), teamID, username,
+		ctx, teamID, username,
 	)
 	return err
 }
 
-// CreateTeam creates a team in the configured organization
-func (api *API) CreateTeam(name string) (*gh.Team, error) {
-	teams, _, err := api.Organizations.ListTeams(context.Background(), api.organization, nil)
+// ListTeamMembers returns the usernames of the members of the team with the
+// given ID
+func (api *API) ListTeamMembers(ctx context.Context, teamID int) ([]string, error) {
+	usernames := []string{}
+	opt := &gh.OrganizationListTeamMembersOptions{
+		ListOptions: gh.ListOptions{PerPage: 100},
+	}
+	for {
+		users, resp, err := api.Organizations.ListTeamMembers(ctx, teamID, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			usernames = append(usernames, user.GetLogin())
+		}
+		if resp.NextPage == 0 {
+			return usernames, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
+// ListOrgMembers returns the usernames of the members of the configured
+// organization
+func (api *API) ListOrgMembers(ctx context.Context) ([]string, error) {
+	usernames := []string{}
+	opt := &gh.ListMembersOptions{
+		ListOptions: gh.ListOptions{PerPage: 100},
+	}
+	for {
+		users, resp, err := api.Organizations.ListMembers(ctx, api.organization, opt)
+		if err != nil {
+			return nil, err
+		}
+		for _, user := range users {
+			usernames = append(usernames, user.GetLogin())
+		}
+		if resp.NextPage == 0 {
+			return usernames, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
+// CreateTeam creates a team in the configured organization, or returns the
+// team with the given name if there already is one. Also returns whether the
+// team was created.
+func (api *API) CreateTeam(ctx context.Context, name string) (*gh.Team, bool, error) {
+	teams, err := api.listTeams(ctx)
 	if err != nil {
-		return nil, err
+		return nil, false, err
 	}
 
 	// Check if the team already exists
 	for _, team := range teams {
 		if *team.Name == name {
-			return team, nil
+			return team, false, nil
 		}
 	}
 
@@ -155,13 +225,16 @@ func (api *API) CreateTeam(name string) (*gh.Team, error) {
 		Name:    name,
 		Privacy: gh.String("closed"),
 	}
-	t, _, err := api.Organizations.CreateTeam(context.Background(), api.organization, team)
-	return t, err
+	t, _, err := api.Organizations.CreateTeam(ctx, api.organization, team)
+	if err != nil {
+		return nil, false, err
+	}
+	return t, true, nil
 }
 
 // GetTeam retrieves team with given team ID from configured organization
-func (api *API) GetTeam(id int) (*gh.Team, error) {
-	teams, _, err := api.Organizations.ListTeams(context.Background(), api.organization, nil)
+func (api *API) GetTeam(ctx context.Context, id int) (*gh.Team, error) {
+	teams, err := api.listTeams(ctx)
 	if err != nil {
 		return nil, err
 	}
@@ -173,8 +246,71 @@ func (api *API) GetTeam(id int) (*gh.Team, error) {
 	return nil, fmt.Errorf("GitHub team with ID %d not found", id)
 }
 
+// listTeams returns every team in the configured organization
+func (api *API) listTeams(ctx context.Context) ([]*gh.Team, error) {
+	teams := []*gh.Team{}
+	opt := &gh.ListOptions{PerPage: 100}
+	for {
+		page, resp, err := api.Organizations.ListTeams(ctx, api.organization, opt)
+		if err != nil {
+			return nil, err
+		}
+		teams = append(teams, page...)
+		if resp.NextPage == 0 {
+			return teams, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
+// listRepos returns every repository in the configured organization
+func (api *API) listRepos(ctx context.Context) ([]*gh.Repository, error) {
+	repos := []*gh.Repository{}
+	opt := &gh.RepositoryListByOrgOptions{
+		ListOptions: gh.ListOptions{PerPage: 100},
+	}
+	for {
+		page, resp, err := api.Repositories.ListByOrg(ctx, api.organization, opt)
+		if err != nil {
+			return nil, err
+		}
+		repos = append(repos, page...)
+		if resp.NextPage == 0 {
+			return repos, nil
+		}
+		opt.Page = resp.NextPage
+	}
+}
+
 // RemoveTeam removes team with given ID from organization
-func (api *API) RemoveTeam(id int) error {
-	_, err := api.Organizations.DeleteTeam(context.Background(), id)
+func (api *API) RemoveTeam(ctx context.Context, id int) error {
+	_, err := api.Organizations.DeleteTeam(ctx, id)
 	return err
 }
+
+// repoRegex matches the URLs of GitHub repositories, e.g.
+// https://github.com/ubclaunchpad/rocket, and their owner/name shorthands,
+// e.g. ubclaunchpad/rocket
+var repoRegex = regexp.MustCompile(
+	`^(?:(?:https?://)?(?:www\.)?github\.com/)?([A-Za-z0-9-]+)/([A-Za-z0-9._-]+?)(?:\.git)?/?$`)
+
+// ParseRepo returns the owner and name of the repository with the given URL
+// or owner/name shorthand
+func ParseRepo(repo string) (owner, name string, err error) {
+	match := repoRegex.FindStringSubmatch(repo)
+	if match == nil {
+		return "", "", fmt.Errorf("%s is not a GitHub repository", repo)
+	}
+	return match[1], match[2], nil
+}
+
+// GetRepo retrieves the repository with the given URL or owner/name
+// shorthand, returning an error if it doesn't exist
+func (api *API) GetRepo(ctx context.Context, repo string) (*gh.Repository, error) {
+	owner, name, err := ParseRepo(repo)
+	if err != nil {
+		return nil, err
+	}
+	r, _, err := api.Repositories.Get(ctx, owner, name)
+	return r, err
+}
@@ -1,7 +1,10 @@
 package github
 
 import (
+	"context"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"reflect"
 	"testing"
 	"time"
@@ -45,7 +48,7 @@ func TestAPI_GetOrgStats(t *testing.T) {
 				Client:       tt.fields.Client,
 				cache:        tt.fields.cache,
 			}
-			got, err := api.GetOrgStats()
+			got, err := api.GetOrgStats(context.Background())
 			if (err != nil) != tt.wantErr {
 				t.Errorf("API.GetOrgStats() error = %v, wantErr %v", err, tt.wantErr)
 				return
@@ -56,3 +59,83 @@ func TestAPI_GetOrgStats(t *testing.T) {
 		})
 	}
 }
+
+func TestParseRepo(t *testing.T) {
+	tests := []struct {
+		repo      string
+		wantOwner string
+		wantName  string
+		wantErr   bool
+	}{
+		{"ubclaunchpad/rocket", "ubclaunchpad", "rocket", false},
+		{"https://github.com/ubclaunchpad/rocket", "ubclaunchpad", "rocket", false},
+		{"github.com/ubclaunchpad/rocket.git", "ubclaunchpad", "rocket", false},
+		{"https://www.github.com/ubclaunchpad/inertia/", "ubclaunchpad", "inertia", false},
+		{"https://gitlab.com/ubclaunchpad/rocket", "", "", true},
+		{"rocket", "", "", true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.repo, func(t *testing.T) {
+			owner, name, err := ParseRepo(tt.repo)
+			if (err != nil) != tt.wantErr {
+				t.Errorf("ParseRepo() error = %v, wantErr %v", err, tt.wantErr)
+				return
+			}
+			if owner != tt.wantOwner || name != tt.wantName {
+				t.Errorf("ParseRepo() = %s, %s, want %s, %s", owner, name, tt.wantOwner, tt.wantName)
+			}
+		})
+	}
+}
+
+func TestAPI_GetRepo(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.URL.Path != "/repos/ubclaunchpad/rocket" {
+			http.NotFound(w, r)
+			return
+		}
+		w.Write([]byte(`{"name": "rocket", "html_url": "https://github.com/ubclaunchpad/rocket"}`))
+	}))
+	defer server.Close()
+	client := gh.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	api := &API{organization: "ubclaunchpad", Client: client}
+
+	repo, err := api.GetRepo(context.Background(), "https://github.com/ubclaunchpad/rocket")
+	if err != nil {
+		t.Fatalf("API.GetRepo() error = %v", err)
+	}
+	if repo.GetHTMLURL() != "https://github.com/ubclaunchpad/rocket" {
+		t.Errorf("API.GetRepo() = %v", repo)
+	}
+	if _, err := api.GetRepo(context.Background(), "ubclaunchpad/nope"); err == nil {
+		t.Error("API.GetRepo() of a repository that doesn't exist should fail")
+	}
+}
+
+func TestAPI_IsTeamMember(t *testing.T) {
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		switch r.URL.Path {
+		case "/teams/1/memberships/bfbachmann":
+			w.Write([]byte(`{"state": "active"}`))
+		case "/teams/1/memberships/broken":
+			w.WriteHeader(http.StatusInternalServerError)
+		default:
+			http.NotFound(w, r)
+		}
+	}))
+	defer server.Close()
+	client := gh.NewClient(nil)
+	client.BaseURL, _ = url.Parse(server.URL + "/")
+	api := &API{organization: "ubclaunchpad", Client: client}
+
+	if onTeam, err := api.IsTeamMember(context.Background(), "bfbachmann", 1); err != nil || !onTeam {
+		t.Errorf("API.IsTeamMember() of a member = %v, %v, want true", onTeam, err)
+	}
+	if onTeam, err := api.IsTeamMember(context.Background(), "nope", 1); err != nil || onTeam {
+		t.Errorf("API.IsTeamMember() of a non-member = %v, %v, want false", onTeam, err)
+	}
+	if _, err := api.IsTeamMember(context.Background(), "broken", 1); err == nil {
+		t.Error("API.IsTeamMember() should fail if GitHub does")
+	}
+}
@@ -0,0 +1,306 @@
+package github
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"strconv"
+	"sync"
+	"time"
+)
+
+const (
+	// maxRetries is how many times a request that hit a rate limit is retried
+	maxRetries = 3
+	// maxRateLimitWait is the longest Rocket waits for a rate limit to reset.
+	// Requests that would have to wait longer fail instead.
+	maxRateLimitWait = 2 * time.Minute
+	// secondaryBackoff is how long Rocket waits before retrying a request
+	// that hit a secondary rate limit without saying when to retry it,
+	// doubled on every retry, as GitHub recommends
+	secondaryBackoff = time.Minute
+	// maxCachedResponses is how many responses are kept to make conditional
+	// requests with
+	maxCachedResponses = 500
+)
+
+// transport is an HTTP transport for the GitHub API that respects its rate
+// limits and saves quota with conditional requests. Requests wait for the
+// primary rate limit to reset when it has run out, and requests that hit a
+// secondary rate limit are retried with backoff. GET responses with ETags are
+// cached, and requested again with If-None-Match, so that unchanged
+// responses are served from the cache without counting against the rate
+// limit.
+type transport struct {
+	base http.RoundTripper
+	now  func() time.Time
+	// sleep waits for the given duration, or returns the context's error if
+	// it is done first
+	sleep func(context.Context, time.Duration) error
+
+	mu sync.Mutex
+	// remaining and reset are the requests left in the primary rate limit
+	// and when it resets, from the last response
+	remaining int
+	reset     time.Time
+	// responses are cached responses by cache key, and keys are their keys
+	// oldest first, so that the oldest is evicted when the cache is full
+	responses map[string]*cachedResponse
+	keys      []string
+}
+
+// cachedResponse is a response that is served again when GitHub says it
+// hasn't changed.
+type cachedResponse struct {
+	etag   string
+	status int
+	header http.Header
+	body   []byte
+}
+
+// newTransport returns a transport that makes requests with the given
+// transport.
+func newTransport(base http.RoundTripper) *transport {
+	if base == nil {
+		base = http.DefaultTransport
+	}
+	return &transport{
+		base:      base,
+		now:       time.Now,
+		sleep:     sleep,
+		remaining: -1,
+		responses: map[string]*cachedResponse{},
+	}
+}
+
+// sleep waits for the given duration, or returns the given context's error if
+// it is done first.
+func sleep(ctx context.Context, d time.Duration) error {
+	timer := time.NewTimer(d)
+	defer timer.Stop()
+	select {
+	case <-timer.C:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// RoundTrip makes the given request, waiting for and retrying on rate
+// limits, and serving unchanged responses from the cache.
+func (t *transport) RoundTrip(req *http.Request) (*http.Response, error) {
+	ctx := req.Context()
+	for attempt := 0; ; attempt++ {
+		if err := t.waitForReset(ctx); err != nil {
+			return nil, err
+		}
+
+		r, err := t.prepare(req, attempt)
+		if err != nil {
+			return nil, err
+		}
+		cached := t.cached(r)
+		if cached != nil {
+			r.Header.Set("If-None-Match", cached.etag)
+		}
+		res, err := t.base.RoundTrip(r)
+		if err != nil {
+			return nil, err
+		}
+		t.updateRateLimit(res)
+
+		if wait, ok := t.retryAfter(res, attempt); ok {
+			io.Copy(ioutil.Discard, res.Body)
+			res.Body.Close()
+			if err := t.sleep(ctx, wait); err != nil {
+				return nil, err
+			}
+			continue
+		}
+		if res.StatusCode == http.StatusNotModified && cached != nil {
+			return cached.response(req, res), nil
+		}
+		return t.store(req, res)
+	}
+}
+
+// prepare returns a copy of the given request to make the given attempt at
+// it with, with a fresh copy of its body if it is being retried.
+func (t *transport) prepare(req *http.Request, attempt int) (*http.Request, error) {
+	r := req.Clone(req.Context())
+	if attempt > 0 && req.Body != nil {
+		if req.GetBody == nil {
+			return nil, fmt.Errorf("can't retry %s %s: its body can't be read again",
+				req.Method, req.URL)
+		}
+		body, err := req.GetBody()
+		if err != nil {
+			return nil, err
+		}
+		r.Body = body
+	}
+	return r, nil
+}
+
+// waitForReset waits for the primary rate limit to reset if it has run out.
+// Returns an error if it resets too far in the future, or the given context
+// is done first.
+func (t *transport) waitForReset(ctx context.Context) error {
+	t.mu.Lock()
+	remaining, reset := t.remaining, t.reset
+	t.mu.Unlock()
+	if remaining != 0 {
+		return nil
+	}
+	wait := reset.Sub(t.now())
+	if wait <= 0 {
+		return nil
+	}
+	if wait > maxRateLimitWait {
+		return fmt.Errorf("GitHub rate limit exceeded until %s", reset.Format(time.RFC3339))
+	}
+	return t.sleep(ctx, wait)
+}
+
+// updateRateLimit records the state of the primary rate limit from the
+// X-RateLimit-* headers of the given response.
+func (t *transport) updateRateLimit(res *http.Response) {
+	remaining, err := strconv.Atoi(res.Header.Get("X-RateLimit-Remaining"))
+	if err != nil {
+		return
+	}
+	reset, err := strconv.ParseInt(res.Header.Get("X-RateLimit-Reset"), 10, 64)
+	if err != nil {
+		return
+	}
+	t.mu.Lock()
+	t.remaining = remaining
+	t.reset = time.Unix(reset, 0)
+	t.mu.Unlock()
+}
+
+// retryAfter returns how long to wait before retrying a request that got
+// the given response on the given attempt, and false if it shouldn't be
+// retried because it didn't hit a rate limit or the wait is too long.
+func (t *transport) retryAfter(res *http.Response, attempt int) (time.Duration, bool) {
+	if res.StatusCode != http.StatusForbidden && res.StatusCode != http.StatusTooManyRequests {
+		return 0, false
+	}
+	if attempt >= maxRetries {
+		return 0, false
+	}
+
+	var wait time.Duration
+	if secs, err := strconv.Atoi(res.Header.Get("Retry-After")); err == nil {
+		// Secondary rate limits usually say when to retry
+		wait = time.Duration(secs) * time.Second
+	} else if res.Header.Get("X-RateLimit-Remaining") == "0" {
+		// The primary rate limit ran out, so the retry waits until it
+		// resets, unless that is too long
+		t.mu.Lock()
+		reset := t.reset
+		t.mu.Unlock()
+		return 0, reset.Sub(t.now()) <= maxRateLimitWait
+	} else if res.StatusCode == http.StatusTooManyRequests || isSecondaryRateLimit(res) {
+		wait = secondaryBackoff << uint(attempt)
+	} else {
+		// Other 403s are permission errors, which retrying won't fix
+		return 0, false
+	}
+	if wait < 0 {
+		wait = 0
+	}
+	return wait, wait <= maxRateLimitWait
+}
+
+// isSecondaryRateLimit returns true if the given 403 response says a
+// secondary rate limit was hit. Its body is left intact.
+func isSecondaryRateLimit(res *http.Response) bool {
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+	return err == nil && bytes.Contains(bytes.ToLower(body), []byte("secondary rate limit"))
+}
+
+// cached returns the cached response to the given request, or nil if there
+// is none. Only GET requests are cached.
+func (t *transport) cached(req *http.Request) *cachedResponse {
+	if req.Method != "GET" {
+		return nil
+	}
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	return t.responses[cacheKey(req)]
+}
+
+// store caches the given response to the given request if it can be
+// requested again conditionally, and returns it with its body intact.
+func (t *transport) store(req *http.Request, res *http.Response) (*http.Response, error) {
+	etag := res.Header.Get("ETag")
+	if req.Method != "GET" || res.StatusCode != http.StatusOK || etag == "" {
+		return res, nil
+	}
+	body, err := ioutil.ReadAll(res.Body)
+	res.Body.Close()
+	if err != nil {
+		return nil, err
+	}
+	res.Body = ioutil.NopCloser(bytes.NewReader(body))
+
+	key := cacheKey(req)
+	t.mu.Lock()
+	defer t.mu.Unlock()
+	if _, ok := t.responses[key]; !ok {
+		t.keys = append(t.keys, key)
+		if len(t.keys) > maxCachedResponses {
+			delete(t.responses, t.keys[0])
+			t.keys = t.keys[1:]
+		}
+	}
+	t.responses[key] = &cachedResponse{
+		etag:   etag,
+		status: res.StatusCode,
+		header: res.Header.Clone(),
+		body:   body,
+	}
+	return res, nil
+}
+
+// cacheKey returns the key the response to the given request is cached by.
+// GitHub's responses depend on the media type asked for and the token they
+// are asked for with, so the key is made of the URL, the Accept header and a
+// hash of the Authorization header, which keeps tokens out of the cache.
+func cacheKey(req *http.Request) string {
+	auth := sha256.Sum256([]byte(req.Header.Get("Authorization")))
+	return req.URL.String() + " " + req.Header.Get("Accept") + " " +
+		hex.EncodeToString(auth[:])
+}
+
+// response returns the cached response to the given request, with the rate
+// limit headers of the given Not Modified response to it.
+func (c *cachedResponse) response(req *http.Request, notModified *http.Response) *http.Response {
+	io.Copy(ioutil.Discard, notModified.Body)
+	notModified.Body.Close()
+	header := c.header.Clone()
+	for _, h := range []string{"X-RateLimit-Limit", "X-RateLimit-Remaining", "X-RateLimit-Reset"} {
+		if v := notModified.Header.Get(h); v != "" {
+			header.Set(h, v)
+		}
+	}
+	return &http.Response{
+		Status:        fmt.Sprintf("%d %s", c.status, http.StatusText(c.status)),
+		StatusCode:    c.status,
+		Proto:         notModified.Proto,
+		ProtoMajor:    notModified.ProtoMajor,
+		ProtoMinor:    notModified.ProtoMinor,
+		Header:        header,
+		Body:          ioutil.NopCloser(bytes.NewReader(c.body)),
+		ContentLength: int64(len(c.body)),
+		Request:       req,
+	}
+}
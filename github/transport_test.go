@@ -0,0 +1,189 @@
+package github
+
+import (
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+// newTestTransport returns a transport whose waits are recorded instead of
+// slept.
+func newTestTransport(now time.Time) (*transport, *[]time.Duration) {
+	waits := []time.Duration{}
+	t := newTransport(nil)
+	t.now = func() time.Time { return now }
+	t.sleep = func(ctx context.Context, d time.Duration) error {
+		waits = append(waits, d)
+		return nil
+	}
+	return t, &waits
+}
+
+// do makes a request with the given method and body with the given
+// transport, and returns the status and body of the response.
+func do(t *testing.T, tr *transport, method, url, body string) (int, string) {
+	var req *http.Request
+	var err error
+	if body == "" {
+		req, err = http.NewRequest(method, url, nil)
+	} else {
+		req, err = http.NewRequest(method, url, strings.NewReader(body))
+	}
+	if err != nil {
+		t.Fatal(err)
+	}
+	res, err := (&http.Client{Transport: tr}).Do(req)
+	if !assert.Nil(t, err) {
+		return 0, ""
+	}
+	defer res.Body.Close()
+	b, _ := ioutil.ReadAll(res.Body)
+	return res.StatusCode, string(b)
+}
+
+func TestTransportConditionalRequests(t *testing.T) {
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		if r.Header.Get("If-None-Match") == `"v1"` {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", `"v1"`)
+		w.Write([]byte(`[{"login":"bfbachmann"}]`))
+	}))
+	defer srv.Close()
+	tr, _ := newTestTransport(time.Now())
+
+	// Unchanged responses are served from the cache
+	for i := 0; i < 2; i++ {
+		status, body := do(t, tr, "GET", srv.URL+"/orgs/ubclaunchpad/members", "")
+		assert.Equal(t, http.StatusOK, status)
+		assert.Equal(t, `[{"login":"bfbachmann"}]`, body)
+	}
+	assert.Equal(t, 2, requests)
+
+	// Only GET requests are made conditionally
+	status, _ := do(t, tr, "POST", srv.URL+"/orgs/ubclaunchpad/members", "{}")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Len(t, tr.responses, 1)
+}
+
+func TestTransportCacheKeys(t *testing.T) {
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		etag := `"` + r.Header.Get("Authorization") + r.Header.Get("Accept") + `"`
+		if r.Header.Get("If-None-Match") == etag {
+			w.WriteHeader(http.StatusNotModified)
+			return
+		}
+		w.Header().Set("ETag", etag)
+		w.Write([]byte(r.Header.Get("Authorization") + " " + r.Header.Get("Accept")))
+	}))
+	defer srv.Close()
+	tr, _ := newTestTransport(time.Now())
+	get := func(auth, accept string) string {
+		req, _ := http.NewRequest("GET", srv.URL+"/orgs/ubclaunchpad/teams", nil)
+		req.Header.Set("Authorization", auth)
+		req.Header.Set("Accept", accept)
+		res, err := (&http.Client{Transport: tr}).Do(req)
+		if !assert.Nil(t, err) {
+			return ""
+		}
+		defer res.Body.Close()
+		b, _ := ioutil.ReadAll(res.Body)
+		return string(b)
+	}
+
+	// Responses to other tokens or media types aren't served from the cache
+	for i := 0; i < 2; i++ {
+		assert.Equal(t, "token a application/json", get("token a", "application/json"))
+		assert.Equal(t, "token b application/json", get("token b", "application/json"))
+		assert.Equal(t, "token a text/plain", get("token a", "text/plain"))
+	}
+	assert.Len(t, tr.responses, 3)
+	for key := range tr.responses {
+		assert.NotContains(t, key, "token")
+	}
+}
+
+func TestTransportSecondaryRateLimit(t *testing.T) {
+	bodies := []string{}
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		b, _ := ioutil.ReadAll(r.Body)
+		bodies = append(bodies, string(b))
+		switch len(bodies) {
+		case 1:
+			w.Header().Set("Retry-After", "30")
+			w.WriteHeader(http.StatusForbidden)
+		case 2:
+			w.WriteHeader(http.StatusForbidden)
+			w.Write([]byte(`{"message":"You have exceeded a secondary rate limit."}`))
+		default:
+			w.WriteHeader(http.StatusCreated)
+		}
+	}))
+	defer srv.Close()
+	tr, waits := newTestTransport(time.Now())
+
+	// Requests are retried with their bodies after waiting
+	status, _ := do(t, tr, "PUT", srv.URL+"/teams/1/memberships/bfbachmann", `{"role":"member"}`)
+	assert.Equal(t, http.StatusCreated, status)
+	assert.Equal(t, []string{`{"role":"member"}`, `{"role":"member"}`, `{"role":"member"}`}, bodies)
+	assert.Equal(t, []time.Duration{30 * time.Second, 2 * secondaryBackoff}, *waits)
+}
+
+func TestTransportRateLimit(t *testing.T) {
+	now := time.Now()
+	reset := now.Add(time.Minute)
+	requests := 0
+	srv := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.Header().Set("X-RateLimit-Reset", strconv.FormatInt(reset.Unix(), 10))
+		if requests == 1 {
+			w.Header().Set("X-RateLimit-Remaining", "0")
+			w.WriteHeader(http.StatusForbidden)
+			return
+		}
+		w.Header().Set("X-RateLimit-Remaining", "0")
+	}))
+	defer srv.Close()
+	tr, waits := newTestTransport(now)
+
+	// Requests that ran out of quota are retried once it resets
+	status, _ := do(t, tr, "GET", srv.URL+"/orgs/ubclaunchpad/teams", "")
+	assert.Equal(t, http.StatusOK, status)
+	assert.Equal(t, 2, requests)
+	if assert.Len(t, *waits, 2) {
+		assert.Equal(t, time.Duration(0), (*waits)[0])
+		assert.Equal(t, time.Unix(reset.Unix(), 0).Sub(now), (*waits)[1])
+	}
+
+	// Requests fail without being made if the quota resets too far in the
+	// future
+	reset = now.Add(time.Hour)
+	tr.reset = reset
+	_, err := (&http.Client{Transport: tr}).Get(srv.URL + "/orgs/ubclaunchpad/teams")
+	assert.NotNil(t, err)
+	assert.Equal(t, 2, requests)
+
+	// Other 403s aren't retried
+	requests = 0
+	srv2 := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		requests++
+		w.WriteHeader(http.StatusForbidden)
+		w.Write([]byte(`{"message":"Must have admin rights to Repository."}`))
+	}))
+	defer srv2.Close()
+	tr, _ = newTestTransport(now)
+	status, body := do(t, tr, "DELETE", srv2.URL+"/teams/1", "")
+	assert.Equal(t, http.StatusForbidden, status)
+	assert.Equal(t, `{"message":"Must have admin rights to Repository."}`, body)
+	assert.Equal(t, 1, requests)
+}
@@ -1,6 +1,11 @@
 package main
 
 import (
+	"context"
+	"os"
+	"os/signal"
+	"syscall"
+
 	log "github.com/sirupsen/logrus"
 	"github.com/ubclaunchpad/rocket/bot"
 	"github.com/ubclaunchpad/rocket/config"
@@ -16,34 +21,87 @@ func main() {
 	// environment variables.
 	cfg := config.FromEnv()
 
-	// Connect the database and initialize the data access layer. We use the
-	// URL, database, and password specified in the config. This will panic
-	// if we fail to connect to the database.
-	dal := data.New(cfg)
-	defer func() {
-		if err := dal.Close(); err != nil {
-			log.WithError(err)
-		}
-	}()
+	// "rocket migrate" manages the database schema instead of running Rocket.
+	if len(os.Args) > 1 && os.Args[1] == "migrate" {
+		migrate(cfg, os.Args[2:])
+		return
+	}
+
+	// Connect the database and initialize the data access layer. We use
+	// Postgres with the URL, database, and password specified in the config,
+	// or the SQLite database file specified in the config. This will panic if
+	// we fail to connect to the database, and exit if we fail to migrate it
+	// to the schema this version of Rocket expects.
+	dal := data.Open(cfg)
 
-	// Create a client to the GitHub API, using the token from the config.
-	gh := github.New("ubclaunchpad", cfg)
+	// Create a client to the GitHub API, authenticating with the token or
+	// the GitHub App installation from the config. This will exit if the
+	// App's credentials are invalid or GitHub doesn't accept them, since an
+	// installation token is minted right away.
+	gh, err := github.New("ubclaunchpad", cfg)
+	if err != nil {
+		log.WithError(err).Fatal("Failed to create GitHub client")
+	}
 
 	// Set up a server listening on the interface specified in the
 	// config. This will panic if the server fails to bind to the interface
 	// or dies for any reason after beginning listening.
 	srv := server.New(cfg, dal, gh, log.WithField("service", "server"))
 
-	// Set up the Slack bot. This will create an RTM that receives
-	// events from Slack and respond to them as needed.
+	// Set up the Slack bot. This will receive events from Slack over the RTM
+	// or the Events API and respond to them as needed.
 	slackBot := bot.New(cfg, dal, gh, log.WithField("service", "slack"))
 
+	// When using the Events API instead of the RTM, Slack sends us events
+	// and slash commands over HTTP.
+	if cfg.SlackTransport == bot.TransportEvents {
+		if cfg.SlackSigningSecret == "" {
+			slackBot.Log.Fatal("ROCKET_SLACKSIGNINGSECRET is required to use the Events API")
+		}
+		srv.Handle("/slack/events", slackBot.EventsHandler())
+		srv.Handle("/slack/commands", slackBot.SlashCommandHandler())
+	}
+	// Slack sends clicks on buttons in interactive messages over HTTP
+	// regardless of the transport.
+	if cfg.SlackSigningSecret != "" {
+		srv.Handle("/slack/interactions", slackBot.InteractionsHandler())
+	}
+	// GitHub sends events about the organization to its webhook, which keep
+	// teams and projects in sync with changes made on GitHub.
+	if cfg.GithubWebhookSecret != "" {
+		srv.Handle("/webhooks/github", slackBot.GitHubWebhookHandler())
+	}
+
 	// Load plugins
-	if err := plugin.RegisterPlugins(slackBot); err != nil {
+	plugins, err := plugin.RegisterPlugins(slackBot, cfg)
+	if err != nil {
 		slackBot.Log.WithError(err).Fatal("Failed to load plugins")
 	}
 
-	// Start Slack bot and HTTP server
+	// Start Slack bot and HTTP server, and run until we are told to stop
+	signals := make(chan os.Signal, 1)
+	signal.Notify(signals, syscall.SIGINT, syscall.SIGTERM)
 	go srv.Start()
-	slackBot.Start()
+	go slackBot.Start()
+	sig := <-signals
+	log.Infof("Received %s, shutting down", sig)
+
+	// Stop accepting new events and wait for the commands in progress to
+	// finish, then stop plugins and the HTTP server and close the database,
+	// all within the shutdown deadline.
+	ctx, cancel := context.WithTimeout(context.Background(), cfg.ShutdownTimeout)
+	defer cancel()
+	if err := slackBot.Stop(ctx); err != nil {
+		slackBot.Log.WithError(err).Error("Failed to finish handling Slack events")
+	}
+	if err := plugin.Stop(ctx, plugins); err != nil {
+		slackBot.Log.WithError(err).Error("Failed to stop plugins")
+	}
+	if err := srv.Shutdown(ctx); err != nil {
+		log.WithError(err).Error("Failed to shut down HTTP server")
+	}
+	if err := dal.Close(); err != nil {
+		log.WithError(err).Error("Failed to close database connection")
+	}
+	log.Info("Shut down")
 }
@@ -0,0 +1,91 @@
+package main
+
+import (
+	"fmt"
+	"os"
+	"strconv"
+	"text/tabwriter"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/config"
+	"github.com/ubclaunchpad/rocket/data"
+)
+
+const migrateUsage = `Usage: rocket migrate COMMAND
+
+Commands:
+  up                Apply all migrations that have not been applied
+  down              Revert the last migration that was applied
+  status            List migrations and whether they have been applied
+  baseline VERSION  Record migrations up to VERSION as applied without
+                    running them, for databases migrated by hand`
+
+// migrate runs the "rocket migrate" command with the given arguments.
+func migrate(cfg *config.Config, args []string) {
+	if len(args) == 0 {
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+
+	dal := data.OpenWithoutMigrating(cfg)
+	defer func() {
+		if err := dal.Close(); err != nil {
+			log.WithError(err).Error("Failed to close database connection")
+		}
+	}()
+
+	switch {
+	case args[0] == "up" && len(args) == 1:
+		migrations, err := dal.MigrateUp()
+		for _, m := range migrations {
+			log.Infof("Applied migration %d_%s", m.Version, m.Name)
+		}
+		if err != nil {
+			log.WithError(err).Fatal("Failed to apply migrations")
+		}
+		if len(migrations) == 0 {
+			log.Info("The database is up to date")
+		}
+
+	case args[0] == "down" && len(args) == 1:
+		m, err := dal.MigrateDown()
+		if err != nil {
+			log.WithError(err).Fatal("Failed to revert migration")
+		}
+		if m == nil {
+			log.Info("There are no migrations to revert")
+		} else {
+			log.Infof("Reverted migration %d_%s", m.Version, m.Name)
+		}
+
+	case args[0] == "status" && len(args) == 1:
+		status, err := dal.MigrationStatus()
+		w := tabwriter.NewWriter(os.Stdout, 0, 4, 2, ' ', 0)
+		fmt.Fprintln(w, "VERSION\tNAME\tAPPLIED")
+		for _, m := range status {
+			applied := "no"
+			if m.Applied {
+				applied = m.AppliedAt.Format("2006-01-02 15:04:05 MST")
+			}
+			fmt.Fprintf(w, "%d\t%s\t%s\n", m.Version, m.Name, applied)
+		}
+		w.Flush()
+		if err != nil {
+			log.WithError(err).Fatal("Failed to get migration status")
+		}
+
+	case args[0] == "baseline" && len(args) == 2:
+		version, err := strconv.Atoi(args[1])
+		if err != nil {
+			log.Fatalf("Invalid version %q", args[1])
+		}
+		if err := dal.Baseline(version); err != nil {
+			log.WithError(err).Fatal("Failed to baseline the database")
+		}
+		log.Infof("Recorded migrations up to %d as applied", version)
+
+	default:
+		fmt.Fprintln(os.Stderr, migrateUsage)
+		os.Exit(2)
+	}
+}
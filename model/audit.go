@@ -0,0 +1,29 @@
+package model
+
+import "time"
+
+// AuditEntry records a change someone made to Rocket's data or to GitHub.
+// Entries are never changed once they have been recorded.
+type AuditEntry struct {
+	TableName struct{} `sql:"audit_log" json:"-"`
+
+	ID int `sql:",pk" json:"id"`
+	// ActorSlackID is the Slack ID of the member who made the change, or
+	// empty if Rocket made it on its own, e.g. when syncing with Slack
+	ActorSlackID string `json:"actorSlackId"`
+	// Command is the command that made the change, e.g. "team edit Rocket
+	// platform={Go}", or a description of what Rocket was doing
+	Command string `json:"command"`
+	// Action is what was changed, e.g. "member.update"
+	Action string `json:"action"`
+	// Target is the Slack ID of the member or name of the team that changed
+	Target string `json:"target"`
+	// Before and After are JSON snapshots of the target before and after
+	// the change, and are empty if it didn't exist
+	Before    string    `json:"before"`
+	After     string    `json:"after"`
+	CreatedAt time.Time `json:"createdAt"`
+}
+
+// AuditEntries is a list of audit entries
+type AuditEntries []*AuditEntry
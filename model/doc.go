@@ -1,2 +1,3 @@
-// Package model contains the structs that define members and teams.
+// Package model contains the structs that define members, teams, and the
+// audit log.
 package model
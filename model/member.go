@@ -1,6 +1,7 @@
 package model
 
 import (
+	"strings"
 	"time"
 
 	"github.com/nlopes/slack"
@@ -22,6 +23,18 @@ type Member struct {
 	IsTechLead     bool      `json:"isTechLead"`
 	IsAdmin        bool      `json:"-"`
 	CreatedAt      time.Time `json:"-"`
+	// LeftAt is when the member left Launch Pad, or nil if they are still
+	// active. Members who have left are kept as alumni.
+	LeftAt *time.Time `json:"leftAt,omitempty"`
+	// Skills and Interests are the names of the tags of each kind on the
+	// member's profile, sorted by name. They are set on members that are got
+	// on their own, rather than as part of a team.
+	Skills    []string `sql:"-" json:"skills"`
+	Interests []string `sql:"-" json:"interests"`
+
+	// TeamRole is the member's role on the team they were loaded with, and
+	// is empty for members that weren't loaded as part of a team.
+	TeamRole TeamRole `sql:"-" json:"teamRole,omitempty"`
 }
 
 // Members is a list of members
@@ -57,6 +70,14 @@ func (m *Member) SlackAttachments() []slack.Attachment {
 			Text:  "Biography: " + m.Biography,
 			Color: "good",
 		},
+		slack.Attachment{
+			Text:  "Skills: " + strings.Join(m.Skills, ", "),
+			Color: "good",
+		},
+		slack.Attachment{
+			Text:  "Interests: " + strings.Join(m.Interests, ", "),
+			Color: "good",
+		},
 	}
 
 	for _, attachment := range attachments {
@@ -72,5 +93,17 @@ func (m *Member) SlackAttachments() []slack.Attachment {
 		})
 	}
 
+	if m.IsAlumni() {
+		attachments = append(attachments, slack.Attachment{
+			Text:  "Alumni since " + m.LeftAt.Format("January 2006"),
+			Color: "warning",
+		})
+	}
+
 	return attachments
 }
+
+// IsAlumni returns true if the member has left Launch Pad.
+func (m *Member) IsAlumni() bool {
+	return m.LeftAt != nil
+}
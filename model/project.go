@@ -0,0 +1,100 @@
+package model
+
+import (
+	"strings"
+	"time"
+
+	"github.com/nlopes/slack"
+)
+
+// ProjectStatus is how far along a team's project is.
+type ProjectStatus string
+
+const (
+	// ProjectStatusPlanning is the status of projects that are still being
+	// designed. Projects are planning by default.
+	ProjectStatusPlanning ProjectStatus = "planning"
+	// ProjectStatusActive is the status of projects that are being built
+	ProjectStatusActive ProjectStatus = "active"
+	// ProjectStatusLaunched is the status of projects that have been released
+	ProjectStatusLaunched ProjectStatus = "launched"
+	// ProjectStatusInactive is the status of projects no one is working on
+	ProjectStatusInactive ProjectStatus = "inactive"
+)
+
+// ProjectStatuses are all the statuses a project can have.
+var ProjectStatuses = []ProjectStatus{
+	ProjectStatusPlanning,
+	ProjectStatusActive,
+	ProjectStatusLaunched,
+	ProjectStatusInactive,
+}
+
+// IsValid returns true if the status is one of ProjectStatuses.
+func (s ProjectStatus) IsValid() bool {
+	for _, status := range ProjectStatuses {
+		if s == status {
+			return true
+		}
+	}
+	return false
+}
+
+// Project is the concrete representation of what a team is building in the
+// database. Each team has at most one project.
+type Project struct {
+	TableName struct{} `sql:"projects" json:"-"`
+
+	GithubTeamID int `sql:"team_github_team_id,pk" json:"-"`
+	// Team is the name of the team building the project. It is set when the
+	// project is loaded.
+	Team        string `sql:"-" json:"team"`
+	Name        string `json:"name"`
+	Description string `json:"description"`
+	// Repos are the URLs of the project's GitHub repositories
+	Repos     []string      `sql:",array" json:"repos"`
+	Website   string        `json:"website"`
+	Status    ProjectStatus `sql:",notnull" json:"status"`
+	TechStack []string      `sql:",array" json:"techStack"`
+	CreatedAt time.Time     `json:"-"`
+	UpdatedAt time.Time     `json:"updatedAt"`
+}
+
+// Projects is a list of projects
+type Projects []*Project
+
+// SlackAttachments creates and returns a set of Slack attachments (strictly
+// for use in messages sent to Slack clients) that describe the project.
+func (p *Project) SlackAttachments() []slack.Attachment {
+	attachments := []slack.Attachment{
+		slack.Attachment{
+			Text:  "Name: " + p.Name,
+			Color: "good",
+		},
+		slack.Attachment{
+			Text:  "Team: " + p.Team,
+			Color: "good",
+		},
+		slack.Attachment{
+			Text:  "Status: " + string(p.Status),
+			Color: "good",
+		},
+		slack.Attachment{
+			Text:  "Description: " + p.Description,
+			Color: "good",
+		},
+		slack.Attachment{
+			Text:  "Repositories: " + strings.Join(p.Repos, ", "),
+			Color: "good",
+		},
+		slack.Attachment{
+			Text:  "Website: " + p.Website,
+			Color: "good",
+		},
+		slack.Attachment{
+			Text:  "Tech Stack: " + strings.Join(p.TechStack, ", "),
+			Color: "good",
+		},
+	}
+	return attachments
+}
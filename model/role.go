@@ -0,0 +1,34 @@
+package model
+
+// Role is a role a member holds in Launch Pad that grants them permission
+// to use certain Rocket commands.
+type Role string
+
+const (
+	// RoleAdmin is held by Launch Pad admins
+	RoleAdmin Role = "admin"
+	// RoleTechLead is held by Launch Pad tech leads
+	RoleTechLead Role = "tech lead"
+)
+
+// Roles returns the roles the member holds.
+func (m *Member) Roles() []Role {
+	roles := []Role{}
+	if m.IsAdmin {
+		roles = append(roles, RoleAdmin)
+	}
+	if m.IsTechLead {
+		roles = append(roles, RoleTechLead)
+	}
+	return roles
+}
+
+// HasRole returns true if the member holds the given role.
+func (m *Member) HasRole(role Role) bool {
+	for _, r := range m.Roles() {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
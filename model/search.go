@@ -0,0 +1,22 @@
+package model
+
+import (
+	"strings"
+	"unicode"
+)
+
+// SearchResults are the members and teams that match a search, best match
+// first.
+type SearchResults struct {
+	Query   string  `json:"query"`
+	Members Members `json:"members"`
+	Teams   Teams   `json:"teams"`
+}
+
+// SearchTerms returns the words in the given search query in lower case,
+// without punctuation.
+func SearchTerms(query string) []string {
+	return strings.FieldsFunc(strings.ToLower(query), func(r rune) bool {
+		return !unicode.IsLetter(r) && !unicode.IsDigit(r)
+	})
+}
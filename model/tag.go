@@ -0,0 +1,50 @@
+package model
+
+import (
+	"sort"
+	"strings"
+)
+
+// TagKind is what a tag on a member's profile describes.
+type TagKind string
+
+const (
+	// TagKindSkill tags are things members can do, e.g. react
+	TagKindSkill TagKind = "skill"
+	// TagKindInterest tags are things members want to work on, e.g.
+	// machine learning
+	TagKindInterest TagKind = "interest"
+)
+
+// Tag is a skill or interest members can tag themselves with. Tags are
+// normalized with NormalizeTag, so members who tag themselves with "React"
+// and "react " share a tag.
+type Tag struct {
+	TableName struct{} `sql:"tags" json:"-"`
+
+	ID   int     `sql:",pk" json:"-"`
+	Kind TagKind `json:"kind"`
+	Name string  `json:"name"`
+}
+
+// NormalizeTag returns the given tag name in lower case, without leading or
+// trailing spaces and with single spaces between words.
+func NormalizeTag(name string) string {
+	return strings.Join(strings.Fields(strings.ToLower(name)), " ")
+}
+
+// NormalizeTags returns the given tag names normalized with NormalizeTag,
+// sorted, and without duplicates or empty names.
+func NormalizeTags(names []string) []string {
+	seen := map[string]bool{}
+	tags := []string{}
+	for _, name := range names {
+		name = NormalizeTag(name)
+		if name != "" && !seen[name] {
+			seen[name] = true
+			tags = append(tags, name)
+		}
+	}
+	sort.Strings(tags)
+	return tags
+}
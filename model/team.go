@@ -5,16 +5,22 @@ import (
 	"time"
 
 	"github.com/nlopes/slack"
+	"github.com/ubclaunchpad/rocket/blocks"
 )
 
 // Team represents the concrete representation of a team in the database.
 type Team struct {
 	TableName struct{} `sql:"teams" json:"-"`
 
-	Name         string    `json:"name"`
+	Name string `json:"name"`
+	// GithubTeamID is the ID of the team's GitHub team, or its negation if
+	// the GitHub team was deleted.
 	GithubTeamID int       `sql:",pk" json:"-" pg:"github_team_id"`
 	Platform     string    `json:"platform" pg:"platform"`
 	CreatedAt    time.Time `json:"-"`
+	// Archived is true if the team isn't running in the current term. It is
+	// only set on teams that are got by name or GitHub team ID.
+	Archived bool `sql:"-" json:"-"`
 
 	Members []*Member `sql:"-" json:"members" pg:",many2many:team_members,joinFK:Member"`
 }
@@ -26,21 +32,13 @@ type Teams []*Team
 // for use in messages sent to Slack clients) that describe the team's name
 // and list of members.
 func (t *Team) SlackAttachments() []slack.Attachment {
-	members := []string{}
-	leads := []string{}
-	for _, member := range t.Members {
-		if !member.IsTechLead {
-			members = append(members, member.Name)
-		} else {
-			leads = append(leads, member.Name)
-		}
-	}
+	leads, members := t.leadsAndMembers()
 	membersString := strings.Join(members, ", ")
 	leadsString := strings.Join(leads, ", ")
 
 	attachments := []slack.Attachment{
 		slack.Attachment{
-			Text:  "Name: " + t.Name,
+			Text:  "Name: " + t.displayName(),
 			Color: "good",
 		},
 		slack.Attachment{
@@ -59,3 +57,45 @@ func (t *Team) SlackAttachments() []slack.Attachment {
 
 	return attachments
 }
+
+// SlackBlocks creates and returns Block Kit blocks (strictly for use in
+// messages sent to Slack clients) that describe the team's name, platform
+// and list of members.
+func (t *Team) SlackBlocks() []blocks.Block {
+	leads, members := t.leadsAndMembers()
+
+	return []blocks.Block{
+		blocks.Section("*" + t.displayName() + "*"),
+		blocks.Fields(
+			"*Platform*\n"+t.Platform,
+			"*Leads*\n"+strings.Join(leads, ", "),
+			"*Members*\n"+strings.Join(members, ", "),
+		),
+	}
+}
+
+// displayName returns the team's name, noting if it's archived.
+func (t *Team) displayName() string {
+	if t.Archived {
+		return t.Name + " (archived)"
+	}
+	return t.Name
+}
+
+// leadsAndMembers returns the names of the team's leads and the names of its
+// other members, followed by their role if they aren't developers.
+func (t *Team) leadsAndMembers() ([]string, []string) {
+	leads := []string{}
+	members := []string{}
+	for _, member := range t.Members {
+		switch member.TeamRole {
+		case TeamRoleLead:
+			leads = append(leads, member.Name)
+		case TeamRoleDeveloper, "":
+			members = append(members, member.Name)
+		default:
+			members = append(members, member.Name+" ("+string(member.TeamRole)+")")
+		}
+	}
+	return leads, members
+}
@@ -1,10 +1,49 @@
 package model
 
+// TeamRole is the role a member has on a team.
+type TeamRole string
+
+const (
+	// TeamRoleLead is held by the members who lead a team. Leads can manage
+	// their team with Rocket.
+	TeamRoleLead TeamRole = "lead"
+	// TeamRoleDeveloper is the role members have on a team by default
+	TeamRoleDeveloper TeamRole = "developer"
+	// TeamRoleDesigner is held by a team's designers
+	TeamRoleDesigner TeamRole = "designer"
+	// TeamRolePM is held by a team's project managers
+	TeamRolePM TeamRole = "pm"
+	// TeamRoleMentor is held by a team's mentors
+	TeamRoleMentor TeamRole = "mentor"
+)
+
+// TeamRoles are all the roles a member can have on a team.
+var TeamRoles = []TeamRole{
+	TeamRoleLead,
+	TeamRoleDeveloper,
+	TeamRoleDesigner,
+	TeamRolePM,
+	TeamRoleMentor,
+}
+
+// IsValid returns true if the role is one of TeamRoles.
+func (r TeamRole) IsValid() bool {
+	for _, role := range TeamRoles {
+		if r == role {
+			return true
+		}
+	}
+	return false
+}
+
 // TeamMember represents the concrete relationship between teams and members
-// in the database.
+// in the database. Members are on a team for a term, which is the current
+// term if it isn't set.
 type TeamMember struct {
-	GithubTeamID  int    `sql:"team_github_team_id,pk"`
-	MemberSlackID string `sql:",pk"`
+	GithubTeamID  int      `sql:"team_github_team_id,pk"`
+	MemberSlackID string   `sql:",pk"`
+	Term          string   `sql:",pk"`
+	Role          TeamRole `sql:",notnull"`
 
 	Team   *Team   `sql:"-"`
 	Member *Member `sql:"-"`
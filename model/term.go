@@ -0,0 +1,39 @@
+package model
+
+import (
+	"fmt"
+	"regexp"
+	"time"
+)
+
+// TermCodeRegex matches term codes, which are the year the term starts in
+// followed by W for UBC's winter session or S for its summer session, e.g.
+// 2026W.
+var TermCodeRegex = regexp.MustCompile(`^[0-9]{4}[WS]$`)
+
+// Term is an academic term Launch Pad runs teams in. Teams are run in yearly
+// cohorts, so the teams running in a term and their members change from term
+// to term. The term that started most recently is the current term.
+type Term struct {
+	TableName struct{} `sql:"terms" json:"-"`
+
+	Code      string    `sql:",pk" json:"code"`
+	StartedAt time.Time `json:"startedAt"`
+}
+
+// Terms is a list of terms
+type Terms []*Term
+
+// TermAt returns the code of the term the given time falls in. The winter
+// session runs from September to April and the summer session from May to
+// August.
+func TermAt(t time.Time) string {
+	switch {
+	case t.Month() >= time.September:
+		return fmt.Sprintf("%dW", t.Year())
+	case t.Month() >= time.May:
+		return fmt.Sprintf("%dS", t.Year())
+	default:
+		return fmt.Sprintf("%dW", t.Year()-1)
+	}
+}
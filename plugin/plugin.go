@@ -1,42 +1,71 @@
 package plugin
 
 import (
+	"context"
+
 	"github.com/ubclaunchpad/rocket/bot"
 	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/config"
 
 	// Place registered plugin imports here
 	"github.com/ubclaunchpad/rocket/plugins/core"
+	"github.com/ubclaunchpad/rocket/plugins/reconcile"
 	"github.com/ubclaunchpad/rocket/plugins/welcome"
 )
 
 // Plugin is any type that exposes Slack commands and event handlers, and can
-// be started.
+// be started and stopped.
 type Plugin interface {
 	// Starts the plugin or returns an error if one occurred.
 	// Use this as an opportnity to start background goroutines or do any other
 	// additional setup for your plugin.
 	Start() error
+	// Stops the plugin or returns an error if one occurred. Use this to stop
+	// any background goroutines the plugin started, and to release any
+	// resources it holds. Stop must return once the given context is done.
+	Stop(ctx context.Context) error
 	// Returns a slice of commands that the plugin handles.
 	Commands() []*cmd.Command
 	// Returns a mapping from event type to a event handler.
-	// See https://api.slack.com/rtm for event types.
+	// See https://api.slack.com/rtm for event types. Handlers of GitHub
+	// webhook events are registered under bot.GitHubEventType, e.g.
+	// bot.GitHubEventType("membership").
 	EventHandlers() map[string]bot.EventHandler
+	// Returns a mapping from action ID to the handler of clicks on buttons
+	// and choices from menus with that action ID.
+	ActionHandlers() map[string]bot.ActionHandler
 }
 
 // RegisterPlugins registers commands and event handlers from Rocket plugins
-// and starts the plugins. Returns an error if a plugin could not be registered.
-func RegisterPlugins(b *bot.Bot) error {
+// and starts the plugins, which are configured by the given config. Returns
+// the plugins so they can be stopped with Stop, or an error if a plugin could
+// not be registered.
+func RegisterPlugins(b *bot.Bot, cfg *config.Config) ([]Plugin, error) {
 	// Add your plugin to this list
 	plugins := []Plugin{
 		core.New(b),
 		welcome.New(b),
+		reconcile.New(b, cfg),
 	}
 	for _, p := range plugins {
 		if err := registerPlugin(p, b); err != nil {
-			return err
+			return nil, err
 		}
 	}
-	return nil
+	return plugins, nil
+}
+
+// Stop stops the given plugins in the reverse of the order they were
+// started in. Every plugin is stopped even if stopping another one fails.
+// Returns the first error that occurred.
+func Stop(ctx context.Context, plugins []Plugin) error {
+	var firstErr error
+	for i := len(plugins) - 1; i >= 0; i-- {
+		if err := plugins[i].Stop(ctx); err != nil && firstErr == nil {
+			firstErr = err
+		}
+	}
+	return firstErr
 }
 
 // RegisterPlugins registers commands and event handlers from the given plugin
@@ -46,6 +75,9 @@ func registerPlugin(p Plugin, b *bot.Bot) error {
 		return err
 	}
 	b.RegisterEventHandlers(p.EventHandlers())
+	if err := b.RegisterActionHandlers(p.ActionHandlers()); err != nil {
+		return err
+	}
 	p.Start()
 	return nil
 }
@@ -1,14 +1,44 @@
 package plugin
 
 import (
+	"context"
+	"errors"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
 	"github.com/ubclaunchpad/rocket/bot"
+	"github.com/ubclaunchpad/rocket/config"
 )
 
 func TestPluginRegistration(t *testing.T) {
 	b := bot.NewEmptyBot()
-	err := RegisterPlugins(b)
+	plugins, err := RegisterPlugins(b, &config.Config{})
 	assert.Nil(t, err)
+	assert.NotEmpty(t, plugins)
+	assert.Nil(t, Stop(context.Background(), plugins))
+}
+
+// stopRecorder is a plugin that records the order plugins are stopped in.
+type stopRecorder struct {
+	Plugin
+	name    string
+	err     error
+	stopped *[]string
+}
+
+func (s *stopRecorder) Stop(ctx context.Context) error {
+	*s.stopped = append(*s.stopped, s.name)
+	return s.err
+}
+
+func TestStopPlugins(t *testing.T) {
+	stopped := []string{}
+	plugins := []Plugin{
+		&stopRecorder{name: "first", stopped: &stopped},
+		&stopRecorder{name: "second", err: errors.New("failed"), stopped: &stopped},
+		&stopRecorder{name: "third", stopped: &stopped},
+	}
+	err := Stop(context.Background(), plugins)
+	assert.EqualError(t, err, "failed")
+	assert.Equal(t, []string{"third", "second", "first"}, stopped)
 }
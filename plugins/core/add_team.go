@@ -1,19 +1,23 @@
 package core
 
 import (
+	"context"
 	"strings"
 
 	"github.com/nlopes/slack"
 	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/bot"
 	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/data"
 	"github.com/ubclaunchpad/rocket/model"
 )
 
 // NewAddTeamCmd returns an add team command that creates a new Launch Pad team
 func NewAddTeamCmd(ch cmd.CommandHandler) *cmd.Command {
 	return &cmd.Command{
-		Name:     "add-team",
-		HelpText: "Create a new Launch Pad team (admins and tech leads only)",
+		Name:     "add",
+		Aliases:  []string{"add-team"},
+		HelpText: "Create a new Launch Pad team",
 		Options: map[string]*cmd.Option{
 			"name": &cmd.Option{
 				Key:      "name",
@@ -34,6 +38,7 @@ func NewAddTeamCmd(ch cmd.CommandHandler) *cmd.Command {
 				Required: false,
 			},
 		},
+		Permission: cmd.Roles(model.RoleAdmin, model.RoleTechLead),
 		HandleFunc: ch,
 	}
 }
@@ -42,10 +47,6 @@ func NewAddTeamCmd(ch cmd.CommandHandler) *cmd.Command {
 func (core *Plugin) addTeam(c cmd.Context) (string, slack.PostMessageParameters) {
 	noParams := slack.PostMessageParameters{}
 
-	if !c.User.IsAdmin && !c.User.IsTechLead {
-		return "You must be an admin or tech lead to use this command", noParams
-	}
-
 	teamName := c.Options["name"].Value
 	platform := c.Options["platform"].Value
 
@@ -55,23 +56,36 @@ func (core *Plugin) addTeam(c cmd.Context) (string, slack.PostMessageParameters)
 		ghTeamName = c.Options["github"].Value
 	}
 
-	// Create the team on GitHub
-	ghTeam, err := core.Bot.GitHub.CreateTeam(ghTeamName)
-	core.Bot.Log.Info("create team, ", ghTeam, err)
-	if err != nil {
-		log.WithError(err).Errorf("Failed to create team %s on GitHub", teamName)
-		return "Failed to create team " + teamName + " on GitHub", noParams
-	}
-
+	// Create the team on GitHub, and delete it again if adding it to the DB
+	// fails. Teams that were already on GitHub are left there.
 	team := model.Team{
-		Name:         teamName,
-		Platform:     platform,
-		GithubTeamID: int(*ghTeam.ID),
+		Name:     teamName,
+		Platform: platform,
 	}
-	// Finally, add team to DB
-	if err := core.Bot.DAL.CreateTeam(&team); err != nil {
-		log.WithError(err).Errorf("Failed to create team %s", team.Name)
-		return "Failed to create team " + team.Name, noParams
+	res := "Failed to create team " + teamName
+	err := core.Bot.Do(c, func(u *bot.UnitOfWork) error {
+		ghTeam, created, err := core.Bot.GitHub.CreateTeam(c, ghTeamName)
+		if err != nil {
+			res = "Failed to create team " + teamName + " on GitHub"
+			return err
+		}
+		team.GithubTeamID = int(*ghTeam.ID)
+		if created {
+			u.OnUndo("github.create_team", team.Name, func(ctx context.Context) error {
+				return core.Bot.GitHub.RemoveTeam(ctx, team.GithubTeamID)
+			})
+			core.recordGitHub(u.Store, "github.create_team", team.Name, nil,
+				map[string]interface{}{"name": ghTeamName, "githubTeamId": team.GithubTeamID})
+		}
+		err = u.Store.CreateTeam(&team)
+		if err == data.ErrTeamExists {
+			res = "`" + teamName + "` already exists"
+		}
+		return err
+	})
+	if err != nil {
+		log.WithError(err).Error(res)
+		return res, noParams
 	}
 
 	return "`" + team.Name + "` has been added :tada:", noParams
@@ -1,10 +1,12 @@
 package core
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/nlopes/slack"
 	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/bot"
 	"github.com/ubclaunchpad/rocket/cmd"
 	"github.com/ubclaunchpad/rocket/model"
 )
@@ -12,22 +14,32 @@ import (
 // NewAddUserCmd returns an add command that adds a user
 func NewAddUserCmd(ch cmd.CommandHandler) *cmd.Command {
 	return &cmd.Command{
-		Name:     "add-user",
-		HelpText: "Add a user to a team (admins and tech leads only)",
+		Name:     "add-member",
+		Aliases:  []string{"add-user"},
+		HelpText: "Add a user to a team",
 		Options: map[string]*cmd.Option{
 			"user": &cmd.Option{
 				Key:      "user",
 				HelpText: "the Slack handle of the user to add to a team",
-				Format:   cmd.AnyRegex,
+				Type:     cmd.UserOption,
 				Required: true,
 			},
 			"team": &cmd.Option{
 				Key:      "team",
 				HelpText: "the team to add the user to",
-				Format:   cmd.AnyRegex,
+				Type:     cmd.TeamOption,
 				Required: true,
 			},
+			"role": &cmd.Option{
+				Key:      "role",
+				HelpText: "the user's role on the team (developer by default)",
+				Type:     cmd.EnumOption,
+				Choices:  teamRoleChoices(),
+				Required: false,
+			},
 		},
+		Args:       []string{"user", "team"},
+		Permission: cmd.AnyOf(cmd.Roles(model.RoleAdmin), cmd.TeamLead("team")),
 		HandleFunc: ch,
 	}
 }
@@ -36,49 +48,58 @@ func NewAddUserCmd(ch cmd.CommandHandler) *cmd.Command {
 func (core *Plugin) addUser(c cmd.Context) (string, slack.PostMessageParameters) {
 	noParams := slack.PostMessageParameters{}
 	username := c.Options["user"].Value
-	teamName := c.Options["team"].Value
-
-	if !c.User.IsAdmin && !c.User.IsTechLead {
-		return "You must be an admin or tech lead to use this command", noParams
+	team := c.Options["team"].Team()
+	if team.Archived {
+		return "`" + team.Name + "` isn't running this term. Roll it forward " +
+			"with `term roll` first", noParams
 	}
 
-	team := model.Team{
-		Name: teamName,
-	}
-	if err := core.Bot.DAL.GetTeamByName(&team); err != nil {
-		log.WithError(err).Error("Failed to find team " + team.Name)
-		return "Failed to find team " + team.Name, noParams
-	}
-
-	slackID := cmd.ParseMention(username)
+	slackID := c.Options["user"].UserID()
 	member := model.Member{
 		SlackID: slackID,
 	}
-	if err := core.Bot.DAL.GetMemberBySlackID(&member); err != nil {
+	store := core.Bot.Store(c)
+	if err := store.GetMemberBySlackID(&member); err != nil {
 		log.WithError(err).Errorf("Failed to find member %s", username)
 		return "Failed to find member " + username, noParams
 	}
 
-	// Add user to corresponding GitHub team
-	if err := core.Bot.GitHub.AddUserToTeam(member.GithubUsername, team.GithubTeamID); err != nil {
-		log.WithError(err).Errorf("Failed to add user %s to GitHub team %s",
-			member.Name, team.Name)
-		msg := fmt.Sprintf("Failed to add user %s to GitHub team %s. "+
-			"Make sure %s's GitHub ID (currently \"%s\") is correct.",
-			member.Name, team.Name, member.Name, member.GithubUsername)
-		return msg, noParams
-	}
-
 	teamMember := model.TeamMember{
 		MemberSlackID: slackID,
 		GithubTeamID:  team.GithubTeamID,
+		Role:          model.TeamRole(c.Options["role"].Value),
 	}
-	// Finally, add relation to DB
-	if err := core.Bot.DAL.CreateTeamMember(&teamMember); err != nil {
-		log.WithError(err).Errorf("Failed to add member %s to team %s",
-			member.Name, team.Name)
-		return fmt.Sprintf("Failed to add member %s to team %s",
-			member.Name, team.Name), noParams
+	// Add the relation to the DB, then add the user to the corresponding
+	// GitHub team unless they're already on it, removing them again if the
+	// relation can't be saved
+	res := fmt.Sprintf("Failed to add member %s to team %s", member.Name, team.Name)
+	err := core.Bot.Do(c, func(u *bot.UnitOfWork) error {
+		if err := u.Store.CreateTeamMember(&teamMember); err != nil {
+			return err
+		}
+		onTeam, err := core.Bot.GitHub.IsTeamMember(c, member.GithubUsername, team.GithubTeamID)
+		if err == nil && !onTeam {
+			err = core.Bot.GitHub.AddUserToTeam(c, member.GithubUsername, team.GithubTeamID)
+		}
+		if err != nil {
+			res = fmt.Sprintf("Failed to add user %s to GitHub team %s. "+
+				"Make sure %s's GitHub ID (currently \"%s\") is correct.",
+				member.Name, team.Name, member.Name, member.GithubUsername)
+			return err
+		}
+		if onTeam {
+			return nil
+		}
+		u.OnUndo("github.add_team_member", slackID, func(ctx context.Context) error {
+			return core.Bot.GitHub.RemoveUserFromTeam(ctx, member.GithubUsername, team.GithubTeamID)
+		})
+		core.recordGitHub(u.Store, "github.add_team_member", slackID, nil,
+			githubTeamMember(member.GithubUsername, team))
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).Error(res)
+		return res, noParams
 	}
 	return cmd.ToMention(member.SlackID) +
 		" was added to `" + team.Name + "` team :tada:", noParams
@@ -0,0 +1,55 @@
+package core
+
+import (
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// NewArchiveTeamCmd returns an archive team command that stops running a
+// team in the current term (this action can only be performed by admins)
+func NewArchiveTeamCmd(ch cmd.CommandHandler) *cmd.Command {
+	return &cmd.Command{
+		Name:     "archive",
+		HelpText: "Archive a team that isn't running in the current term",
+		Options: map[string]*cmd.Option{
+			"team": &cmd.Option{
+				Key:      "team",
+				HelpText: "the name of the team to archive",
+				Type:     cmd.TeamOption,
+				Required: true,
+			},
+		},
+		Args:        []string{"team"},
+		Permission:  cmd.Roles(model.RoleAdmin),
+		Destructive: true,
+		Summary: func(c cmd.Context) string {
+			return "This will archive the team `" + c.Options["team"].Value +
+				"` and remove its members in the current term"
+		},
+		HandleFunc: ch,
+	}
+}
+
+// archiveTeam stops running a team in the current term. The team keeps its
+// members in past terms.
+func (core *Plugin) archiveTeam(c cmd.Context) (string, slack.PostMessageParameters) {
+	noParams := slack.PostMessageParameters{}
+	team := c.Options["team"].Team()
+	if team.Archived {
+		return "`" + team.Name + "` is already archived", noParams
+	}
+
+	store := core.Bot.Store(c)
+	current := &model.Term{}
+	if err := store.GetCurrentTerm(current); err != nil {
+		log.WithError(err).Error("Failed to get current term")
+		return "Failed to get current term", noParams
+	}
+	if err := store.RemoveTeamFromTerm(team, current.Code); err != nil {
+		log.WithError(err).Errorf("Failed to archive team %s", team.Name)
+		return "Failed to archive team " + team.Name, noParams
+	}
+	return "`" + team.Name + "` has been archived :tada:", noParams
+}
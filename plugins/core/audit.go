@@ -0,0 +1,127 @@
+package core
+
+import (
+	"fmt"
+	"regexp"
+
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/data"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// defaultAuditLimit is the number of audit log entries shown if no limit is
+// given
+const defaultAuditLimit = 20
+
+// slackIDRegex matches Slack user IDs, which are the targets of changes to
+// members in the audit log
+var slackIDRegex = regexp.MustCompile(`^[UW][A-Z0-9]+$`)
+
+// NewAuditCmd returns an audit command that shows the changes people have
+// made with Rocket (this action can only be performed by admins)
+func NewAuditCmd(ch cmd.CommandHandler) *cmd.Command {
+	return &cmd.Command{
+		Name:     "audit",
+		HelpText: "Show recent changes made with Rocket, newest first",
+		Options: map[string]*cmd.Option{
+			"by": &cmd.Option{
+				Key:      "by",
+				HelpText: "only show changes made by this user",
+				Type:     cmd.UserOption,
+			},
+			"member": &cmd.Option{
+				Key:      "member",
+				HelpText: "only show changes to this user",
+				Type:     cmd.UserOption,
+			},
+			"team": &cmd.Option{
+				Key:      "team",
+				HelpText: "only show changes to the team with this name",
+				Format:   cmd.AnyRegex,
+			},
+			"action": &cmd.Option{
+				Key:      "action",
+				HelpText: "only show changes of this kind, e.g. member.update",
+				Format:   cmd.AnyRegex,
+			},
+			"since": &cmd.Option{
+				Key:      "since",
+				HelpText: "only show changes made on or after this day",
+				Type:     cmd.DateOption,
+			},
+			"limit": &cmd.Option{
+				Key:      "limit",
+				HelpText: fmt.Sprintf("the most changes to show (%d by default)", defaultAuditLimit),
+				Type:     cmd.IntOption,
+			},
+		},
+		Permission: cmd.Roles(model.RoleAdmin),
+		HandleFunc: ch,
+	}
+}
+
+// audit shows the entries in the audit log that match the given options.
+func (core *Plugin) audit(c cmd.Context) (string, slack.PostMessageParameters) {
+	noParams := slack.PostMessageParameters{}
+	filter := data.AuditFilter{
+		ActorSlackID: c.Options["by"].UserID(),
+		Action:       c.Options["action"].Value,
+		Since:        c.Options["since"].Date(),
+		Limit:        defaultAuditLimit,
+	}
+	if c.Options["member"].IsSet() && c.Options["team"].IsSet() {
+		return "Only one of `member` and `team` can be given", noParams
+	}
+	filter.Target = c.Options["member"].UserID()
+	if c.Options["team"].IsSet() {
+		filter.Target = c.Options["team"].Value
+	}
+	if c.Options["limit"].IsSet() {
+		if c.Options["limit"].Int() <= 0 {
+			return "The limit must be at least 1", noParams
+		}
+		filter.Limit = c.Options["limit"].Int()
+	}
+
+	entries := model.AuditEntries{}
+	if err := core.Bot.DAL.GetAuditEntries(filter, &entries); err != nil {
+		log.WithError(err).Error("Failed to get audit log")
+		return "Failed to get audit log", noParams
+	}
+	if len(entries) == 0 {
+		return "No changes match", noParams
+	}
+
+	res := ""
+	for _, e := range entries {
+		res += auditSummary(e) + "\n"
+	}
+	return res, noParams
+}
+
+// auditSummary describes an entry in the audit log in one line.
+func auditSummary(e *model.AuditEntry) string {
+	actor := "Rocket"
+	if e.ActorSlackID != "" {
+		actor = cmd.ToMention(e.ActorSlackID)
+	}
+	target := e.Target
+	if slackIDRegex.MatchString(target) {
+		target = cmd.ToMention(target)
+	}
+
+	summary := fmt.Sprintf("%s %s `%s` %s", e.CreatedAt.UTC().Format("2006-01-02 15:04 MST"),
+		actor, e.Action, target)
+	if e.Command != "" {
+		summary += " with `" + e.Command + "`"
+	}
+	if e.Before != "" {
+		summary += " from `" + e.Before + "`"
+	}
+	if e.After != "" {
+		summary += " to `" + e.After + "`"
+	}
+	return summary
+}
@@ -1,13 +1,16 @@
 package core
 
 import (
+	"fmt"
 	"strings"
 	"testing"
+	"time"
 
 	"github.com/nlopes/slack"
 	"github.com/stretchr/testify/assert"
 	"github.com/ubclaunchpad/rocket/bot"
 	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/data"
 	"github.com/ubclaunchpad/rocket/model"
 )
 
@@ -27,22 +30,10 @@ func getTestContext(text string) cmd.Context {
 }
 
 func getTestBot() *bot.Bot {
-	b := &bot.Bot{}
+	b := bot.NewEmptyBot()
 	cp := New(b)
-	b.Commands = map[string]*cmd.Command{
-		"help":        NewHelpCmd(cp.help),
-		"set":         NewSetCmd(cp.set),
-		"viewuser":    NewViewUserCmd(cp.viewUser),
-		"viewteam":    NewViewTeamCmd(cp.viewTeam),
-		"adduser":     NewAddUserCmd(cp.addUser),
-		"addteam":     NewAddTeamCmd(cp.addTeam),
-		"setadmin":    NewToggleAdminCmd(cp.toggleAdmin),
-		"removeuser":  NewRemoveUserCmd(cp.removeUser),
-		"removeteam":  NewRemoveTeamCmd(cp.removeTeam),
-		"teams":       NewTeamsCmd(cp.listTeams),
-		"refresh":     NewRefreshCmd(cp.refresh),
-		"settechlead": NewToggleTechLeadCmd(cp.toggleTechLead),
-		"techleads":   NewTechLeadsCmd(cp.listTechLeads),
+	if err := b.RegisterCommands(cp.Commands()); err != nil {
+		panic(err)
 	}
 	return b
 }
@@ -76,3 +67,479 @@ func TestHelpWithInvalidCommand(t *testing.T) {
 	assert.Nil(t, err)
 	assert.True(t, strings.Contains(res, "is not a Rocket command"))
 }
+
+func TestHelpWithSubcommand(t *testing.T) {
+	b := getTestBot()
+	for _, text := range []string{
+		"@rocket help team add",
+		"@rocket help add-team",
+		"@rocket help command={team} subcommand={add}",
+	} {
+		res, _, err := b.Commands["help"].Execute(getTestContext(text))
+		assert.Nil(t, err)
+		assert.True(t, strings.HasPrefix(res, "Usage: `@rocket team add "), res)
+	}
+
+	res, _, err := b.Commands["help"].Execute(getTestContext("@rocket help team blabla"))
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(res, "`team blabla` is not a Rocket command"))
+}
+
+func TestTeamGroupHelp(t *testing.T) {
+	b := getTestBot()
+	res, params, err := b.Commands["team"].Execute(getTestContext("@rocket team"))
+	assert.Nil(t, err)
+	assert.True(t, strings.HasPrefix(res, "Usage: `@rocket team SUBCOMMAND`"))
+	assert.True(t, strings.Contains(params.Attachments[0].Text, "`add`"))
+}
+
+func TestTeamAliases(t *testing.T) {
+	b := getTestBot()
+	for alias, name := range map[string]string{
+		"add-team":    "team add",
+		"edit-team":   "team edit",
+		"remove-team": "team remove",
+		"view-team":   "team view",
+		"teams":       "team list",
+		"add-user":    "team add-member",
+		"remove-user": "team remove-member",
+	} {
+		assert.Equal(t, name, b.Command(alias).FullName())
+	}
+}
+
+func TestPermissions(t *testing.T) {
+	b := getTestBot()
+	res, _, err := b.Commands["permissions"].Execute(getTestContext("@rocket permissions"))
+	assert.Nil(t, err)
+	assert.True(t, strings.Contains(res, "team remove: admins\n"))
+	assert.True(t, strings.Contains(res, "team view: anyone\n"))
+	assert.False(t, strings.Contains(res, "team: "))
+}
+
+func TestAdminOnlyCommand(t *testing.T) {
+	b := getTestBot()
+	_, _, err := b.Commands["toggle-admin"].Execute(
+		getTestContext("@rocket toggle-admin <@U5RU9TB38>"))
+	assert.NotNil(t, err)
+	assert.Equal(t, "Only admins can use `toggle-admin`", err.Error())
+}
+
+func TestDestructiveCommands(t *testing.T) {
+	b := getTestBot()
+	ctx := getTestContext("@rocket team remove Rocket")
+	ctx.User.IsAdmin = true
+
+	_, _, err := b.Command("team").Execute(ctx)
+	confirm, ok := err.(*cmd.ConfirmationRequired)
+	assert.True(t, ok)
+	assert.Equal(t, "This will delete the team `Rocket` and its GitHub team", confirm.Summary)
+
+	ctx.Message.Text = "@rocket toggle-admin <@U5RU9TB38>"
+	_, _, err = b.Command("toggle-admin").Execute(ctx)
+	confirm, ok = err.(*cmd.ConfirmationRequired)
+	assert.True(t, ok)
+	assert.Equal(t, "This will toggle <@U5RU9TB38>'s admin status", confirm.Summary)
+}
+
+func TestPruneWithoutDeletedUsers(t *testing.T) {
+	b := getTestBot()
+	ctx := getTestContext("@rocket prune")
+	ctx.User.IsAdmin = true
+
+	// There is nothing to confirm
+	res, _, err := b.Command("prune").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "There are no deleted members to remove", res)
+}
+
+// getStoreTestContext returns a context for the given message sent by the
+// member with the given Slack ID in the test bot's store.
+func getStoreTestContext(b *bot.Bot, slackID, text string) cmd.Context {
+	ctx := getTestContext(text)
+	ctx.User = model.Member{SlackID: slackID}
+	if err := b.DAL.GetMemberBySlackID(&ctx.User); err != nil {
+		panic(err)
+	}
+	ctx.ResolveTeam = func(name string) (*model.Team, error) {
+		team := &model.Team{Name: name}
+		return team, b.DAL.GetTeamByName(team)
+	}
+	return ctx
+}
+
+func TestMemberCommands(t *testing.T) {
+	b := getTestBot()
+	b.DAL.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno", IsAdmin: true})
+	b.DAL.CreateMember(&model.Member{SlackID: "U2", Name: "Alice"})
+
+	ctx := getStoreTestContext(b, "U1", "@rocket set name={Big Bruno} major={Rocket Science}")
+	res, _, err := b.Command("set").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "Your information has been updated :simple_smile:", res)
+	member := &model.Member{SlackID: "U1"}
+	assert.Nil(t, b.DAL.GetMemberBySlackID(member))
+	assert.Equal(t, "Big Bruno", member.Name)
+	assert.Equal(t, "Rocket Science", member.Major)
+
+	ctx = getStoreTestContext(b, "U1", "@rocket toggle-tech-lead <@U2>")
+	_, _, err = b.Command("toggle-tech-lead").Execute(ctx)
+	assert.Nil(t, err)
+	res, _, err = b.Command("tech-leads").Execute(getStoreTestContext(b, "U2", "@rocket tech-leads"))
+	assert.Nil(t, err)
+	assert.Equal(t, "Alice\n", res)
+	res, _, err = b.Command("admins").Execute(getStoreTestContext(b, "U2", "@rocket admins"))
+	assert.Nil(t, err)
+	assert.Equal(t, "Big Bruno\n", res)
+
+	res, params, err := b.Command("view-user").Execute(getStoreTestContext(b, "U2", "@rocket view-user <@U2>"))
+	assert.Nil(t, err)
+	assert.Equal(t, "<@U2>'s profile", res)
+	assert.Equal(t, "Name: Alice", params.Attachments[0].Text)
+
+	res, _, err = b.Command("view-user").Execute(getStoreTestContext(b, "U2", "@rocket view-user <@U3>"))
+	assert.Nil(t, err)
+	assert.Equal(t, "Failed to get member <@U3>", res)
+}
+
+func TestTagCommands(t *testing.T) {
+	b := getTestBot()
+	b.DAL.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno"})
+	b.DAL.CreateMember(&model.Member{SlackID: "U2", Name: "Alice"})
+
+	ctx := getStoreTestContext(b, "U1", "@rocket set skills={React, Go} interests={Machine  Learning}")
+	res, params, err := b.Command("set").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "Your information has been updated :simple_smile:", res)
+	assert.Equal(t, "Skills: go, react", params.Attachments[6].Text)
+	assert.Equal(t, "Interests: machine learning", params.Attachments[7].Text)
+	ctx = getStoreTestContext(b, "U2", "@rocket set skills={react}")
+	_, _, err = b.Command("set").Execute(ctx)
+	assert.Nil(t, err)
+
+	// Setting skills again replaces them
+	ctx = getStoreTestContext(b, "U1", "@rocket set skills={react}")
+	_, _, err = b.Command("set").Execute(ctx)
+	assert.Nil(t, err)
+	member := &model.Member{SlackID: "U1"}
+	assert.Nil(t, b.DAL.GetMemberBySlackID(member))
+	assert.Equal(t, []string{"react"}, member.Skills)
+	assert.Equal(t, []string{"machine learning"}, member.Interests)
+
+	ctx = getStoreTestContext(b, "U2", "@rocket find skill={React}")
+	res, _, err = b.Command("find").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "Members with the skill `react`:\nAlice (<@U2>)\nBruno (<@U1>)\n", res)
+	ctx = getStoreTestContext(b, "U2", "@rocket find interest={machine learning}")
+	res, _, err = b.Command("find").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "Members with the interest `machine learning`:\nBruno (<@U1>)\n", res)
+	ctx = getStoreTestContext(b, "U2", "@rocket find skill={go}")
+	res, _, err = b.Command("find").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "No one has the skill `go` yet", res)
+	ctx = getStoreTestContext(b, "U2", "@rocket find")
+	res, _, err = b.Command("find").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "Please give a skill or interest to find members by, e.g. `find skill={react}`", res)
+
+	// Members can't tag themselves with too many skills
+	skills := []string{}
+	for i := 0; i <= maxTags; i++ {
+		skills = append(skills, fmt.Sprintf("skill %d", i))
+	}
+	ctx = getStoreTestContext(b, "U2", "@rocket set skills={"+strings.Join(skills, ",")+"}")
+	res, _, err = b.Command("set").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "Sorry, you can have at most 20 skills", res)
+}
+
+func TestSearchCommand(t *testing.T) {
+	b := getTestBot()
+	b.DAL.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno", Position: "Rocket Mascot"})
+	b.DAL.CreateMember(&model.Member{SlackID: "U2", Name: "Alice"})
+	b.DAL.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1, Platform: "Go"})
+
+	ctx := getStoreTestContext(b, "U2", "@rocket search rocket")
+	res, _, err := b.Command("search").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "Members matching `rocket`:\nBruno (<@U1>) - Rocket Mascot\n"+
+		"Teams matching `rocket`:\nRocket - Go\n", res)
+
+	ctx = getStoreTestContext(b, "U2", "@rocket search \"rockt\"")
+	res, _, err = b.Command("search").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "Teams matching `rockt`:\nRocket - Go\n", res)
+
+	ctx = getStoreTestContext(b, "U2", "@rocket search \"underwater basket\"")
+	res, _, err = b.Command("search").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "Nothing matches `underwater basket`", res)
+}
+
+func TestTeamCommands(t *testing.T) {
+	b := getTestBot()
+	b.DAL.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno", IsAdmin: true})
+	b.DAL.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1})
+	b.DAL.CreateTeam(&model.Team{Name: "Buddy", GithubTeamID: 2})
+
+	res, _, err := b.Command("team").Execute(getStoreTestContext(b, "U1", "@rocket team list"))
+	assert.Nil(t, err)
+	assert.Equal(t, "Buddy\nRocket\n", res)
+
+	ctx := getStoreTestContext(b, "U1", "@rocket team edit Rocket platform={Go}")
+	res, _, err = b.Command("team").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "`Rocket` has been updated :tada:", res)
+	team := &model.Team{Name: "Rocket"}
+	assert.Nil(t, b.DAL.GetTeamByName(team))
+	assert.Equal(t, "Go", team.Platform)
+
+	// Teams that don't exist are rejected before the handler runs
+	ctx = getStoreTestContext(b, "U1", "@rocket team edit Nope platform={Go}")
+	_, _, err = b.Command("team").Execute(ctx)
+	assert.NotNil(t, err)
+	assert.Equal(t, data.ErrNotFound, b.DAL.GetTeamByName(&model.Team{Name: "Nope"}))
+}
+
+func TestTeamRoleCommands(t *testing.T) {
+	b := getTestBot()
+	b.DAL.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno"})
+	b.DAL.CreateMember(&model.Member{SlackID: "U2", Name: "Alice"})
+	b.DAL.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1})
+	b.DAL.CreateTeam(&model.Team{Name: "Buddy", GithubTeamID: 2})
+	b.DAL.CreateTeamMember(&model.TeamMember{
+		GithubTeamID: 1, MemberSlackID: "U1", Role: model.TeamRoleLead})
+	b.DAL.CreateTeamMember(&model.TeamMember{GithubTeamID: 1, MemberSlackID: "U2"})
+	b.DAL.CreateTeamMember(&model.TeamMember{GithubTeamID: 2, MemberSlackID: "U1"})
+
+	// Leads can manage their own team
+	ctx := getStoreTestContext(b, "U1", "@rocket team set-role <@U2> Rocket designer")
+	res, _, err := b.Command("team").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "<@U2> is now a designer on `Rocket` :tada:", res)
+	team := &model.Team{Name: "Rocket"}
+	assert.Nil(t, b.DAL.GetTeamByName(team))
+	assert.Equal(t, model.TeamRoleDesigner, team.Members[0].TeamRole)
+
+	ctx = getStoreTestContext(b, "U1", "@rocket team edit Rocket platform={Go}")
+	_, _, err = b.Command("team").Execute(ctx)
+	assert.Nil(t, err)
+
+	// But not teams they're only a member of
+	ctx = getStoreTestContext(b, "U1", "@rocket team edit Buddy platform={Go}")
+	_, _, err = b.Command("team").Execute(ctx)
+	assert.NotNil(t, err)
+	ctx = getStoreTestContext(b, "U1", "@rocket team set-role <@U1> Buddy lead")
+	_, _, err = b.Command("team").Execute(ctx)
+	assert.NotNil(t, err)
+
+	// Roles can only be set for members of the team
+	ctx = getStoreTestContext(b, "U1", "@rocket team set-role <@U3> Rocket pm")
+	res, _, err = b.Command("team").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "<@U3> is not on `Rocket`", res)
+}
+
+func TestAuditCommand(t *testing.T) {
+	b := getTestBot()
+	b.DAL.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno", IsAdmin: true})
+	b.DAL.CreateMember(&model.Member{SlackID: "U2", Name: "Alice"})
+	b.DAL.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1})
+
+	ctx := getStoreTestContext(b, "U1", "@rocket toggle-tech-lead <@U2>")
+	_, _, err := b.Commands["toggle-tech-lead"].Execute(ctx)
+	assert.Nil(t, err)
+	ctx = getStoreTestContext(b, "U1", "@rocket team edit Rocket platform={Go}")
+	_, _, err = b.Command("team").Execute(ctx)
+	assert.Nil(t, err)
+
+	ctx = getStoreTestContext(b, "U1", "@rocket audit member=<@U2>")
+	res, _, err := b.Commands["audit"].Execute(ctx)
+	assert.Nil(t, err)
+	lines := strings.Split(strings.TrimSpace(res), "\n")
+	if assert.Len(t, lines, 1) {
+		assert.Contains(t, lines[0], "<@U1> `member.update` <@U2> with `toggle-tech-lead <@U2>`")
+		assert.Contains(t, lines[0], `"isTechLead":false`)
+		assert.Contains(t, lines[0], `"isTechLead":true`)
+	}
+
+	ctx = getStoreTestContext(b, "U1", "@rocket audit limit=1")
+	res, _, err = b.Commands["audit"].Execute(ctx)
+	assert.Nil(t, err)
+	assert.Contains(t, res, "`team.update` Rocket with `team edit Rocket platform={Go}`")
+	assert.NotContains(t, res, "member.update")
+
+	ctx = getStoreTestContext(b, "U1", "@rocket audit by=<@U2>")
+	res, _, err = b.Commands["audit"].Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "No changes match", res)
+
+	// Only admins can see the audit log
+	ctx = getStoreTestContext(b, "U2", "@rocket audit")
+	_, _, err = b.Commands["audit"].Execute(ctx)
+	assert.NotNil(t, err)
+}
+
+func TestReactivateCommand(t *testing.T) {
+	b := getTestBot()
+	leftAt := time.Now()
+	b.DAL.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno", IsAdmin: true})
+	b.DAL.CreateMember(&model.Member{SlackID: "U2", Name: "Alice", LeftAt: &leftAt})
+
+	ctx := getStoreTestContext(b, "U1", "@rocket reactivate <@U2>")
+	res, _, err := b.Commands["reactivate"].Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "<@U2> is an active member again :tada:", res)
+	member := &model.Member{SlackID: "U2"}
+	assert.Nil(t, b.DAL.GetMemberBySlackID(member))
+	assert.False(t, member.IsAlumni())
+
+	res, _, err = b.Commands["reactivate"].Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "<@U2> is already an active member", res)
+}
+
+func TestTermCommands(t *testing.T) {
+	b := getTestBot()
+	b.DAL.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno", IsAdmin: true})
+	b.DAL.CreateMember(&model.Member{SlackID: "U2", Name: "Alice"})
+	b.DAL.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1})
+	b.DAL.CreateTeam(&model.Team{Name: "Buddy", GithubTeamID: 2})
+	b.DAL.CreateTeamMember(&model.TeamMember{
+		GithubTeamID: 1, MemberSlackID: "U2", Role: model.TeamRoleLead})
+	previous := model.TermAt(time.Now())
+
+	// Teams are rolled forward into new terms, with their members if asked
+	ctx := getStoreTestContext(b, "U1", "@rocket term start 2099W members={true}")
+	res, _, err := b.Command("term").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "`2099W` has started with 2 teams :tada: Archive teams "+
+		"that aren't running this term with `term archive`", res)
+	team := &model.Team{Name: "Rocket"}
+	assert.Nil(t, b.DAL.GetTeamByName(team))
+	if assert.Len(t, team.Members, 1) {
+		assert.Equal(t, model.TeamRoleLead, team.Members[0].TeamRole)
+	}
+
+	ctx = getStoreTestContext(b, "U1", "@rocket term list")
+	res, _, err = b.Command("term").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "2099W (current)\n"+previous+"\n", res)
+
+	ctx = getStoreTestContext(b, "U1", "@rocket term start 2099W")
+	res, _, err = b.Command("term").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "`2099W` has already started", res)
+	ctx = getStoreTestContext(b, "U1", "@rocket term start 2099X")
+	_, _, err = b.Command("term").Execute(ctx)
+	assert.NotNil(t, err)
+
+	// Archived teams can't get new members until they're rolled forward
+	ctx = getStoreTestContext(b, "U1", "@rocket term archive Rocket")
+	ctx.Confirmed = true
+	res, _, err = b.Command("term").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "`Rocket` has been archived :tada:", res)
+	team = &model.Team{Name: "Rocket"}
+	assert.Nil(t, b.DAL.GetTeamByName(team))
+	assert.True(t, team.Archived)
+	assert.Len(t, team.Members, 0)
+	teams := model.Teams{}
+	assert.Nil(t, b.DAL.GetTeamsByTerm(previous, &teams))
+	if assert.Len(t, teams, 2) {
+		assert.Len(t, teams[1].Members, 1)
+	}
+
+	ctx = getStoreTestContext(b, "U1", "@rocket team add-member <@U1> Rocket")
+	res, _, err = b.Command("team").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "`Rocket` isn't running this term. Roll it forward "+
+		"with `term roll` first", res)
+
+	ctx = getStoreTestContext(b, "U1", "@rocket term roll Rocket members={true}")
+	res, _, err = b.Command("term").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "`Rocket` is running in `2099W` :tada:", res)
+	team = &model.Team{Name: "Rocket"}
+	assert.Nil(t, b.DAL.GetTeamByName(team))
+	assert.False(t, team.Archived)
+	assert.Len(t, team.Members, 1)
+
+	ctx = getStoreTestContext(b, "U1", "@rocket term roll Rocket")
+	res, _, err = b.Command("term").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "`Rocket` is already running this term", res)
+
+	// Teams deleted on GitHub can't run again
+	buddy := &model.Team{Name: "Buddy", GithubTeamID: 2}
+	assert.Nil(t, b.DAL.RemoveTeamFromTerm(buddy, "2099W"))
+	assert.Nil(t, b.DAL.UnlinkTeam(buddy))
+	ctx = getStoreTestContext(b, "U1", "@rocket term roll Buddy")
+	res, _, err = b.Command("term").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "`Buddy` was deleted on GitHub, so it can't run again", res)
+
+	// Only admins can manage terms
+	ctx = getStoreTestContext(b, "U2", "@rocket term start 2100S")
+	_, _, err = b.Command("term").Execute(ctx)
+	assert.NotNil(t, err)
+}
+
+func TestProjectCommands(t *testing.T) {
+	b := getTestBot()
+	b.DAL.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno", IsAdmin: true})
+	b.DAL.CreateMember(&model.Member{SlackID: "U2", Name: "Alice"})
+	b.DAL.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1})
+	b.DAL.CreateTeamMember(&model.TeamMember{
+		GithubTeamID: 1, MemberSlackID: "U2", Role: model.TeamRoleLead})
+
+	ctx := getStoreTestContext(b, "U1", "@rocket project view Rocket")
+	res, _, err := b.Command("project").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "`Rocket` doesn't have a project yet. Add one with `project edit`", res)
+
+	// Leads can edit their team's project
+	ctx = getStoreTestContext(b, "U2", "@rocket project edit Rocket "+
+		"description={Our Slack bot} status=active stack={Go, Postgres} "+
+		"website={https://ubclaunchpad.com}")
+	res, _, err = b.Command("project").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "`Rocket`'s project has been updated :tada:", res)
+	project := &model.Project{GithubTeamID: 1}
+	assert.Nil(t, b.DAL.GetProjectByTeam(project))
+	assert.Equal(t, "Rocket", project.Name)
+	assert.Equal(t, "Our Slack bot", project.Description)
+	assert.Equal(t, model.ProjectStatusActive, project.Status)
+	assert.Equal(t, []string{"Go", "Postgres"}, project.TechStack)
+
+	// Only the fields that are given change
+	ctx = getStoreTestContext(b, "U2", "@rocket project edit Rocket name={Rocket Bot}")
+	_, _, err = b.Command("project").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Nil(t, b.DAL.GetProjectByTeam(project))
+	assert.Equal(t, "Rocket Bot", project.Name)
+	assert.Equal(t, "Our Slack bot", project.Description)
+
+	ctx = getStoreTestContext(b, "U2", "@rocket project edit Rocket website={ubclaunchpad}")
+	res, _, err = b.Command("project").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "ubclaunchpad is not a website URL", res)
+
+	ctx = getStoreTestContext(b, "U1", "@rocket project list")
+	res, _, err = b.Command("project").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "Rocket Bot (Rocket): active\n", res)
+
+	// Only admins can remove projects
+	ctx = getStoreTestContext(b, "U2", "@rocket project remove Rocket")
+	ctx.Confirmed = true
+	_, _, err = b.Command("project").Execute(ctx)
+	assert.NotNil(t, err)
+	ctx = getStoreTestContext(b, "U1", "@rocket project remove Rocket")
+	ctx.Confirmed = true
+	res, _, err = b.Command("project").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "`Rocket`'s project has been deleted :tada:", res)
+	assert.Equal(t, data.ErrNotFound, b.DAL.GetProjectByTeam(project))
+}
@@ -1,10 +1,21 @@
 package core
 
 import (
+	"context"
+	"errors"
+
+	"github.com/nlopes/slack"
 	"github.com/ubclaunchpad/rocket/bot"
 	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/data"
+	"github.com/ubclaunchpad/rocket/model"
 )
 
+// errInvalid is returned from units of work that are abandoned because the
+// user gave an invalid value, which is explained in the response rather than
+// logged.
+var errInvalid = errors.New("invalid value")
+
 // Plugin stores the values required for accessing GitHub, Slack, Postgres,
 // and Rocket's HTTP request handlers.
 type Plugin struct {
@@ -24,6 +35,11 @@ func (cp *Plugin) Start() error {
 	return nil
 }
 
+// Stop stops the plugin.
+func (cp *Plugin) Stop(ctx context.Context) error {
+	return nil
+}
+
 // Commands returns a list of commands this plugin makes available to the Bot.
 func (cp *Plugin) Commands() []*cmd.Command {
 	return []*cmd.Command{
@@ -31,18 +47,39 @@ func (cp *Plugin) Commands() []*cmd.Command {
 		NewSetCmd(cp.set),
 		NewEditUserCmd(cp.editUser),
 		NewViewUserCmd(cp.viewUser),
-		NewViewTeamCmd(cp.viewTeam),
-		NewAddUserCmd(cp.addUser),
-		NewAddTeamCmd(cp.addTeam),
-		NewEditTeamCmd(cp.editTeam),
+		NewFindCmd(cp.find),
+		NewSearchCmd(cp.search),
+		NewTeamCmd().AddSubcommands(
+			NewViewTeamCmd(cp.viewTeam),
+			NewAddUserCmd(cp.addUser),
+			NewSetRoleCmd(cp.setRole),
+			NewAddTeamCmd(cp.addTeam),
+			NewEditTeamCmd(cp.editTeam),
+			NewRemoveUserCmd(cp.removeUser),
+			NewRemoveTeamCmd(cp.removeTeam),
+			NewTeamsCmd(cp.listTeams),
+		),
+		NewTermCmd().AddSubcommands(
+			NewTermsCmd(cp.listTerms),
+			NewStartTermCmd(cp.startTerm),
+			NewRollTeamCmd(cp.rollTeam),
+			NewArchiveTeamCmd(cp.archiveTeam),
+		),
+		NewProjectCmd().AddSubcommands(
+			NewProjectsCmd(cp.listProjects),
+			NewViewProjectCmd(cp.viewProject),
+			NewEditProjectCmd(cp.editProject),
+			NewRemoveProjectCmd(cp.removeProject),
+		),
 		NewToggleAdminCmd(cp.toggleAdmin),
-		NewRemoveUserCmd(cp.removeUser),
-		NewRemoveTeamCmd(cp.removeTeam),
-		NewTeamsCmd(cp.listTeams),
 		NewAdminsCmd(cp.listAdmins),
 		NewRefreshCmd(cp.refresh),
+		NewPruneCmd(cp.prune, cp.pruneSummary),
+		NewReactivateCmd(cp.reactivate),
 		NewTechLeadsCmd(cp.listTechLeads),
 		NewToggleTechLeadCmd(cp.toggleTechLead),
+		NewPermissionsCmd(cp.listPermissions),
+		NewAuditCmd(cp.audit),
 	}
 }
 
@@ -51,3 +88,41 @@ func (cp *Plugin) EventHandlers() map[string]bot.EventHandler {
 	// This plugin currently has no custom event handlers.
 	return map[string]bot.EventHandler{}
 }
+
+// ActionHandlers returns a mapping from action ID to action handler.
+func (cp *Plugin) ActionHandlers() map[string]bot.ActionHandler {
+	return map[string]bot.ActionHandler{
+		joinTeamAction:      cp.handleJoinTeam,
+		addTeamMemberAction: cp.handleAddTeamMember,
+		removeTeamAction:    cp.handleRemoveTeam,
+	}
+}
+
+// recordGitHub records a change made on GitHub in the audit log. The change
+// has already been made, so failing to record it is only logged.
+func (cp *Plugin) recordGitHub(store *data.AuditedStore, action, target string, before, after interface{}) {
+	if err := store.Record(action, target, before, after); err != nil {
+		cp.Bot.Log.WithError(err).Error("Failed to record GitHub change")
+	}
+}
+
+// githubTeamMember describes a GitHub user's membership of a team for the
+// audit log.
+func githubTeamMember(githubUsername string, team *model.Team) map[string]interface{} {
+	return map[string]interface{}{
+		"githubUsername": githubUsername,
+		"team":           team.Name,
+		"githubTeamId":   team.GithubTeamID,
+	}
+}
+
+// runAction runs a command on behalf of the user who took an action, so that
+// the command's permissions apply, and returns the command's response or the
+// reason it failed.
+func (cp *Plugin) runAction(c cmd.Context, text string) (string, slack.PostMessageParameters) {
+	res, params, err := cp.Bot.RunCommand(c, text)
+	if err != nil {
+		return err.Error(), slack.PostMessageParameters{}
+	}
+	return res, params
+}
@@ -0,0 +1,119 @@
+package core
+
+import (
+	"net/url"
+
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/data"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// NewEditProjectCmd returns an edit project command that adds or updates the
+// project a team is building
+func NewEditProjectCmd(ch cmd.CommandHandler) *cmd.Command {
+	return &cmd.Command{
+		Name:     "edit",
+		HelpText: "Add or update the project a Launch Pad team is building",
+		Options: map[string]*cmd.Option{
+			"team": &cmd.Option{
+				Key:      "team",
+				HelpText: "the name of the team building the project",
+				Type:     cmd.TeamOption,
+				Required: true,
+			},
+			"name": &cmd.Option{
+				Key:      "name",
+				HelpText: "the name of the project (the team's name by default)",
+				Format:   cmd.AnyRegex,
+			},
+			"description": &cmd.Option{
+				Key:      "description",
+				HelpText: "what the project is",
+				Format:   cmd.AnyRegex,
+			},
+			"repos": &cmd.Option{
+				Key:      "repos",
+				HelpText: "the project's GitHub repositories, e.g. ubclaunchpad/rocket",
+				Type:     cmd.ListOption,
+			},
+			"website": &cmd.Option{
+				Key:      "website",
+				HelpText: "the URL of the project's website",
+				Format:   cmd.AnyRegex,
+			},
+			"status": &cmd.Option{
+				Key:      "status",
+				HelpText: "how far along the project is",
+				Type:     cmd.EnumOption,
+				Choices:  projectStatusChoices(),
+			},
+			"stack": &cmd.Option{
+				Key:      "stack",
+				HelpText: "the technologies the project is built with, e.g. Go,Postgres",
+				Type:     cmd.ListOption,
+			},
+		},
+		Args:       []string{"team"},
+		Permission: cmd.AnyOf(cmd.Roles(model.RoleAdmin), cmd.TeamLead("team")),
+		HandleFunc: ch,
+	}
+}
+
+// editProject adds or updates a team's project. Only the fields that are
+// given are changed.
+func (core *Plugin) editProject(c cmd.Context) (string, slack.PostMessageParameters) {
+	noParams := slack.PostMessageParameters{}
+	team := c.Options["team"].Team()
+	store := core.Bot.Store(c)
+	project := &model.Project{GithubTeamID: team.GithubTeamID}
+	if err := store.GetProjectByTeam(project); err != nil && err != data.ErrNotFound {
+		log.WithError(err).Errorf("Failed to get project of team %s", team.Name)
+		return "Failed to get project of team " + team.Name, noParams
+	}
+
+	if c.Options["name"].IsSet() {
+		project.Name = c.Options["name"].Value
+	}
+	if project.Name == "" {
+		project.Name = team.Name
+	}
+	if c.Options["description"].IsSet() {
+		project.Description = c.Options["description"].Value
+	}
+	if c.Options["website"].IsSet() {
+		website := c.Options["website"].Value
+		if u, err := url.Parse(website); err != nil || u.Host == "" ||
+			(u.Scheme != "http" && u.Scheme != "https") {
+			return website + " is not a website URL", noParams
+		}
+		project.Website = website
+	}
+	if c.Options["status"].IsSet() {
+		project.Status = model.ProjectStatus(c.Options["status"].Value)
+	}
+	if c.Options["stack"].IsSet() {
+		project.TechStack = c.Options["stack"].List()
+	}
+
+	// Make sure the repositories exist, and store their canonical URLs
+	if c.Options["repos"].IsSet() {
+		repos := []string{}
+		for _, repo := range c.Options["repos"].List() {
+			r, err := core.Bot.GitHub.GetRepo(c, repo)
+			if err != nil {
+				log.WithError(err).Errorf("Failed to find GitHub repository %s", repo)
+				return "Failed to find GitHub repository " + repo, noParams
+			}
+			repos = append(repos, r.GetHTMLURL())
+		}
+		project.Repos = repos
+	}
+
+	if err := store.SaveProject(project); err != nil {
+		log.WithError(err).Errorf("Failed to save project of team %s", team.Name)
+		return "Failed to save project of team " + team.Name, noParams
+	}
+	return "`" + team.Name + "`'s project has been updated :tada:", noParams
+}
@@ -10,13 +10,14 @@ import (
 // NewEditTeamCmd returns an add team command that creates a new Launch Pad team
 func NewEditTeamCmd(ch cmd.CommandHandler) *cmd.Command {
 	return &cmd.Command{
-		Name:     "edit-team",
-		HelpText: "Update an existing Launch Pad team (admins and tech leads only)",
+		Name:     "edit",
+		Aliases:  []string{"edit-team"},
+		HelpText: "Update an existing Launch Pad team",
 		Options: map[string]*cmd.Option{
 			"team": &cmd.Option{
 				Key:      "team",
 				HelpText: "the name of the existing team",
-				Format:   cmd.AnyRegex,
+				Type:     cmd.TeamOption,
 				Required: true,
 			},
 			"name": &cmd.Option{
@@ -32,6 +33,8 @@ func NewEditTeamCmd(ch cmd.CommandHandler) *cmd.Command {
 				Required: false,
 			},
 		},
+		Args:       []string{"team"},
+		Permission: cmd.AnyOf(cmd.Roles(model.RoleAdmin), cmd.TeamLead("team")),
 		HandleFunc: ch,
 	}
 }
@@ -39,27 +42,15 @@ func NewEditTeamCmd(ch cmd.CommandHandler) *cmd.Command {
 // editTeam edits an existing Launch Pad team.
 func (core *Plugin) editTeam(c cmd.Context) (string, slack.PostMessageParameters) {
 	noParams := slack.PostMessageParameters{}
-	if !c.User.IsAdmin && !c.User.IsTechLead {
-		return "You must be an admin or tech lead to use this command", noParams
-	}
-
 	currentName := c.Options["team"].Value
-	currentTeam := &model.Team{
-		Name: currentName,
-	}
+	currentTeam := c.Options["team"].Team()
 	newTeam := &model.Team{
 		Name:     c.Options["name"].Value,
 		Platform: c.Options["platform"].Value,
 	}
 
-	// Try get existing team from DB
-	if err := core.Bot.DAL.GetTeamByName(currentTeam); err != nil {
-		core.Bot.Log.WithError(err).Errorf("failed to update team %s", currentName)
-		return "Failed to update team " + currentName, noParams
-	}
-
 	// Finally, update team in DB
-	if err := core.Bot.DAL.UpdateTeam(currentTeam, newTeam); err != nil {
+	if err := core.Bot.Store(c).UpdateTeam(currentTeam, newTeam); err != nil {
 		log.WithError(err).Errorf("failed to update team %s", currentName)
 		return "Failed to update team " + currentName, noParams
 	}
@@ -11,13 +11,13 @@ import (
 func NewEditUserCmd(ch cmd.CommandHandler) *cmd.Command {
 	return &cmd.Command{
 		Name:     "edit",
-		HelpText: "Set properties on another user's Launch Pad profile (admins only)",
+		HelpText: "Set properties on another user's Launch Pad profile",
 		Options: map[string]*cmd.Option{
 			"member": &cmd.Option{
 				Key:      "member",
 				HelpText: "the Slack handle of the user to edit",
-				Format:   cmd.AnyRegex,
-				Required: false,
+				Type:     cmd.UserOption,
+				Required: true,
 			},
 			"name": &cmd.Option{
 				Key:      "name",
@@ -50,6 +50,8 @@ func NewEditUserCmd(ch cmd.CommandHandler) *cmd.Command {
 				Required: false,
 			},
 		},
+		Args:       []string{"member"},
+		Permission: cmd.Roles(model.RoleAdmin),
 		HandleFunc: ch,
 	}
 }
@@ -57,13 +59,9 @@ func NewEditUserCmd(ch cmd.CommandHandler) *cmd.Command {
 // Generic command for setting some information about the sender's profile.
 func (core *Plugin) editUser(c cmd.Context) (string, slack.PostMessageParameters) {
 	noParams := slack.PostMessageParameters{}
-	if !c.User.IsAdmin {
-		return "You must be an admin to use this command", noParams
-	}
-
 	memberName := c.Options["member"].Value
 	c.User = model.Member{
-		SlackID: cmd.ParseMention(memberName),
+		SlackID: c.Options["member"].UserID(),
 	}
 	if err := core.Bot.DAL.GetMemberBySlackID(&c.User); err != nil {
 		return "Failed to find member " + memberName, noParams
@@ -0,0 +1,64 @@
+package core
+
+import (
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// NewFindCmd returns a find command that lists Launch Pad members with a
+// skill or interest
+func NewFindCmd(ch cmd.CommandHandler) *cmd.Command {
+	return &cmd.Command{
+		Name:     "find",
+		HelpText: "Find Launch Pad members with a skill or interest",
+		Options: map[string]*cmd.Option{
+			"skill": &cmd.Option{
+				Key:      "skill",
+				HelpText: "a skill members have tagged themselves with, e.g. react",
+				Format:   cmd.AnyRegex,
+			},
+			"interest": &cmd.Option{
+				Key:      "interest",
+				HelpText: "an interest members have tagged themselves with, e.g. machine learning",
+				Format:   cmd.AnyRegex,
+			},
+		},
+		HandleFunc: ch,
+	}
+}
+
+// find lists the members who have tagged themselves with a skill or
+// interest.
+func (core *Plugin) find(c cmd.Context) (string, slack.PostMessageParameters) {
+	noParams := slack.PostMessageParameters{}
+	tag := &model.Tag{}
+	switch {
+	case c.Options["skill"].IsSet() && c.Options["interest"].IsSet():
+		return "Please find members by either a skill or an interest", noParams
+	case c.Options["skill"].IsSet():
+		tag.Kind = model.TagKindSkill
+		tag.Name = model.NormalizeTag(c.Options["skill"].Value)
+	case c.Options["interest"].IsSet():
+		tag.Kind = model.TagKindInterest
+		tag.Name = model.NormalizeTag(c.Options["interest"].Value)
+	default:
+		return "Please give a skill or interest to find members by, " +
+			"e.g. `find skill={react}`", noParams
+	}
+
+	members := model.Members{}
+	if err := core.Bot.DAL.GetMembersByTag(tag, &members); err != nil {
+		log.WithError(err).Errorf("Failed to get members with %s %s", tag.Kind, tag.Name)
+		return "Failed to find members", noParams
+	}
+	if len(members) == 0 {
+		return "No one has the " + string(tag.Kind) + " `" + tag.Name + "` yet", noParams
+	}
+	res := "Members with the " + string(tag.Kind) + " `" + tag.Name + "`:\n"
+	for _, member := range members {
+		res += member.Name + " (" + cmd.ToMention(member.SlackID) + ")\n"
+	}
+	return res, noParams
+}
@@ -2,6 +2,7 @@ package core
 
 import (
 	"fmt"
+	"strings"
 
 	"github.com/nlopes/slack"
 	"github.com/ubclaunchpad/rocket/cmd"
@@ -20,7 +21,14 @@ func NewHelpCmd(ch cmd.CommandHandler) *cmd.Command {
 				Format:   cmd.AlphaRegex,
 				Required: false,
 			},
+			"subcommand": &cmd.Option{
+				Key:      "subcommand",
+				HelpText: "get help using a particular subcommand of a Rocket command",
+				Format:   cmd.AlphaRegex,
+				Required: false,
+			},
 		},
+		Args:       []string{"command", "subcommand"},
 		HandleFunc: ch,
 	}
 }
@@ -33,8 +41,8 @@ func (core *Plugin) help(c cmd.Context) (string, slack.PostMessageParameters) {
 	if opt == "" {
 		// General help
 		res = "Usage: `@rocket COMMAND`\n\nGet help using a specific " +
-			"command with `@rocket help command={COMMAND}`\n" +
-			"Example: `@rocket set name={A Guy} github={arealguy}`"
+			"command with `@rocket help COMMAND`\n" +
+			"Example: `@rocket set name=\"A Guy\" github=arealguy`"
 
 		// Get length of longest command to to evenly space command names and
 		// their descriptions
@@ -52,7 +60,11 @@ func (core *Plugin) help(c cmd.Context) (string, slack.PostMessageParameters) {
 			for i := 0; i < longestCmdLength-len(cmd.Name); i++ {
 				dividerSpace += " "
 			}
-			cmds += fmt.Sprintf("%s%s %s\n", cmd.Name, dividerSpace, cmd.HelpText)
+			helpText := cmd.HelpText
+			if cmd.Permission != nil {
+				helpText += " (" + cmd.Permission.String() + " only)"
+			}
+			cmds += fmt.Sprintf("%s%s %s\n", cmd.Name, dividerSpace, helpText)
 		}
 		cmds += "\n```"
 		commands := slack.Attachment{
@@ -64,12 +76,13 @@ func (core *Plugin) help(c cmd.Context) (string, slack.PostMessageParameters) {
 		return res, params
 	}
 	// Command-specific help
-	for _, cmd := range core.Bot.Commands {
-		if opt == cmd.Name {
-			return cmd.Help()
+	path := strings.Fields(opt + " " + c.Options["subcommand"].Value)
+	if command := core.Bot.Command(path[0]); command != nil {
+		if sub := command.Find(path[1:]...); sub != nil {
+			return sub.Help()
 		}
 	}
 	res = fmt.Sprintf("`%s` is not a Rocket command.\n"+
-		"See `@rocket help`", opt)
+		"See `@rocket help`", strings.Join(path, " "))
 	return res, slack.PostMessageParameters{}
 }
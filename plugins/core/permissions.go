@@ -0,0 +1,55 @@
+package core
+
+import (
+	"fmt"
+	"sort"
+
+	"github.com/nlopes/slack"
+	"github.com/ubclaunchpad/rocket/cmd"
+)
+
+// NewPermissionsCmd returns a permissions command that lists who is allowed
+// to use each Rocket command
+func NewPermissionsCmd(ch cmd.CommandHandler) *cmd.Command {
+	return &cmd.Command{
+		Name:       "permissions",
+		HelpText:   "List who can use each Rocket command",
+		Options:    map[string]*cmd.Option{},
+		HandleFunc: ch,
+	}
+}
+
+// listPermissions displays the permission required by every Rocket command
+// and subcommand.
+func (core *Plugin) listPermissions(c cmd.Context) (string, slack.PostMessageParameters) {
+	commands := []*cmd.Command{}
+	for _, command := range core.Bot.Commands {
+		commands = append(commands, withSubcommands(command)...)
+	}
+	sort.Slice(commands, func(i, j int) bool {
+		return commands[i].FullName() < commands[j].FullName()
+	})
+
+	res := "```\n"
+	for _, command := range commands {
+		if command.HandleFunc == nil {
+			// Commands that only group subcommands can't be executed
+			continue
+		}
+		who := "anyone"
+		if command.Permission != nil {
+			who = command.Permission.String()
+		}
+		res += fmt.Sprintf("%s: %s\n", command.FullName(), who)
+	}
+	return res + "```", slack.PostMessageParameters{}
+}
+
+// withSubcommands returns the given command and all of its subcommands.
+func withSubcommands(command *cmd.Command) []*cmd.Command {
+	commands := []*cmd.Command{command}
+	for _, sub := range command.Subcommands() {
+		commands = append(commands, withSubcommands(sub)...)
+	}
+	return commands
+}
@@ -0,0 +1,26 @@
+package core
+
+import (
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// NewProjectCmd returns a project command that groups the subcommands used
+// to manage the projects Launch Pad teams are building. Subcommands are
+// added with cmd.AddSubcommands.
+func NewProjectCmd() *cmd.Command {
+	return &cmd.Command{
+		Name:     "project",
+		HelpText: "Manage the projects Launch Pad teams are building",
+		Options:  map[string]*cmd.Option{},
+	}
+}
+
+// projectStatusChoices returns the names of the statuses projects can have.
+func projectStatusChoices() []string {
+	choices := []string{}
+	for _, status := range model.ProjectStatuses {
+		choices = append(choices, string(status))
+	}
+	return choices
+}
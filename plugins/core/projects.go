@@ -0,0 +1,34 @@
+package core
+
+import (
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// NewProjectsCmd returns a projects command that displays a list of projects
+func NewProjectsCmd(ch cmd.CommandHandler) *cmd.Command {
+	return &cmd.Command{
+		Name:       "list",
+		Aliases:    []string{"projects"},
+		HelpText:   "List the projects Launch Pad teams are building",
+		Options:    map[string]*cmd.Option{},
+		HandleFunc: ch,
+	}
+}
+
+// listProjects displays the projects Launch Pad teams are building
+func (core *Plugin) listProjects(c cmd.Context) (string, slack.PostMessageParameters) {
+	noParams := slack.PostMessageParameters{}
+	projects := model.Projects{}
+	if err := core.Bot.DAL.GetProjects(&projects); err != nil {
+		log.WithError(err).Error("Failed to get projects")
+		return "Failed to get projects", noParams
+	}
+	list := ""
+	for _, project := range projects {
+		list += project.Name + " (" + project.Team + "): " + string(project.Status) + "\n"
+	}
+	return list, noParams
+}
@@ -0,0 +1,67 @@
+package core
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/nlopes/slack"
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// NewPruneCmd returns a prune command that makes members whose Slack accounts
+// have been deleted alumni (this action can only be performed by admins)
+func NewPruneCmd(ch cmd.CommandHandler, summary func(cmd.Context) string) *cmd.Command {
+	return &cmd.Command{
+		Name:        "prune",
+		HelpText:    "Make members whose Slack accounts have been deleted alumni and remove them from the GitHub organization",
+		Options:     map[string]*cmd.Option{},
+		Permission:  cmd.Roles(model.RoleAdmin),
+		Destructive: true,
+		Summary:     summary,
+		HandleFunc:  ch,
+	}
+}
+
+// pruneSummary lists the members that prune will make alumni, or returns an
+// empty string if there are none.
+func (core *Plugin) pruneSummary(c cmd.Context) string {
+	names := []string{}
+	for _, user := range core.Bot.DeletedUsers() {
+		names = append(names, user.Name)
+	}
+	if len(names) == 0 {
+		return ""
+	}
+	return fmt.Sprintf("This will make %d members whose Slack accounts have been "+
+		"deleted alumni and remove them from the GitHub organization: %s",
+		len(names), strings.Join(names, ", "))
+}
+
+// prune makes members whose Slack accounts have been deleted alumni.
+func (core *Plugin) prune(c cmd.Context) (string, slack.PostMessageParameters) {
+	noParams := slack.PostMessageParameters{}
+	users := core.Bot.DeletedUsers()
+	if len(users) == 0 {
+		return "There are no deleted members to remove", noParams
+	}
+
+	removed := []string{}
+	failed := []string{}
+	for _, user := range users {
+		if err := core.Bot.RetireMember(c, user.ID); err != nil {
+			core.Bot.Log.WithError(err).Errorf("Failed to retire member %s", user.Name)
+			failed = append(failed, user.Name)
+		} else {
+			removed = append(removed, user.Name)
+		}
+	}
+	res := fmt.Sprintf("%d members are now alumni :wave:", len(removed))
+	if len(removed) > 0 {
+		res += "\n" + strings.Join(removed, ", ")
+	}
+	if len(failed) > 0 {
+		res += "\nFailed to remove " + strings.Join(failed, ", ")
+	}
+	return res, noParams
+}
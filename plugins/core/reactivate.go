@@ -0,0 +1,86 @@
+package core
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/bot"
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// NewReactivateCmd returns a reactivate command that makes alumni active
+// members again (this action can only be performed by admins)
+func NewReactivateCmd(ch cmd.CommandHandler) *cmd.Command {
+	return &cmd.Command{
+		Name:     "reactivate",
+		HelpText: "Make an alumni an active member again and add them back to the GitHub organization",
+		Options: map[string]*cmd.Option{
+			"user": &cmd.Option{
+				Key:      "user",
+				HelpText: "the Slack handle of the alumni to reactivate",
+				Type:     cmd.UserOption,
+				Required: true,
+			},
+		},
+		Args:       []string{"user"},
+		Permission: cmd.Roles(model.RoleAdmin),
+		HandleFunc: ch,
+	}
+}
+
+// reactivate makes an alumni an active member again.
+func (core *Plugin) reactivate(c cmd.Context) (string, slack.PostMessageParameters) {
+	noParams := slack.PostMessageParameters{}
+	username := c.Options["user"].Value
+	store := core.Bot.Store(c)
+	member := &model.Member{SlackID: c.Options["user"].UserID()}
+	if err := store.GetMemberBySlackID(member); err != nil {
+		log.WithError(err).Errorf("Failed to find member %s", username)
+		return "Failed to find member " + username, noParams
+	}
+	if !member.IsAlumni() {
+		return cmd.ToMention(member.SlackID) + " is already an active member", noParams
+	}
+
+	// Reactivate them, then add them back to our GitHub org by adding them
+	// to the `all` team, unless they're still on it
+	res := "Failed to reactivate member " + member.Name
+	err := core.Bot.Do(c, func(u *bot.UnitOfWork) error {
+		member.LeftAt = nil
+		if err := u.Store.SetMemberLeftAt(member); err != nil {
+			return err
+		}
+		if member.GithubUsername == "" {
+			return nil
+		}
+		onTeam, err := core.Bot.GitHub.IsTeamMember(c, member.GithubUsername, bot.GithubAllTeamID)
+		if err == nil && !onTeam {
+			err = core.Bot.GitHub.AddUserToTeam(c, member.GithubUsername, bot.GithubAllTeamID)
+		}
+		if err != nil {
+			res = fmt.Sprintf("Failed to add %s back to Launch Pad's GitHub organization",
+				member.Name)
+			return err
+		}
+		if onTeam {
+			return nil
+		}
+		u.OnUndo("github.add_team_member", member.SlackID, func(ctx context.Context) error {
+			return core.Bot.GitHub.RemoveUserFromTeam(ctx, member.GithubUsername, bot.GithubAllTeamID)
+		})
+		core.recordGitHub(u.Store, "github.add_team_member", member.SlackID, nil,
+			githubTeamMember(member.GithubUsername, &model.Team{
+				Name:         "all",
+				GithubTeamID: bot.GithubAllTeamID,
+			}))
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).Error(res)
+		return res, noParams
+	}
+	return cmd.ToMention(member.SlackID) + " is an active member again :tada:", noParams
+}
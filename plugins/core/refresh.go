@@ -11,9 +11,11 @@ import (
 // any users that don't already exist
 func NewRefreshCmd(ch cmd.CommandHandler) *cmd.Command {
 	return &cmd.Command{
-		Name:     "refresh",
-		HelpText: "for debugging Rocket (admins only)",
-		Options:  map[string]*cmd.Option{},
+		Name:       "refresh",
+		HelpText:   "for debugging Rocket",
+		Options:    map[string]*cmd.Option{},
+		Permission: cmd.Roles(model.RoleAdmin),
+		HandleFunc: ch,
 	}
 }
 
@@ -22,27 +24,24 @@ func NewRefreshCmd(ch cmd.CommandHandler) *cmd.Command {
 // already exist.
 func (core *Plugin) refresh(c cmd.Context) (string, slack.PostMessageParameters) {
 	noParams := slack.PostMessageParameters{}
-	if !c.User.IsAdmin {
-		return "You must be an admin to use this command", noParams
-	}
-
 	// Pull in all users from Slack
 	core.Bot.UpdateUsers()
 
+	store := core.Bot.Store(c)
 	var member model.Member
-	for _, user := range core.Bot.Users {
+	for _, user := range core.Bot.Users() {
 		member = model.Member{
 			SlackID:  user.ID,
 			ImageURL: user.Profile.Image192,
 		}
 
-		if err := core.Bot.DAL.CreateMember(&member); err != nil {
+		if err := store.CreateMember(&member); err != nil {
 			log.WithError(err).Error("Error creating member with Slack ID " + member.SlackID)
 			return "Error creating member with Slack ID " + member.SlackID, noParams
 		}
 
 		// Set Slack image URL
-		if err := core.Bot.DAL.SetMemberImageURL(&member); err != nil {
+		if err := store.SetMemberImageURL(&member); err != nil {
 			core.Bot.Log.WithError(err).Error("Error setting image for Slack ID " + member.SlackID)
 			return "Error setting image for Slack ID %s" + member.SlackID, noParams
 		}
@@ -0,0 +1,44 @@
+package core
+
+import (
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// NewRemoveProjectCmd returns a remove project command that deletes a team's
+// project (this action can only be performed by admins)
+func NewRemoveProjectCmd(ch cmd.CommandHandler) *cmd.Command {
+	return &cmd.Command{
+		Name:     "remove",
+		HelpText: "Delete the project a Launch Pad team is building",
+		Options: map[string]*cmd.Option{
+			"team": &cmd.Option{
+				Key:      "team",
+				HelpText: "the name of the team whose project to remove",
+				Type:     cmd.TeamOption,
+				Required: true,
+			},
+		},
+		Args:        []string{"team"},
+		Permission:  cmd.Roles(model.RoleAdmin),
+		Destructive: true,
+		Summary: func(c cmd.Context) string {
+			return "This will delete the project of the team `" + c.Options["team"].Value + "`"
+		},
+		HandleFunc: ch,
+	}
+}
+
+// removeProject deletes a team's project.
+func (core *Plugin) removeProject(c cmd.Context) (string, slack.PostMessageParameters) {
+	noParams := slack.PostMessageParameters{}
+	team := c.Options["team"].Team()
+	project := &model.Project{GithubTeamID: team.GithubTeamID}
+	if err := core.Bot.Store(c).DeleteProject(project); err != nil {
+		log.WithError(err).Errorf("Failed to delete project of team %s", team.Name)
+		return "Failed to delete project of team " + team.Name, noParams
+	}
+	return "`" + team.Name + "`'s project has been deleted :tada:", noParams
+}
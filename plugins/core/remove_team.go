@@ -3,6 +3,7 @@ package core
 import (
 	"github.com/nlopes/slack"
 	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/bot"
 	"github.com/ubclaunchpad/rocket/cmd"
 	"github.com/ubclaunchpad/rocket/model"
 )
@@ -10,16 +11,24 @@ import (
 // NewRemoveTeamCmd returns a remove team command that removes a new Launch Pad team
 func NewRemoveTeamCmd(ch cmd.CommandHandler) *cmd.Command {
 	return &cmd.Command{
-		Name:     "remove-team",
-		HelpText: "Delete a new Launch Pad team",
+		Name:     "remove",
+		Aliases:  []string{"remove-team"},
+		HelpText: "Delete a Launch Pad team",
 		Options: map[string]*cmd.Option{
 			"team": &cmd.Option{
 				Key:      "team",
 				HelpText: "the name of the team to remove",
-				Format:   cmd.AnyRegex,
+				Type:     cmd.TeamOption,
 				Required: true,
 			},
 		},
+		Args:        []string{"team"},
+		Permission:  cmd.Roles(model.RoleAdmin),
+		Destructive: true,
+		Summary: func(c cmd.Context) string {
+			return "This will delete the team `" + c.Options["team"].Value +
+				"` and its GitHub team"
+		},
 		HandleFunc: ch,
 	}
 }
@@ -27,28 +36,26 @@ func NewRemoveTeamCmd(ch cmd.CommandHandler) *cmd.Command {
 // removeTeam removes a Launch Pad team.
 func (core *Plugin) removeTeam(c cmd.Context) (string, slack.PostMessageParameters) {
 	noParams := slack.PostMessageParameters{}
-	if !c.User.IsAdmin {
-		return "You must be an admin to use this command", noParams
-	}
-
-	team := model.Team{
-		Name: c.Options["team"].Value,
-	}
-	if err := core.Bot.DAL.GetTeamByName(&team); err != nil {
-		log.WithError(err).Error("Failed to find team " + team.Name)
-		return "Failed to find team " + team.Name, noParams
-	}
-
-	// Remove team from GitHub
-	if err := core.Bot.GitHub.RemoveTeam(team.GithubTeamID); err != nil {
-		log.WithError(err).Error("Failed to remove GitHub team " + team.Name)
-		return "Failed to remove GitHub team " + team.Name, noParams
-	}
+	team := c.Options["team"].Team()
 
-	// Finally remove team from database
-	if err := core.Bot.DAL.DeleteTeamByName(&team); err != nil {
-		log.WithError(err).Error("Failed to delete team " + team.Name)
-		return "Failed to delete team " + team.Name, noParams
+	// Remove the team from the DB first, since deleting the GitHub team
+	// can't be undone
+	res := "Failed to delete team " + team.Name
+	err := core.Bot.Do(c, func(u *bot.UnitOfWork) error {
+		if err := u.Store.DeleteTeamByName(team); err != nil {
+			return err
+		}
+		if err := core.Bot.GitHub.RemoveTeam(c, team.GithubTeamID); err != nil {
+			res = "Failed to remove GitHub team " + team.Name
+			return err
+		}
+		core.recordGitHub(u.Store, "github.remove_team", team.Name,
+			map[string]interface{}{"githubTeamId": team.GithubTeamID}, nil)
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).Error(res)
+		return res, noParams
 	}
 	return "`" + team.Name + "` team has been deleted :tada:", noParams
 }
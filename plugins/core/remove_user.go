@@ -1,10 +1,12 @@
 package core
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/nlopes/slack"
 	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/bot"
 	"github.com/ubclaunchpad/rocket/cmd"
 	"github.com/ubclaunchpad/rocket/model"
 )
@@ -12,21 +14,25 @@ import (
 // NewRemoveUserCmd returns a remove user command that removes a user
 func NewRemoveUserCmd(ch cmd.CommandHandler) *cmd.Command {
 	return &cmd.Command{
-		Name:     "remove-user",
+		Name:     "remove-member",
+		Aliases:  []string{"remove-user"},
 		HelpText: "Remove a user from a team",
 		Options: map[string]*cmd.Option{
 			"user": &cmd.Option{
 				Key:      "user",
 				HelpText: "the Slack handle of the user to remove from a team",
-				Format:   cmd.AnyRegex,
+				Type:     cmd.UserOption,
+				Required: true,
 			},
 			"team": &cmd.Option{
 				Key:      "team",
 				HelpText: "the team to remove the user from",
-				Format:   cmd.AnyRegex,
+				Type:     cmd.TeamOption,
 				Required: true,
 			},
 		},
+		Args:       []string{"user", "team"},
+		Permission: cmd.AnyOf(cmd.Roles(model.RoleAdmin), cmd.TeamLead("team")),
 		HandleFunc: ch,
 	}
 }
@@ -34,48 +40,45 @@ func NewRemoveUserCmd(ch cmd.CommandHandler) *cmd.Command {
 // removeUser removes a user from a team.
 func (core *Plugin) removeUser(c cmd.Context) (string, slack.PostMessageParameters) {
 	noParams := slack.PostMessageParameters{}
-	if !c.User.IsAdmin {
-		return "You must be an admin to use this command", noParams
-	}
-
-	teamName := c.Options["team"].Value
+	team := c.Options["team"].Team()
 	username := c.Options["user"].Value
-	team := model.Team{
-		Name: teamName,
-	}
-	if err := core.Bot.DAL.GetTeamByName(&team); err != nil {
-		log.WithError(err).Error("Failed to get team " + team.Name)
-		return "Failed to get team " + team.Name, noParams
-	}
-
-	memberSlackID := cmd.ParseMention(username)
+	memberSlackID := c.Options["user"].UserID()
 	member := model.Member{
 		SlackID: memberSlackID,
 	}
-	if err := core.Bot.DAL.GetMemberBySlackID(&member); err != nil {
+	store := core.Bot.Store(c)
+	if err := store.GetMemberBySlackID(&member); err != nil {
 		log.WithError(err).Error("Failed to get member " + username)
 		return "Failed to get member " + username, noParams
 	}
 
-	// Remove user from GitHub team
-	if err := core.Bot.GitHub.RemoveUserFromTeam(member.GithubUsername, team.GithubTeamID); err != nil {
-		log.WithError(err).Errorf("Failed to remove member %s from GitHub team %s",
-			member.Name, team.Name)
-		msg := fmt.Sprintf("Failed to remove user %s from GitHub team %s. "+
-			"Make sure %s's GitHub ID (currently \"%s\") is correct.",
-			member.Name, team.Name, member.Name, member.GithubUsername)
-		return msg, noParams
-	}
-
 	teamMember := model.TeamMember{
 		MemberSlackID: memberSlackID,
 		GithubTeamID:  team.GithubTeamID,
 	}
-	// Remove user team relation from DB
-	if err := core.Bot.DAL.DeleteTeamMember(&teamMember); err != nil {
-		log.WithError(err).Error("Failed to remove member " +
-			member.Name + " from team " + team.Name)
-		return "Failed to remove member from team", noParams
+	// Remove the relation from the DB, then remove the user from the GitHub
+	// team, adding them back if the removal can't be saved
+	res := "Failed to remove member from team"
+	err := core.Bot.Do(c, func(u *bot.UnitOfWork) error {
+		if err := u.Store.DeleteTeamMember(&teamMember); err != nil {
+			return err
+		}
+		if err := core.Bot.GitHub.RemoveUserFromTeam(c, member.GithubUsername, team.GithubTeamID); err != nil {
+			res = fmt.Sprintf("Failed to remove user %s from GitHub team %s. "+
+				"Make sure %s's GitHub ID (currently \"%s\") is correct.",
+				member.Name, team.Name, member.Name, member.GithubUsername)
+			return err
+		}
+		u.OnUndo("github.remove_team_member", memberSlackID, func(ctx context.Context) error {
+			return core.Bot.GitHub.AddUserToTeam(ctx, member.GithubUsername, team.GithubTeamID)
+		})
+		core.recordGitHub(u.Store, "github.remove_team_member", memberSlackID,
+			githubTeamMember(member.GithubUsername, team), nil)
+		return nil
+	})
+	if err != nil {
+		log.WithError(err).Error(res)
+		return res, noParams
 	}
 	return cmd.ToMention(member.SlackID) +
 		" was removed from `" + team.Name + "` :tada:", noParams
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// NewRollTeamCmd returns a roll team command that runs an archived team in
+// the current term (this action can only be performed by admins)
+func NewRollTeamCmd(ch cmd.CommandHandler) *cmd.Command {
+	return &cmd.Command{
+		Name:     "roll",
+		HelpText: "Roll a team forward into the current term",
+		Options: map[string]*cmd.Option{
+			"team": &cmd.Option{
+				Key:      "team",
+				HelpText: "the name of the team to roll forward",
+				Type:     cmd.TeamOption,
+				Required: true,
+			},
+			"members": &cmd.Option{
+				Key:      "members",
+				HelpText: "whether the team keeps its members from the previous term (false by default)",
+				Type:     cmd.BoolOption,
+			},
+		},
+		Args:       []string{"team"},
+		Permission: cmd.Roles(model.RoleAdmin),
+		HandleFunc: ch,
+	}
+}
+
+// rollTeam runs an archived team in the current term.
+func (core *Plugin) rollTeam(c cmd.Context) (string, slack.PostMessageParameters) {
+	noParams := slack.PostMessageParameters{}
+	team := c.Options["team"].Team()
+	if !team.Archived {
+		return "`" + team.Name + "` is already running this term", noParams
+	}
+	if team.GithubTeamID < 0 {
+		return "`" + team.Name + "` was deleted on GitHub, so it can't run again", noParams
+	}
+
+	store := core.Bot.Store(c)
+	current := &model.Term{}
+	if err := store.GetCurrentTerm(current); err != nil {
+		log.WithError(err).Error("Failed to get current term")
+		return "Failed to get current term", noParams
+	}
+
+	// Find the team's members in the previous term
+	var previous *model.Team
+	code, err := previousTerm(store)
+	if err != nil {
+		log.WithError(err).Error("Failed to get previous term")
+		return "Failed to get previous term", noParams
+	}
+	if code != "" {
+		teams := model.Teams{}
+		if err := store.GetTeamsByTerm(code, &teams); err != nil {
+			log.WithError(err).Errorf("Failed to get teams in term %s", code)
+			return "Failed to get teams in term " + code, noParams
+		}
+		for _, t := range teams {
+			if t.GithubTeamID == team.GithubTeamID {
+				previous = t
+			}
+		}
+	}
+
+	if err := rollForward(store, team, previous, current.Code, c.Options["members"].Bool()); err != nil {
+		log.WithError(err).Errorf("Failed to roll team %s forward into term %s",
+			team.Name, current.Code)
+		return "Failed to roll `" + team.Name + "` forward", noParams
+	}
+	return "`" + team.Name + "` is running in `" + current.Code + "` :tada:", noParams
+}
@@ -0,0 +1,79 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// maxSearchResults is the most members and the most teams listed by `search`.
+const maxSearchResults = 10
+
+// NewSearchCmd returns a search command that finds members and teams
+func NewSearchCmd(ch cmd.CommandHandler) *cmd.Command {
+	return &cmd.Command{
+		Name:     "search",
+		HelpText: "Search Launch Pad members and teams",
+		Options: map[string]*cmd.Option{
+			"query": &cmd.Option{
+				Key:      "query",
+				HelpText: "what to search for, e.g. \"rocket bot\"",
+				Format:   cmd.AnyRegex,
+				Required: true,
+			},
+		},
+		Args:       []string{"query"},
+		HandleFunc: ch,
+	}
+}
+
+// search lists the members and teams that best match a query.
+func (core *Plugin) search(c cmd.Context) (string, slack.PostMessageParameters) {
+	noParams := slack.PostMessageParameters{}
+	query := c.Options["query"].Value
+	if len(model.SearchTerms(query)) == 0 {
+		return "Please search for some words, e.g. `search \"rocket bot\"`", noParams
+	}
+	results := &model.SearchResults{}
+	if err := core.Bot.DAL.Search(query, results); err != nil {
+		log.WithError(err).Errorf("Failed to search for %s", query)
+		return "Failed to search for `" + query + "`", noParams
+	}
+	if len(results.Members) == 0 && len(results.Teams) == 0 {
+		return "Nothing matches `" + query + "`", noParams
+	}
+
+	res := ""
+	if len(results.Members) > 0 {
+		res += "Members matching `" + query + "`:\n"
+		for i, member := range results.Members {
+			if i == maxSearchResults {
+				res += fmt.Sprintf("...and %d more\n", len(results.Members)-i)
+				break
+			}
+			res += member.Name + " (" + cmd.ToMention(member.SlackID) + ")"
+			if member.Position != "" {
+				res += " - " + member.Position
+			}
+			res += "\n"
+		}
+	}
+	if len(results.Teams) > 0 {
+		res += "Teams matching `" + query + "`:\n"
+		for i, team := range results.Teams {
+			if i == maxSearchResults {
+				res += fmt.Sprintf("...and %d more\n", len(results.Teams)-i)
+				break
+			}
+			res += team.Name
+			if team.Platform != "" {
+				res += " - " + team.Platform
+			}
+			res += "\n"
+		}
+	}
+	return res, noParams
+}
@@ -1,14 +1,19 @@
 package core
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/nlopes/slack"
 	log "github.com/sirupsen/logrus"
 	"github.com/ubclaunchpad/rocket/bot"
 	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/model"
 )
 
+// maxTags is the most skills or interests a member can have.
+const maxTags = 20
+
 // NewSetCmd returns a set command that sets user information
 func NewSetCmd(ch cmd.CommandHandler) *cmd.Command {
 	return &cmd.Command{
@@ -51,84 +56,148 @@ func NewSetCmd(ch cmd.CommandHandler) *cmd.Command {
 				Format:   cmd.AnyRegex,
 				Required: false,
 			},
+			"skills": &cmd.Option{
+				Key:      "skills",
+				HelpText: "things you can do, e.g. React,Go,UI design (replaces your current skills)",
+				Type:     cmd.ListOption,
+			},
+			"interests": &cmd.Option{
+				Key:      "interests",
+				HelpText: "things you want to work on, e.g. machine learning,mobile (replaces your current interests)",
+				Type:     cmd.ListOption,
+			},
 		},
 		HandleFunc: ch,
 	}
 }
 
 // Generic command for setting some information about the sender's profile.
+// Either every given property is set, or none of them are.
 func (core *Plugin) set(c cmd.Context) (string, slack.PostMessageParameters) {
 	params := slack.PostMessageParameters{}
 	githubChanged := false
+	res := "Failed to update your information"
 
-	if c.Options["name"].Value != "" {
-		c.User.Name = c.Options["name"].Value
-		if err := core.Bot.DAL.SetMemberName(&c.User); err != nil {
-			log.WithError(err).Errorf("Failed to set name: %s", c.User.Name)
-			return "Failed to set name " + c.User.Name, params
+	err := core.Bot.Do(c, func(u *bot.UnitOfWork) error {
+		store := u.Store
+		if c.Options["name"].Value != "" {
+			c.User.Name = c.Options["name"].Value
+			if err := store.SetMemberName(&c.User); err != nil {
+				res = "Failed to set name " + c.User.Name
+				return err
+			}
 		}
-	}
 
-	if c.Options["email"].Value != "" {
-		c.User.Email = c.Options["email"].Value
-		if err := core.Bot.DAL.SetMemberEmail(&c.User); err != nil {
-			log.WithError(err).Errorf("Failed to set email: %s", c.User.Email)
-			return "Failed to set email " + c.User.Email, params
+		if c.Options["email"].Value != "" {
+			c.User.Email = c.Options["email"].Value
+			if err := store.SetMemberEmail(&c.User); err != nil {
+				res = "Failed to set email " + c.User.Email
+				return err
+			}
 		}
-	}
 
-	if c.Options["github"].Value != "" {
-		c.User.GithubUsername = c.Options["github"].Value
-		// Check that the user exists
-		exists, err := core.Bot.GitHub.UserExists(c.User.GithubUsername)
-		if err != nil {
-			log.WithError(err).Errorf("Error checking whether user %s exists", c.User.GithubUsername)
-			return "Error checking whether user exists", params
-		} else if !exists {
-			return fmt.Sprintf("Github user %s does not exist", c.User.GithubUsername), params
+		if c.Options["major"].Value != "" {
+			c.User.Major = c.Options["major"].Value
+			if err := store.SetMemberMajor(&c.User); err != nil {
+				res = "Failed to set major"
+				return err
+			}
 		}
 
-		// Add the user to our GitHub org by adding to `all` team
-		if err := core.Bot.GitHub.AddUserToTeam(c.User.GithubUsername, bot.GithubAllTeamID); err != nil {
-			log.WithError(err).Errorf("Failed to add %s to Launch Pad Github organization",
-				c.User.GithubUsername)
-			return "Failed to add you to Launch Pad's GitHub organization", params
+		if c.Options["position"].Value != "" {
+			c.User.Position = c.Options["position"].Value
+			if err := store.SetMemberPosition(&c.User); err != nil {
+				res = "Failed to set position"
+				return err
+			}
 		}
 
-		// Finally, set their username in the DB
-		if err := core.Bot.DAL.SetMemberGitHubUsername(&c.User); err != nil {
-			log.WithError(err).Errorf("Failed to set GitHub username")
-			return "Failed to set GitHub username", params
+		if c.Options["biography"].Value != "" {
+			c.User.Biography = c.Options["biography"].Value
+			// Max bio length is 600 characters
+			if len(c.User.Biography) > 600 {
+				res = "Sorry, your biography must be at most 600 characters in length"
+				return errInvalid
+			}
+			if err := store.SetMemberBiography(&c.User); err != nil {
+				res = "Failed to set biography"
+				return err
+			}
 		}
-		githubChanged = true
-	}
 
-	if c.Options["major"].Value != "" {
-		c.User.Major = c.Options["major"].Value
-		if err := core.Bot.DAL.SetMemberMajor(&c.User); err != nil {
-			log.WithError(err).Error("Failed to set major")
-			return "Failed to set major", params
+		if c.Options["skills"].IsSet() {
+			c.User.Skills = model.NormalizeTags(c.Options["skills"].List())
+			if len(c.User.Skills) > maxTags {
+				res = fmt.Sprintf("Sorry, you can have at most %d skills", maxTags)
+				return errInvalid
+			}
+			if err := store.SetMemberSkills(&c.User); err != nil {
+				res = "Failed to set skills"
+				return err
+			}
 		}
-	}
 
-	if c.Options["position"].Value != "" {
-		c.User.Position = c.Options["position"].Value
-		if err := core.Bot.DAL.SetMemberPosition(&c.User); err != nil {
-			log.WithError(err).Error("Failed to set position")
-			return "Failed to set position", params
+		if c.Options["interests"].IsSet() {
+			c.User.Interests = model.NormalizeTags(c.Options["interests"].List())
+			if len(c.User.Interests) > maxTags {
+				res = fmt.Sprintf("Sorry, you can have at most %d interests", maxTags)
+				return errInvalid
+			}
+			if err := store.SetMemberInterests(&c.User); err != nil {
+				res = "Failed to set interests"
+				return err
+			}
 		}
-	}
 
-	if c.Options["biography"].Value != "" {
-		c.User.Biography = c.Options["biography"].Value
-		// Max bio length is 600 characters
-		if len(c.User.Biography) > 600 {
-			return "Sorry, your biography must be at most 600 characters in length", params
-		}
-		if err := core.Bot.DAL.SetMemberBiography(&c.User); err != nil {
-			log.WithError(err).Error("Failed to set biography")
-			return "Failed to set biography", params
+		// GitHub is changed last, so that nothing else can fail after it
+		if c.Options["github"].Value != "" {
+			c.User.GithubUsername = c.Options["github"].Value
+			// Check that the user exists
+			exists, err := core.Bot.GitHub.UserExists(c, c.User.GithubUsername)
+			if err != nil {
+				res = "Error checking whether user exists"
+				return err
+			} else if !exists {
+				res = fmt.Sprintf("Github user %s does not exist", c.User.GithubUsername)
+				return errInvalid
+			}
+
+			if err := store.SetMemberGitHubUsername(&c.User); err != nil {
+				res = "Failed to set GitHub username"
+				return err
+			}
+
+			// Add the user to our GitHub org by adding to `all` team,
+			// unless they're already on it
+			username := c.User.GithubUsername
+			onTeam, err := core.Bot.GitHub.IsTeamMember(c, username, bot.GithubAllTeamID)
+			if err == nil && !onTeam {
+				err = core.Bot.GitHub.AddUserToTeam(c, username, bot.GithubAllTeamID)
+			}
+			if err != nil {
+				res = "Failed to add you to Launch Pad's GitHub organization"
+				return err
+			}
+			if onTeam {
+				return nil
+			}
+			u.OnUndo("github.add_team_member", c.User.SlackID, func(ctx context.Context) error {
+				return core.Bot.GitHub.RemoveUserFromTeam(ctx, username, bot.GithubAllTeamID)
+			})
+			core.recordGitHub(store, "github.add_team_member", c.User.SlackID, nil,
+				githubTeamMember(username, &model.Team{
+					Name:         "all",
+					GithubTeamID: bot.GithubAllTeamID,
+				}))
+			githubChanged = true
 		}
+		return nil
+	})
+	if err == errInvalid {
+		return res, params
+	} else if err != nil {
+		log.WithError(err).Error(res)
+		return res, params
 	}
 
 	params.Attachments = c.User.SlackAttachments()
@@ -0,0 +1,75 @@
+package core
+
+import (
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/data"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// NewSetRoleCmd returns a set role command that sets the role a member has on
+// one of their teams
+func NewSetRoleCmd(ch cmd.CommandHandler) *cmd.Command {
+	return &cmd.Command{
+		Name:     "set-role",
+		HelpText: "Set the role a user has on a team",
+		Options: map[string]*cmd.Option{
+			"user": &cmd.Option{
+				Key:      "user",
+				HelpText: "the Slack handle of the user whose role to set",
+				Type:     cmd.UserOption,
+				Required: true,
+			},
+			"team": &cmd.Option{
+				Key:      "team",
+				HelpText: "the team the user is on",
+				Type:     cmd.TeamOption,
+				Required: true,
+			},
+			"role": &cmd.Option{
+				Key:      "role",
+				HelpText: "the user's new role on the team",
+				Type:     cmd.EnumOption,
+				Choices:  teamRoleChoices(),
+				Required: true,
+			},
+		},
+		Args:       []string{"user", "team", "role"},
+		Permission: cmd.AnyOf(cmd.Roles(model.RoleAdmin), cmd.TeamLead("team")),
+		HandleFunc: ch,
+	}
+}
+
+// setRole sets the role a member has on a team.
+func (core *Plugin) setRole(c cmd.Context) (string, slack.PostMessageParameters) {
+	noParams := slack.PostMessageParameters{}
+	team := c.Options["team"].Team()
+	slackID := c.Options["user"].UserID()
+	teamMember := model.TeamMember{
+		GithubTeamID:  team.GithubTeamID,
+		MemberSlackID: slackID,
+		Role:          model.TeamRole(c.Options["role"].Value),
+	}
+
+	err := core.Bot.Store(c).SetTeamMemberRole(&teamMember)
+	if err == data.ErrNotFound {
+		return cmd.ToMention(slackID) + " is not on `" + team.Name + "`", noParams
+	}
+	if err != nil {
+		log.WithError(err).Errorf("Failed to set role of member %s on team %s",
+			slackID, team.Name)
+		return "Failed to set role on team " + team.Name, noParams
+	}
+	return cmd.ToMention(slackID) + " is now a " + string(teamMember.Role) +
+		" on `" + team.Name + "` :tada:", noParams
+}
+
+// teamRoleChoices returns the names of the roles members can have on teams.
+func teamRoleChoices() []string {
+	choices := []string{}
+	for _, role := range model.TeamRoles {
+		choices = append(choices, string(role))
+	}
+	return choices
+}
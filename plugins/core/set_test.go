@@ -1,9 +1,11 @@
 package core
 
 import (
+	"strings"
 	"testing"
 
 	"github.com/stretchr/testify/assert"
+	"github.com/ubclaunchpad/rocket/model"
 )
 
 func TestSetCommand(t *testing.T) {
@@ -18,3 +20,19 @@ func TestBioFieldExists(t *testing.T) {
 	res := b.Commands["set"].Options["biography"].Key
 	assert.Equal(t, res, "biography")
 }
+
+func TestSetCommandIsAtomic(t *testing.T) {
+	b := getTestBot()
+	b.DAL.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno"})
+
+	// Properties set before an invalid one are discarded with it
+	ctx := getStoreTestContext(b, "U1", "@rocket set name={Big Bruno} "+
+		"biography={"+strings.Repeat("rocket ", 100)+"}")
+	res, _, err := b.Command("set").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "Sorry, your biography must be at most 600 characters in length", res)
+	member := &model.Member{SlackID: "U1"}
+	assert.Nil(t, b.DAL.GetMemberBySlackID(member))
+	assert.Equal(t, "Bruno", member.Name)
+	assert.Equal(t, "", member.Biography)
+}
@@ -0,0 +1,88 @@
+package core
+
+import (
+	"fmt"
+
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// NewStartTermCmd returns a start term command that starts a new term
+// (this action can only be performed by admins)
+func NewStartTermCmd(ch cmd.CommandHandler) *cmd.Command {
+	return &cmd.Command{
+		Name:     "start",
+		HelpText: "Start a new term, rolling the teams running now forward into it",
+		Options: map[string]*cmd.Option{
+			"term": &cmd.Option{
+				Key:      "term",
+				HelpText: "the code of the new term, e.g. 2026W for the 2026 winter session",
+				Format:   model.TermCodeRegex,
+				Required: true,
+			},
+			"roll": &cmd.Option{
+				Key:      "roll",
+				HelpText: "whether to roll the teams running now forward (true by default)",
+				Type:     cmd.BoolOption,
+			},
+			"members": &cmd.Option{
+				Key:      "members",
+				HelpText: "whether teams that are rolled forward keep their members (false by default)",
+				Type:     cmd.BoolOption,
+			},
+		},
+		Args:       []string{"term"},
+		Permission: cmd.Roles(model.RoleAdmin),
+		HandleFunc: ch,
+	}
+}
+
+// startTerm starts a new term.
+func (core *Plugin) startTerm(c cmd.Context) (string, slack.PostMessageParameters) {
+	noParams := slack.PostMessageParameters{}
+	code := c.Options["term"].Value
+	store := core.Bot.Store(c)
+
+	terms := model.Terms{}
+	if err := store.GetTerms(&terms); err != nil {
+		log.WithError(err).Error("Failed to get terms")
+		return "Failed to get terms", noParams
+	}
+	for _, term := range terms {
+		if term.Code == code {
+			return "`" + code + "` has already started", noParams
+		}
+	}
+
+	// Get the teams running now before the new term becomes current
+	teams := model.Teams{}
+	roll := !c.Options["roll"].IsSet() || c.Options["roll"].Bool()
+	if roll {
+		if err := store.GetTeams(&teams); err != nil {
+			log.WithError(err).Error("Failed to get teams")
+			return "Failed to get teams", noParams
+		}
+	}
+
+	if err := store.CreateTerm(&model.Term{Code: code}); err != nil {
+		log.WithError(err).Errorf("Failed to start term %s", code)
+		return "Failed to start term " + code, noParams
+	}
+	for _, team := range teams {
+		if err := rollForward(store, team, team, code, c.Options["members"].Bool()); err != nil {
+			log.WithError(err).Errorf("Failed to roll team %s forward into term %s",
+				team.Name, code)
+			return fmt.Sprintf("Started `%s`, but failed to roll `%s` forward into it",
+				code, team.Name), noParams
+		}
+	}
+
+	if len(teams) == 0 {
+		return "`" + code + "` has started :tada: Roll teams forward into it with " +
+			"`term roll`", noParams
+	}
+	return fmt.Sprintf("`%s` has started with %d teams :tada: Archive teams "+
+		"that aren't running this term with `term archive`", code, len(teams)), noParams
+}
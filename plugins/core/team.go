@@ -0,0 +1,15 @@
+package core
+
+import (
+	"github.com/ubclaunchpad/rocket/cmd"
+)
+
+// NewTeamCmd returns a team command that groups the subcommands used to
+// manage Launch Pad teams. Subcommands are added with cmd.AddSubcommands.
+func NewTeamCmd() *cmd.Command {
+	return &cmd.Command{
+		Name:     "team",
+		HelpText: "Manage Launch Pad teams and their members",
+		Options:  map[string]*cmd.Option{},
+	}
+}
@@ -10,7 +10,8 @@ import (
 // NewTeamsCmd returns a teams command that displays a list of Launch Pad teams
 func NewTeamsCmd(ch cmd.CommandHandler) *cmd.Command {
 	return &cmd.Command{
-		Name:       "teams",
+		Name:       "list",
+		Aliases:    []string{"teams"},
 		HelpText:   "List Launch Pad teams",
 		Options:    map[string]*cmd.Option{},
 		HandleFunc: ch,
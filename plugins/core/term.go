@@ -0,0 +1,58 @@
+package core
+
+import (
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/data"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// NewTermCmd returns a term command that groups the subcommands used to
+// manage the academic terms Launch Pad teams run in. Subcommands are added
+// with cmd.AddSubcommands.
+func NewTermCmd() *cmd.Command {
+	return &cmd.Command{
+		Name:     "term",
+		HelpText: "Manage the terms Launch Pad teams run in",
+		Options:  map[string]*cmd.Option{},
+	}
+}
+
+// rollForward runs the given team in the given term. If withMembers is true
+// and the team ran in the given previous term, its members in that term join
+// it with the same roles.
+func rollForward(store data.Store, team *model.Team, previous *model.Team, term string, withMembers bool) error {
+	if err := store.AddTeamToTerm(team, term); err != nil {
+		return err
+	}
+	if !withMembers || previous == nil {
+		return nil
+	}
+	for _, member := range previous.Members {
+		if member.IsAlumni() {
+			continue
+		}
+		err := store.CreateTeamMember(&model.TeamMember{
+			GithubTeamID:  team.GithubTeamID,
+			MemberSlackID: member.SlackID,
+			Term:          term,
+			Role:          member.TeamRole,
+		})
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// previousTerm returns the code of the term before the current term, or an
+// empty string if the current term is the first.
+func previousTerm(store data.Store) (string, error) {
+	terms := model.Terms{}
+	if err := store.GetTerms(&terms); err != nil {
+		return "", err
+	}
+	if len(terms) < 2 {
+		return "", nil
+	}
+	return terms[1].Code, nil
+}
@@ -0,0 +1,38 @@
+package core
+
+import (
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// NewTermsCmd returns a terms command that displays a list of terms
+func NewTermsCmd(ch cmd.CommandHandler) *cmd.Command {
+	return &cmd.Command{
+		Name:       "list",
+		Aliases:    []string{"terms"},
+		HelpText:   "List the terms Launch Pad teams have run in, newest first",
+		Options:    map[string]*cmd.Option{},
+		HandleFunc: ch,
+	}
+}
+
+// listTerms displays the terms Launch Pad teams have run in
+func (core *Plugin) listTerms(c cmd.Context) (string, slack.PostMessageParameters) {
+	noParams := slack.PostMessageParameters{}
+	terms := model.Terms{}
+	if err := core.Bot.DAL.GetTerms(&terms); err != nil {
+		log.WithError(err).Error("Failed to get terms")
+		return "Failed to get terms", noParams
+	}
+	codes := ""
+	for i, term := range terms {
+		codes += term.Code
+		if i == 0 {
+			codes += " (current)"
+		}
+		codes += "\n"
+	}
+	return codes, noParams
+}
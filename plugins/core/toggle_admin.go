@@ -14,15 +14,21 @@ import (
 func NewToggleAdminCmd(ch cmd.CommandHandler) *cmd.Command {
 	return &cmd.Command{
 		Name:     "toggle-admin",
-		HelpText: "Toggle an existing user's admin status (admins only)",
+		HelpText: "Toggle an existing user's admin status",
 		Options: map[string]*cmd.Option{
 			"user": &cmd.Option{
 				Key:      "user",
 				HelpText: "the Slack handle of the user to update",
-				Format:   cmd.AnyRegex,
+				Type:     cmd.UserOption,
 				Required: true,
 			},
 		},
+		Args:        []string{"user"},
+		Permission:  cmd.Roles(model.RoleAdmin),
+		Destructive: true,
+		Summary: func(c cmd.Context) string {
+			return "This will toggle " + c.Options["user"].Value + "'s admin status"
+		},
 		HandleFunc: ch,
 	}
 }
@@ -30,21 +36,18 @@ func NewToggleAdminCmd(ch cmd.CommandHandler) *cmd.Command {
 // toggleAdmin toggles an existing user's admin status
 func (core *Plugin) toggleAdmin(c cmd.Context) (string, slack.PostMessageParameters) {
 	noParams := slack.PostMessageParameters{}
-	if !c.User.IsAdmin {
-		return "You must be an admin to use this command", noParams
-	}
 	username := c.Options["user"].Value
-	member := &model.Member{SlackID: cmd.ParseMention(username)}
+	member := &model.Member{SlackID: c.Options["user"].UserID()}
 	err := core.Bot.DAL.GetMemberBySlackID(member)
 	if err != nil {
-		log.WithError(err).Error("Failed to get %s", username)
+		log.WithError(err).Errorf("Failed to get %s", username)
 		return "Failed to find user", noParams
 	}
 
 	// Update member admin status
 	member.IsAdmin = !member.IsAdmin
-	if err := core.Bot.DAL.SetMemberIsAdmin(member); err != nil {
-		log.WithError(err).Error("Failed to update %s's admin status", username)
+	if err := core.Bot.Store(c).SetMemberIsAdmin(member); err != nil {
+		log.WithError(err).Errorf("Failed to update %s's admin status", username)
 		return "Failed to update admin status", noParams
 	}
 	return fmt.Sprintf(
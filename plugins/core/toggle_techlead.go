@@ -14,15 +14,17 @@ import (
 func NewToggleTechLeadCmd(ch cmd.CommandHandler) *cmd.Command {
 	return &cmd.Command{
 		Name:     "toggle-tech-lead",
-		HelpText: "Toggle an existing user's tech lead status (admins only)",
+		HelpText: "Toggle an existing user's tech lead status",
 		Options: map[string]*cmd.Option{
 			"user": &cmd.Option{
 				Key:      "user",
 				HelpText: "the Slack handle of the user to update",
-				Format:   cmd.AnyRegex,
+				Type:     cmd.UserOption,
 				Required: true,
 			},
 		},
+		Args:       []string{"user"},
+		Permission: cmd.Roles(model.RoleAdmin),
 		HandleFunc: ch,
 	}
 }
@@ -30,21 +32,18 @@ func NewToggleTechLeadCmd(ch cmd.CommandHandler) *cmd.Command {
 // toggleTechLead toggles an existing user's tech lead status
 func (core *Plugin) toggleTechLead(c cmd.Context) (string, slack.PostMessageParameters) {
 	noParams := slack.PostMessageParameters{}
-	if !c.User.IsAdmin {
-		return "You must be an admin to use this command", noParams
-	}
 	username := c.Options["user"].Value
-	member := &model.Member{SlackID: cmd.ParseMention(username)}
+	member := &model.Member{SlackID: c.Options["user"].UserID()}
 	err := core.Bot.DAL.GetMemberBySlackID(member)
 	if err != nil {
-		log.WithError(err).Error("Failed to get %s", username)
+		log.WithError(err).Errorf("Failed to get %s", username)
 		return "Failed to find user", noParams
 	}
 
 	// Update tech lead status
 	member.IsTechLead = !member.IsTechLead
-	if err := core.Bot.DAL.SetMemberIsTechLead(member); err != nil {
-		log.WithError(err).Error("Failed to update %s's tech lead status", username)
+	if err := core.Bot.Store(c).SetMemberIsTechLead(member); err != nil {
+		log.WithError(err).Errorf("Failed to update %s's tech lead status", username)
 		return "Failed to update tech lead status", noParams
 	}
 	return fmt.Sprintf(
@@ -0,0 +1,46 @@
+package core
+
+import (
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/data"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// NewViewProjectCmd returns a view project command that displays information
+// about a team's project
+func NewViewProjectCmd(ch cmd.CommandHandler) *cmd.Command {
+	return &cmd.Command{
+		Name:     "view",
+		HelpText: "View information about the project a Launch Pad team is building",
+		Options: map[string]*cmd.Option{
+			"team": &cmd.Option{
+				Key:      "team",
+				HelpText: "the name of the team whose project to view",
+				Type:     cmd.TeamOption,
+				Required: true,
+			},
+		},
+		Args:       []string{"team"},
+		HandleFunc: ch,
+	}
+}
+
+// viewProject displays a team's project.
+func (core *Plugin) viewProject(c cmd.Context) (string, slack.PostMessageParameters) {
+	params := slack.PostMessageParameters{}
+	team := c.Options["team"].Team()
+	project := &model.Project{GithubTeamID: team.GithubTeamID}
+	err := core.Bot.DAL.GetProjectByTeam(project)
+	if err == data.ErrNotFound {
+		return "`" + team.Name + "` doesn't have a project yet. Add one with " +
+			"`project edit`", params
+	}
+	if err != nil {
+		log.WithError(err).Errorf("Failed to get project of team %s", team.Name)
+		return "Failed to get project of team " + team.Name, params
+	}
+	params.Attachments = project.SlackAttachments()
+	return "Project " + project.Name, params
+}
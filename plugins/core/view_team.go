@@ -4,51 +4,47 @@ import (
 	"fmt"
 
 	"github.com/nlopes/slack"
-	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/blocks"
+	"github.com/ubclaunchpad/rocket/bot"
 	"github.com/ubclaunchpad/rocket/cmd"
-	"github.com/ubclaunchpad/rocket/model"
 )
 
 // NewViewTeamCmd returns a view team command that displays information about a user
 func NewViewTeamCmd(ch cmd.CommandHandler) *cmd.Command {
 	return &cmd.Command{
-		Name:     "view-team",
+		Name:     "view",
+		Aliases:  []string{"view-team"},
 		HelpText: "View information about a Launch Pad team",
 		Options: map[string]*cmd.Option{
 			"team": &cmd.Option{
 				Key:      "team",
 				HelpText: "the name of the team to view",
-				Format:   cmd.AnyRegex,
+				Type:     cmd.TeamOption,
 				Required: true,
 			},
 		},
+		Args:       []string{"team"},
 		HandleFunc: ch,
 	}
 }
 
-// viewTeam displays a teams's information.
+// Actions offered by `team view`
+const (
+	joinTeamAction      = "team-join"
+	addTeamMemberAction = "team-add-member"
+	removeTeamAction    = "team-remove"
+)
+
+// viewTeam displays a teams's information along with buttons for joining,
+// adding members to and removing the team.
 func (core *Plugin) viewTeam(c cmd.Context) (string, slack.PostMessageParameters) {
 	params := slack.PostMessageParameters{}
-	team := model.Team{
-		Name: c.Options["team"].Value,
-	}
-
-	// Fetch team from DB
-	if err := core.Bot.DAL.GetTeamByName(&team); err != nil {
-		log.WithError(err).Error("Failed to get team " + team.Name)
-		return "Failed to get team " + team.Name, params
-	}
-
+	team := c.Options["team"].Team()
 	params.Attachments = team.SlackAttachments()
 
 	// Fetch GitHub team name since we don't store it in the DB
-	if ghTeam, err := core.Bot.GitHub.GetTeam(team.GithubTeamID); err == nil {
-		ghNameAttachment := slack.Attachment{
-			Text:  "GitHub Team Name: " + *ghTeam.Name,
-			Color: "good",
-		}
-		params.Attachments = append(params.Attachments, ghNameAttachment)
-	} else {
+	ghTeam, err := core.Bot.GitHub.GetTeam(c, team.GithubTeamID)
+	if err != nil {
 		msg := fmt.Sprintf("Failed to find GitHub team with ID %d", team.GithubTeamID)
 		core.Bot.Log.WithError(err).Error(msg)
 		return "Found team " + team.Name +
@@ -56,5 +52,45 @@ func (core *Plugin) viewTeam(c cmd.Context) (string, slack.PostMessageParameters
 			msg, params
 	}
 
-	return "Team " + team.Name, params
+	msg := blocks.NewMessage("Team "+team.Name, team.SlackBlocks()...).Add(
+		blocks.Context("GitHub Team Name: "+*ghTeam.Name),
+		blocks.Actions(team.Name,
+			blocks.Button(joinTeamAction, "Join", team.Name).WithStyle(blocks.Primary),
+			blocks.SelectUser(addTeamMemberAction, "Add member"),
+			blocks.Button(removeTeamAction, "Remove", team.Name).
+				WithStyle(blocks.Danger).
+				WithConfirm(blocks.Confirm("Remove team",
+					"Are you sure you want to delete `"+team.Name+"` and its GitHub team?",
+					"Remove")),
+		),
+	)
+	if err := core.Bot.PostBlocks(c, c.Message.Channel, msg); err != nil {
+		// Fall back to a plain message
+		core.Bot.Log.WithError(err).Error("Failed to post team " + team.Name)
+		params.Attachments = append(params.Attachments, slack.Attachment{
+			Text:  "GitHub Team Name: " + *ghTeam.Name,
+			Color: "good",
+		})
+		return "Team " + team.Name, params
+	}
+	return "", params
+}
+
+// handleJoinTeam adds the user who clicked the "Join" button of a team to
+// the team.
+func (core *Plugin) handleJoinTeam(c cmd.Context, a bot.Action) (string, slack.PostMessageParameters) {
+	return core.runAction(c, "team add-member "+cmd.ToMention(c.User.SlackID)+" "+cmd.Quote(a.Value))
+}
+
+// handleAddTeamMember adds the user chosen from the "Add member" menu of a
+// team to the team.
+func (core *Plugin) handleAddTeamMember(c cmd.Context, a bot.Action) (string, slack.PostMessageParameters) {
+	return core.runAction(c, "team add-member "+cmd.ToMention(a.Value)+" "+cmd.Quote(a.BlockID))
+}
+
+// handleRemoveTeam removes a team after its "Remove" button was clicked and
+// the removal was confirmed in the button's confirmation dialog.
+func (core *Plugin) handleRemoveTeam(c cmd.Context, a bot.Action) (string, slack.PostMessageParameters) {
+	c.Confirmed = true
+	return core.runAction(c, "team remove "+cmd.Quote(a.Value))
 }
@@ -16,10 +16,11 @@ func NewViewUserCmd(ch cmd.CommandHandler) *cmd.Command {
 			"user": &cmd.Option{
 				Key:      "user",
 				HelpText: "the slack handle of the user to view",
-				Format:   cmd.AnyRegex,
+				Type:     cmd.UserOption,
 				Required: true,
 			},
 		},
+		Args:       []string{"user"},
 		HandleFunc: ch,
 	}
 }
@@ -29,7 +30,7 @@ func (core *Plugin) viewUser(c cmd.Context) (string, slack.PostMessageParameters
 	params := slack.PostMessageParameters{}
 	username := c.Options["user"].Value
 	user := model.Member{
-		SlackID: cmd.ParseMention(username),
+		SlackID: c.Options["user"].UserID(),
 	}
 	if err := core.Bot.DAL.GetMemberBySlackID(&user); err != nil {
 		log.WithError(err).Error("Failed to get member " + username)
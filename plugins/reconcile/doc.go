@@ -0,0 +1,5 @@
+// Package reconcile contains the Reconcile plugin, which periodically checks
+// that the team memberships stored in Rocket agree with the memberships of
+// the GitHub teams and organization, reports where they don't to an admin
+// channel, and can fix them in a configured direction
+package reconcile
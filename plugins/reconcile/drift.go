@@ -0,0 +1,185 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/data"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// DriftKind is a way Rocket and GitHub can disagree about a membership.
+type DriftKind string
+
+const (
+	// DriftNotOnGitHubTeam is drift where a member is on a team in Rocket but
+	// not on its GitHub team
+	DriftNotOnGitHubTeam DriftKind = "not_on_github_team"
+	// DriftNotOnTeam is drift where a GitHub user is on a team's GitHub team
+	// but not on the team in Rocket
+	DriftNotOnTeam DriftKind = "not_on_team"
+	// DriftNotInOrg is drift where a member who isn't alumni has a GitHub
+	// username but isn't in the GitHub organization
+	DriftNotInOrg DriftKind = "not_in_org"
+	// DriftAlumniInOrg is drift where alumni are still in the GitHub
+	// organization
+	DriftAlumniInOrg DriftKind = "alumni_in_org"
+	// DriftUnknownInOrg is drift where a GitHub user is in the GitHub
+	// organization but no member has their username
+	DriftUnknownInOrg DriftKind = "unknown_in_org"
+)
+
+// Drift is a membership Rocket and GitHub disagree about.
+type Drift struct {
+	Kind           DriftKind
+	GithubUsername string
+	// Member is the member with the GitHub username, or nil if there is none
+	Member *model.Member
+	// Team is the team whose memberships disagree, or nil if the GitHub
+	// organization's do
+	Team *model.Team
+}
+
+// String describes the drift for reports posted to Slack.
+func (d Drift) String() string {
+	who := "`" + d.GithubUsername + "`"
+	if d.Member != nil {
+		who = cmd.ToMention(d.Member.SlackID) + " (" + who + ")"
+	}
+	switch d.Kind {
+	case DriftNotOnGitHubTeam:
+		return who + " is on `" + d.Team.Name + "` but not its GitHub team"
+	case DriftNotOnTeam:
+		return who + " is on the GitHub team of `" + d.Team.Name + "` but not the team"
+	case DriftNotInOrg:
+		return who + " isn't in the GitHub organization"
+	case DriftAlumniInOrg:
+		return who + " is alumni but still in the GitHub organization"
+	case DriftUnknownInOrg:
+		return who + " is in the GitHub organization but isn't a member"
+	}
+	return fmt.Sprintf("%s has drifted (%s)", who, d.Kind)
+}
+
+// Diff returns the memberships of the teams running in the current term and
+// of the members in the given store that disagree with GitHub, teams first.
+func Diff(ctx context.Context, gh GitHub, store data.Store) ([]Drift, error) {
+	var teams model.Teams
+	if err := store.GetTeams(&teams); err != nil {
+		return nil, err
+	}
+	var members model.Members
+	if err := store.GetAllMembers(&members); err != nil {
+		return nil, err
+	}
+	githubTeams := map[int][]string{}
+	for _, team := range teams {
+		usernames, err := gh.ListTeamMembers(ctx, team.GithubTeamID)
+		if err != nil {
+			return nil, fmt.Errorf("failed to list members of GitHub team %s: %s",
+				team.Name, err)
+		}
+		githubTeams[team.GithubTeamID] = usernames
+	}
+	org, err := gh.ListOrgMembers(ctx)
+	if err != nil {
+		return nil, fmt.Errorf("failed to list members of GitHub organization: %s", err)
+	}
+	return diff(teams, members, githubTeams, org), nil
+}
+
+// diff returns the memberships of the given teams and members that disagree
+// with the given GitHub team members, by GitHub team ID, and organization
+// members. GitHub usernames are compared case-insensitively, like GitHub
+// does.
+func diff(teams model.Teams, members model.Members, githubTeams map[int][]string, org []string) []Drift {
+	bySlackID := map[string]*model.Member{}
+	byUsername := map[string]*model.Member{}
+	for _, m := range members {
+		bySlackID[m.SlackID] = m
+		if m.GithubUsername != "" {
+			byUsername[strings.ToLower(m.GithubUsername)] = m
+		}
+	}
+
+	drifts := []Drift{}
+	for _, team := range teams {
+		// Alumni keep their team memberships in Rocket, but are removed from
+		// GitHub, so they aren't expected on GitHub teams
+		onTeam := map[string]*model.Member{}
+		for _, m := range team.Members {
+			if member := bySlackID[m.SlackID]; member != nil {
+				m = member
+			}
+			if m.GithubUsername != "" && !m.IsAlumni() {
+				onTeam[strings.ToLower(m.GithubUsername)] = m
+			}
+		}
+		onGitHubTeam := map[string]string{}
+		for _, username := range githubTeams[team.GithubTeamID] {
+			onGitHubTeam[strings.ToLower(username)] = username
+		}
+
+		teamDrifts := []Drift{}
+		for key, m := range onTeam {
+			if _, ok := onGitHubTeam[key]; !ok {
+				teamDrifts = append(teamDrifts, Drift{
+					Kind:           DriftNotOnGitHubTeam,
+					GithubUsername: m.GithubUsername,
+					Member:         m,
+					Team:           team,
+				})
+			}
+		}
+		for key, username := range onGitHubTeam {
+			if _, ok := onTeam[key]; !ok {
+				teamDrifts = append(teamDrifts, Drift{
+					Kind:           DriftNotOnTeam,
+					GithubUsername: username,
+					Member:         byUsername[key],
+					Team:           team,
+				})
+			}
+		}
+		drifts = append(drifts, sortDrifts(teamDrifts)...)
+	}
+
+	inOrg := map[string]bool{}
+	orgDrifts := []Drift{}
+	for _, username := range org {
+		key := strings.ToLower(username)
+		inOrg[key] = true
+		m := byUsername[key]
+		switch {
+		case m == nil:
+			orgDrifts = append(orgDrifts, Drift{Kind: DriftUnknownInOrg, GithubUsername: username})
+		case m.IsAlumni():
+			orgDrifts = append(orgDrifts, Drift{Kind: DriftAlumniInOrg, GithubUsername: username, Member: m})
+		}
+	}
+	for key, m := range byUsername {
+		if !inOrg[key] && !m.IsAlumni() {
+			orgDrifts = append(orgDrifts, Drift{
+				Kind:           DriftNotInOrg,
+				GithubUsername: m.GithubUsername,
+				Member:         m,
+			})
+		}
+	}
+	return append(drifts, sortDrifts(orgDrifts)...)
+}
+
+// sortDrifts sorts the given drifts by kind, then GitHub username, so that
+// reports are stable.
+func sortDrifts(drifts []Drift) []Drift {
+	sort.Slice(drifts, func(i, j int) bool {
+		if drifts[i].Kind != drifts[j].Kind {
+			return drifts[i].Kind < drifts[j].Kind
+		}
+		return strings.ToLower(drifts[i].GithubUsername) < strings.ToLower(drifts[j].GithubUsername)
+	})
+	return drifts
+}
@@ -0,0 +1,167 @@
+package reconcile
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/ubclaunchpad/rocket/bot"
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// Direction is the way drift is fixed in.
+type Direction string
+
+const (
+	// DirectionNone only reports drift. This is the default.
+	DirectionNone Direction = ""
+	// DirectionGitHub fixes drift by making GitHub match Rocket
+	DirectionGitHub Direction = "github"
+	// DirectionRocket fixes drift by making Rocket match GitHub
+	DirectionRocket Direction = "rocket"
+)
+
+// IsValid returns true if the direction is one drift can be fixed in.
+func (d Direction) IsValid() bool {
+	return d == DirectionNone || d == DirectionGitHub || d == DirectionRocket
+}
+
+// syncCommand is the command recorded in the audit log for the fixes Rocket
+// makes on its own
+const syncCommand = "github sync"
+
+// fixAction describes how the given drift is fixed in the given direction,
+// e.g. "add them to the GitHub team", or returns an empty string if it isn't
+// fixed in that direction. People on GitHub who aren't members are never
+// removed from the organization or its teams, since they may be owners,
+// maintainers or bots.
+func fixAction(d Drift, dir Direction) string {
+	switch dir {
+	case DirectionGitHub:
+		switch d.Kind {
+		case DriftNotOnGitHubTeam:
+			return "add them to the GitHub team"
+		case DriftNotOnTeam:
+			if d.Member != nil {
+				return "remove them from the GitHub team"
+			}
+		case DriftNotInOrg:
+			return "invite them to the GitHub organization"
+		case DriftAlumniInOrg:
+			return "remove them from the GitHub organization"
+		}
+	case DirectionRocket:
+		switch d.Kind {
+		case DriftNotOnGitHubTeam:
+			return "remove them from the team"
+		case DriftNotOnTeam:
+			if d.Member != nil && !d.Member.IsAlumni() {
+				return "add them to the team"
+			}
+		}
+	}
+	return ""
+}
+
+// fix fixes the given drift in the given direction on behalf of the user in
+// the given context. Drift that isn't fixed in the direction is left alone.
+func (p *Plugin) fix(c cmd.Context, d Drift, dir Direction) error {
+	if fixAction(d, dir) == "" {
+		return nil
+	}
+	store := p.Bot.Store(c)
+	switch {
+	case dir == DirectionGitHub && d.Kind == DriftNotOnGitHubTeam:
+		if err := p.GitHub.AddUserToTeam(c, d.GithubUsername, d.Team.GithubTeamID); err != nil {
+			return err
+		}
+		p.record(c, "github.add_team_member", d, nil, githubTeamMember(d))
+	case dir == DirectionGitHub && d.Kind == DriftNotOnTeam:
+		if err := p.GitHub.RemoveUserFromTeam(c, d.GithubUsername, d.Team.GithubTeamID); err != nil {
+			return err
+		}
+		p.record(c, "github.remove_team_member", d, githubTeamMember(d), nil)
+	case dir == DirectionGitHub && d.Kind == DriftNotInOrg:
+		// Adding them to the `all` team invites them to the org
+		if err := p.GitHub.AddUserToTeam(c, d.GithubUsername, bot.GithubAllTeamID); err != nil {
+			return err
+		}
+		p.record(c, "github.add_org_member", d, nil,
+			map[string]string{"githubUsername": d.GithubUsername})
+	case dir == DirectionGitHub && d.Kind == DriftAlumniInOrg:
+		if err := p.GitHub.RemoveUserFromOrg(c, d.GithubUsername); err != nil {
+			return err
+		}
+		p.record(c, "github.remove_org_member", d,
+			map[string]string{"githubUsername": d.GithubUsername}, nil)
+	case dir == DirectionRocket && d.Kind == DriftNotOnGitHubTeam:
+		return store.DeleteTeamMember(&model.TeamMember{
+			MemberSlackID: d.Member.SlackID,
+			GithubTeamID:  d.Team.GithubTeamID,
+		})
+	case dir == DirectionRocket && d.Kind == DriftNotOnTeam:
+		return store.CreateTeamMember(&model.TeamMember{
+			MemberSlackID: d.Member.SlackID,
+			GithubTeamID:  d.Team.GithubTeamID,
+		})
+	}
+	return nil
+}
+
+// record records a change made on GitHub to fix the given drift in the audit
+// log. The change has already been made, so failing to record it is only
+// logged.
+func (p *Plugin) record(c cmd.Context, action string, d Drift, before, after interface{}) {
+	target := d.GithubUsername
+	if d.Member != nil {
+		target = d.Member.SlackID
+	}
+	if err := p.Bot.Store(c).Record(action, target, before, after); err != nil {
+		p.Bot.Log.WithError(err).Error("Failed to record GitHub change")
+	}
+}
+
+// githubTeamMember describes the GitHub team membership of the given drift
+// for the audit log.
+func githubTeamMember(d Drift) map[string]interface{} {
+	return map[string]interface{}{
+		"githubUsername": d.GithubUsername,
+		"team":           d.Team.Name,
+		"githubTeamId":   d.Team.GithubTeamID,
+	}
+}
+
+// Reconcile fixes the given drift in the given direction on behalf of Rocket
+// and returns a report of the drift and what was done about it, for posting
+// to Slack.
+func (p *Plugin) Reconcile(ctx context.Context, drifts []Drift, dir Direction) string {
+	c := bot.SystemContext(ctx, syncCommand)
+	lines := make([]string, len(drifts))
+	for i, d := range drifts {
+		lines[i] = d.String()
+		action := fixAction(d, dir)
+		if action == "" {
+			continue
+		}
+		if err := p.fix(c, d, dir); err != nil {
+			p.Bot.Log.WithError(err).Errorf("Failed to fix drift: %s", d)
+			lines[i] += " (failed to " + action + ")"
+		} else {
+			lines[i] += " (fixed: " + action + ")"
+		}
+	}
+	return report(lines)
+}
+
+// report returns a report of drift with the given lines, for posting to
+// Slack.
+func report(lines []string) string {
+	if len(lines) == 0 {
+		return "Rocket and GitHub agree on every membership :tada:"
+	}
+	res := fmt.Sprintf("Rocket and GitHub disagree on %d memberships:\n", len(lines))
+	for _, line := range lines {
+		res += "• " + line + "\n"
+	}
+	return res
+}
@@ -0,0 +1,150 @@
+package reconcile
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/nlopes/slack"
+	"github.com/ubclaunchpad/rocket/bot"
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/config"
+)
+
+// reconcileTimeout is how long each periodic reconciliation has
+const reconcileTimeout = 10 * time.Minute
+
+// GitHub is the part of the GitHub API the plugin uses, which is implemented
+// by github.API.
+type GitHub interface {
+	ListTeamMembers(ctx context.Context, teamID int) ([]string, error)
+	ListOrgMembers(ctx context.Context) ([]string, error)
+	AddUserToTeam(ctx context.Context, username string, teamID int) error
+	RemoveUserFromTeam(ctx context.Context, username string, teamID int) error
+	RemoveUserFromOrg(ctx context.Context, username string) error
+}
+
+// Plugin reconciles the memberships stored in Rocket with GitHub's every
+// Interval, reporting drift to Channel and fixing it in Direction.
+type Plugin struct {
+	Bot    *bot.Bot
+	GitHub GitHub
+	// Interval is how often memberships are reconciled. They are only
+	// reconciled on request if it is 0.
+	Interval time.Duration
+	// Channel is the channel drift is reported to. It is only logged if it
+	// isn't set.
+	Channel   string
+	Direction Direction
+
+	cancel context.CancelFunc
+	done   sync.WaitGroup
+}
+
+// New returns a new instance of the ReconcilePlugin with the given bot,
+// configured by the given config.
+func New(b *bot.Bot, cfg *config.Config) *Plugin {
+	p := &Plugin{
+		Bot:       b,
+		Interval:  cfg.SyncInterval,
+		Channel:   cfg.SyncChannel,
+		Direction: Direction(cfg.SyncFix),
+	}
+	// Avoid storing a nil *github.API in the interface, which isn't nil
+	if b.GitHub != nil {
+		p.GitHub = b.GitHub
+	}
+	if !p.Direction.IsValid() {
+		b.Log.Errorf("Invalid ROCKET_SYNCFIX %q, drift will only be reported", cfg.SyncFix)
+		p.Direction = DirectionNone
+	}
+	return p
+}
+
+// Start starts reconciling memberships periodically, if an interval is set.
+func (p *Plugin) Start() error {
+	p.Bot.Log.Info("Running ReconcilePlugin")
+	if p.Interval <= 0 || p.GitHub == nil {
+		return nil
+	}
+	ctx, cancel := context.WithCancel(context.Background())
+	p.cancel = cancel
+	p.done.Add(1)
+	go func() {
+		defer p.done.Done()
+		ticker := time.NewTicker(p.Interval)
+		defer ticker.Stop()
+		for {
+			select {
+			case <-ctx.Done():
+				return
+			case <-ticker.C:
+				p.run(ctx)
+			}
+		}
+	}()
+	return nil
+}
+
+// Stop stops reconciling memberships, interrupting a reconciliation in
+// progress.
+func (p *Plugin) Stop(ctx context.Context) error {
+	if p.cancel == nil {
+		return nil
+	}
+	p.cancel()
+	stopped := make(chan struct{})
+	go func() {
+		p.done.Wait()
+		close(stopped)
+	}()
+	select {
+	case <-stopped:
+		return nil
+	case <-ctx.Done():
+		return ctx.Err()
+	}
+}
+
+// run reconciles memberships once, and reports drift if there is any.
+func (p *Plugin) run(ctx context.Context) {
+	ctx, cancel := context.WithTimeout(ctx, reconcileTimeout)
+	defer cancel()
+	drifts, err := Diff(ctx, p.GitHub, p.Bot.DAL)
+	if err != nil {
+		p.Bot.Log.WithError(err).Error("Failed to reconcile GitHub memberships")
+		return
+	}
+	if len(drifts) == 0 {
+		p.Bot.Log.Debug("Rocket and GitHub memberships agree")
+		return
+	}
+	res := p.Reconcile(ctx, drifts, p.Direction)
+	if p.Channel == "" {
+		p.Bot.Log.Warn(res)
+		return
+	}
+	if _, _, err := p.Bot.API.PostMessage(p.Channel, res, slack.PostMessageParameters{}); err != nil {
+		p.Bot.Log.WithError(err).Error("Failed to report GitHub drift")
+	}
+}
+
+// Commands returns a list of commands this plugin makes available to the Bot.
+func (p *Plugin) Commands() []*cmd.Command {
+	return []*cmd.Command{
+		NewSyncCmd().AddSubcommands(
+			NewDryRunCmd(p.dryRun),
+		),
+	}
+}
+
+// EventHandlers returns an empty map, because this plugin handles no events.
+func (p *Plugin) EventHandlers() map[string]bot.EventHandler {
+	return map[string]bot.EventHandler{}
+}
+
+// ActionHandlers returns an empty map, because this plugin has no
+// interactive messages.
+func (p *Plugin) ActionHandlers() map[string]bot.ActionHandler {
+	return map[string]bot.ActionHandler{}
+}
@@ -0,0 +1,218 @@
+package reconcile
+
+import (
+	"context"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/nlopes/slack"
+	"github.com/stretchr/testify/assert"
+	"github.com/ubclaunchpad/rocket/bot"
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/config"
+	"github.com/ubclaunchpad/rocket/data"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// fakeGitHub is a GitHub organization kept in memory.
+type fakeGitHub struct {
+	teams map[int][]string
+	org   []string
+	err   error
+}
+
+func (gh *fakeGitHub) ListTeamMembers(ctx context.Context, teamID int) ([]string, error) {
+	return gh.teams[teamID], gh.err
+}
+
+func (gh *fakeGitHub) ListOrgMembers(ctx context.Context) ([]string, error) {
+	return gh.org, gh.err
+}
+
+func (gh *fakeGitHub) AddUserToTeam(ctx context.Context, username string, teamID int) error {
+	if gh.err != nil {
+		return gh.err
+	}
+	gh.teams[teamID] = append(gh.teams[teamID], username)
+	return nil
+}
+
+func (gh *fakeGitHub) RemoveUserFromTeam(ctx context.Context, username string, teamID int) error {
+	if gh.err != nil {
+		return gh.err
+	}
+	gh.teams[teamID] = without(gh.teams[teamID], username)
+	return nil
+}
+
+func (gh *fakeGitHub) RemoveUserFromOrg(ctx context.Context, username string) error {
+	if gh.err != nil {
+		return gh.err
+	}
+	gh.org = without(gh.org, username)
+	return nil
+}
+
+func without(usernames []string, username string) []string {
+	res := []string{}
+	for _, u := range usernames {
+		if u != username {
+			res = append(res, u)
+		}
+	}
+	return res
+}
+
+// getTestPlugin returns a plugin whose bot has an admin, U1, and members on
+// a team, Rocket, that disagree with the given fake GitHub organization.
+func getTestPlugin(gh *fakeGitHub) (*bot.Bot, *Plugin) {
+	b := bot.NewEmptyBot()
+	p := New(b, &config.Config{})
+	p.GitHub = gh
+	if err := b.RegisterCommands(p.Commands()); err != nil {
+		panic(err)
+	}
+	leftAt := time.Now()
+	b.DAL.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno", GithubUsername: "bfbachmann", IsAdmin: true})
+	b.DAL.CreateMember(&model.Member{SlackID: "U2", Name: "Alice", GithubUsername: "alice"})
+	b.DAL.CreateMember(&model.Member{SlackID: "U3", Name: "Chad", GithubUsername: "chad"})
+	b.DAL.CreateMember(&model.Member{SlackID: "U4", Name: "Dana", GithubUsername: "dana", LeftAt: &leftAt})
+	b.DAL.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1})
+	b.DAL.CreateTeamMember(&model.TeamMember{MemberSlackID: "U1", GithubTeamID: 1})
+	b.DAL.CreateTeamMember(&model.TeamMember{MemberSlackID: "U2", GithubTeamID: 1})
+	b.DAL.CreateTeamMember(&model.TeamMember{MemberSlackID: "U4", GithubTeamID: 1})
+	return b, p
+}
+
+func TestDiff(t *testing.T) {
+	gh := &fakeGitHub{
+		teams: map[int][]string{1: {"BFBachmann", "chad", "ghost"}},
+		org:   []string{"bfbachmann", "alice", "dana", "octocat"},
+	}
+	b, _ := getTestPlugin(gh)
+
+	drifts, err := Diff(context.Background(), gh, b.DAL)
+	assert.Nil(t, err)
+	kinds := map[string]DriftKind{}
+	for _, d := range drifts {
+		kinds[d.GithubUsername] = d.Kind
+	}
+	// Usernames are compared case-insensitively, and alumni aren't expected
+	// on GitHub teams
+	assert.Equal(t, DriftNotOnGitHubTeam, kinds["alice"])
+	assert.Equal(t, DriftAlumniInOrg, kinds["dana"])
+	assert.Equal(t, DriftUnknownInOrg, kinds["octocat"])
+	assert.Equal(t, DriftNotOnTeam, kinds["ghost"])
+	assert.Len(t, drifts, 6)
+	assert.Equal(t, "<@U2> (`alice`) is on `Rocket` but not its GitHub team", drifts[0].String())
+	assert.Equal(t, "`ghost` is on the GitHub team of `Rocket` but not the team", drifts[2].String())
+
+	// Chad is on the GitHub team but not the Rocket team, and isn't in the
+	// org, which is reported as two drifts
+	assert.Equal(t, DriftNotOnTeam, drifts[1].Kind)
+	assert.Equal(t, "chad", drifts[1].GithubUsername)
+	assert.Equal(t, DriftNotInOrg, drifts[4].Kind)
+	assert.Equal(t, "chad", drifts[4].GithubUsername)
+
+	gh.err = errors.New("GitHub is down")
+	_, err = Diff(context.Background(), gh, b.DAL)
+	assert.NotNil(t, err)
+}
+
+func TestDryRunCommand(t *testing.T) {
+	gh := &fakeGitHub{
+		teams: map[int][]string{1: {"bfbachmann"}},
+		org:   []string{"bfbachmann"},
+	}
+	b, p := getTestPlugin(gh)
+	p.Direction = DirectionGitHub
+	ctx := cmd.Context{Message: &slack.Msg{Text: "@rocket sync dry-run"}}
+
+	// Only admins may look for drift
+	ctx.User = model.Member{SlackID: "U2"}
+	_, _, err := b.Command("sync").Execute(ctx)
+	assert.NotNil(t, err)
+
+	ctx.User = model.Member{SlackID: "U1", IsAdmin: true}
+	res, _, err := b.Command("sync").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "Rocket and GitHub disagree on 3 memberships:\n"+
+		"• <@U2> (`alice`) is on `Rocket` but not its GitHub team (would add them to the GitHub team)\n"+
+		"• <@U2> (`alice`) isn't in the GitHub organization (would invite them to the GitHub organization)\n"+
+		"• <@U3> (`chad`) isn't in the GitHub organization (would invite them to the GitHub organization)\n", res)
+	assert.Equal(t, []string{"bfbachmann"}, gh.teams[1], "dry runs don't change GitHub")
+
+	gh.teams[1] = []string{"bfbachmann", "alice"}
+	gh.org = []string{"bfbachmann", "alice", "chad"}
+	res, _, err = b.Command("sync").Execute(ctx)
+	assert.Nil(t, err)
+	assert.Equal(t, "Rocket and GitHub agree on every membership :tada:", res)
+}
+
+func TestReconcile(t *testing.T) {
+	newGitHub := func() *fakeGitHub {
+		return &fakeGitHub{
+			teams: map[int][]string{1: {"bfbachmann", "chad", "ghost"}, bot.GithubAllTeamID: {}},
+			org:   []string{"bfbachmann", "alice", "chad", "dana", "octocat"},
+		}
+	}
+
+	// Making GitHub match Rocket leaves people Rocket doesn't know about in
+	// the org and on teams
+	gh := newGitHub()
+	b, p := getTestPlugin(gh)
+	drifts, err := Diff(context.Background(), gh, b.DAL)
+	assert.Nil(t, err)
+	res := p.Reconcile(context.Background(), drifts, DirectionGitHub)
+	assert.True(t, strings.Contains(res, "(fixed: remove them from the GitHub organization)"), res)
+	assert.ElementsMatch(t, []string{"bfbachmann", "alice", "ghost"}, gh.teams[1])
+	assert.ElementsMatch(t, []string{"bfbachmann", "alice", "chad", "octocat"}, gh.org)
+	drifts, err = Diff(context.Background(), gh, b.DAL)
+	assert.Nil(t, err)
+	if assert.Len(t, drifts, 2) {
+		assert.Equal(t, DriftNotOnTeam, drifts[0].Kind)
+		assert.Equal(t, "ghost", drifts[0].GithubUsername)
+		assert.Equal(t, DriftUnknownInOrg, drifts[1].Kind)
+	}
+	var entries model.AuditEntries
+	assert.Nil(t, b.DAL.GetAuditEntries(data.AuditFilter{Target: "U4"}, &entries))
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "github.remove_org_member", entries[0].Action)
+		assert.Equal(t, syncCommand, entries[0].Command)
+		assert.Equal(t, "", entries[0].ActorSlackID)
+	}
+
+	// Making Rocket match GitHub only adds people who are members to teams
+	gh = newGitHub()
+	b, p = getTestPlugin(gh)
+	drifts, err = Diff(context.Background(), gh, b.DAL)
+	assert.Nil(t, err)
+	p.Reconcile(context.Background(), drifts, DirectionRocket)
+	team := &model.Team{Name: "Rocket"}
+	assert.Nil(t, b.DAL.GetTeamByName(team))
+	slackIDs := []string{}
+	for _, m := range team.Members {
+		slackIDs = append(slackIDs, m.SlackID)
+	}
+	assert.ElementsMatch(t, []string{"U1", "U3", "U4"}, slackIDs)
+
+	// Fixes that fail are reported
+	gh = newGitHub()
+	b, p = getTestPlugin(gh)
+	drifts, err = Diff(context.Background(), gh, b.DAL)
+	assert.Nil(t, err)
+	gh.err = errors.New("GitHub is down")
+	res = p.Reconcile(context.Background(), drifts, DirectionGitHub)
+	assert.True(t, strings.Contains(res, "(failed to add them to the GitHub team)"), res)
+}
+
+func TestStartStop(t *testing.T) {
+	gh := &fakeGitHub{teams: map[int][]string{}}
+	_, p := getTestPlugin(gh)
+	p.Interval = time.Millisecond
+	assert.Nil(t, p.Start())
+	time.Sleep(10 * time.Millisecond)
+	assert.Nil(t, p.Stop(context.Background()))
+}
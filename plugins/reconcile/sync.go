@@ -0,0 +1,53 @@
+package reconcile
+
+import (
+	"github.com/nlopes/slack"
+	log "github.com/sirupsen/logrus"
+	"github.com/ubclaunchpad/rocket/cmd"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+// NewSyncCmd returns a sync command that groups the subcommands used to
+// reconcile Rocket with GitHub. Subcommands are added with
+// cmd.AddSubcommands.
+func NewSyncCmd() *cmd.Command {
+	return &cmd.Command{
+		Name:     "sync",
+		HelpText: "Check that team memberships in Rocket agree with GitHub",
+		Options:  map[string]*cmd.Option{},
+	}
+}
+
+// NewDryRunCmd returns a dry-run command that reports drift between Rocket
+// and GitHub without fixing it (this action can only be performed by admins)
+func NewDryRunCmd(ch cmd.CommandHandler) *cmd.Command {
+	return &cmd.Command{
+		Name:       "dry-run",
+		HelpText:   "List memberships that Rocket and GitHub disagree on, and how they would be fixed",
+		Options:    map[string]*cmd.Option{},
+		Permission: cmd.Roles(model.RoleAdmin),
+		HandleFunc: ch,
+	}
+}
+
+// dryRun reports the memberships Rocket and GitHub disagree on and how the
+// periodic reconciliation would fix them, without changing anything.
+func (p *Plugin) dryRun(c cmd.Context) (string, slack.PostMessageParameters) {
+	noParams := slack.PostMessageParameters{}
+	if p.GitHub == nil {
+		return "GitHub isn't configured", noParams
+	}
+	drifts, err := Diff(c, p.GitHub, p.Bot.DAL)
+	if err != nil {
+		log.WithError(err).Error("Failed to compare memberships with GitHub")
+		return "Failed to compare memberships with GitHub", noParams
+	}
+	lines := make([]string, len(drifts))
+	for i, d := range drifts {
+		lines[i] = d.String()
+		if action := fixAction(d, p.Direction); action != "" {
+			lines[i] += " (would " + action + ")"
+		}
+	}
+	return report(lines), noParams
+}
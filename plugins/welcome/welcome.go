@@ -1,6 +1,7 @@
 package welcome
 
 import (
+	"context"
 	"fmt"
 
 	"github.com/nlopes/slack"
@@ -26,6 +27,11 @@ func (wp *Plugin) Start() error {
 	return nil
 }
 
+// Stop stops the welcome plugin.
+func (wp *Plugin) Stop(ctx context.Context) error {
+	return nil
+}
+
 // Commands returns an empty list of commands, because this plugin has no
 // commands.
 func (wp *Plugin) Commands() []*cmd.Command {
@@ -39,9 +45,15 @@ func (wp *Plugin) EventHandlers() map[string]bot.EventHandler {
 	}
 }
 
+// ActionHandlers returns an empty map, because this plugin has no
+// interactive messages.
+func (wp *Plugin) ActionHandlers() map[string]bot.ActionHandler {
+	return map[string]bot.ActionHandler{}
+}
+
 // handleTeamJoin welcomes a user to our Slack when they join be messaging
 // them in the general channel.
-func (wp *Plugin) handleTeamJoin(evt slack.RTMEvent) {
+func (wp *Plugin) handleTeamJoin(ctx context.Context, evt slack.RTMEvent) {
 	user := evt.Data.(*slack.TeamJoinEvent).User
 	userMention := cmd.ToMention(user.ID)
 
@@ -0,0 +1,10 @@
+-- Only memberships in the current term are kept
+DELETE FROM team_members
+WHERE term <> (SELECT code FROM terms ORDER BY started_at DESC LIMIT 1);
+
+ALTER TABLE team_members
+DROP COLUMN term,
+ADD PRIMARY KEY (team_github_team_id, member_slack_id);
+
+DROP TABLE team_terms;
+DROP TABLE terms;
@@ -0,0 +1,35 @@
+CREATE TABLE terms (
+    code TEXT PRIMARY KEY CHECK (code ~ '^[0-9]{4}[WS]$'),
+    started_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+-- Existing teams and memberships are in the term this migration is run in
+INSERT INTO terms (code)
+VALUES (CASE
+    WHEN EXTRACT(MONTH FROM now()) >= 9 THEN EXTRACT(YEAR FROM now())::INTEGER || 'W'
+    WHEN EXTRACT(MONTH FROM now()) >= 5 THEN EXTRACT(YEAR FROM now())::INTEGER || 'S'
+    ELSE (EXTRACT(YEAR FROM now())::INTEGER - 1) || 'W'
+END);
+
+CREATE TABLE team_terms (
+    team_github_team_id INTEGER REFERENCES teams(github_team_id) ON DELETE CASCADE,
+    term TEXT REFERENCES terms(code) ON DELETE CASCADE,
+    PRIMARY KEY (team_github_team_id, term)
+);
+
+INSERT INTO team_terms (team_github_team_id, term)
+SELECT teams.github_team_id, terms.code
+FROM teams, terms;
+
+ALTER TABLE team_members
+ADD COLUMN term TEXT;
+
+UPDATE team_members
+SET term = (SELECT code FROM terms);
+
+ALTER TABLE team_members
+ALTER COLUMN term SET NOT NULL,
+DROP CONSTRAINT team_members_pkey,
+ADD PRIMARY KEY (team_github_team_id, member_slack_id, term),
+ADD FOREIGN KEY (team_github_team_id, term)
+    REFERENCES team_terms(team_github_team_id, term) ON DELETE CASCADE;
@@ -0,0 +1,12 @@
+CREATE TABLE projects (
+    team_github_team_id INTEGER PRIMARY KEY REFERENCES teams(github_team_id) ON DELETE CASCADE,
+    name TEXT,
+    description TEXT,
+    repos TEXT[] NOT NULL DEFAULT '{}',
+    website TEXT,
+    status TEXT NOT NULL DEFAULT 'planning'
+    CHECK (status IN ('planning', 'active', 'launched', 'inactive')),
+    tech_stack TEXT[] NOT NULL DEFAULT '{}',
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now(),
+    updated_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
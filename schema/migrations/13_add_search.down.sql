@@ -0,0 +1,4 @@
+-- pg_trgm is left installed, since it may have been installed before
+DROP INDEX IF EXISTS teams_name_trgm_idx;
+DROP INDEX teams_search_idx;
+DROP INDEX members_search_idx;
@@ -0,0 +1,34 @@
+-- Indexes for full-text search of members and teams. The indexed expressions
+-- must match the ones data.DAL.Search queries with.
+--
+-- pg_trgm is only installed if the database user is allowed to, so that
+-- Rocket still starts without it. Team names are then only matched by
+-- full-text search, until a superuser runs CREATE EXTENSION pg_trgm.
+DO $$
+BEGIN
+    CREATE EXTENSION IF NOT EXISTS pg_trgm;
+EXCEPTION WHEN insufficient_privilege OR undefined_file THEN
+    RAISE WARNING 'pg_trgm could not be installed: %', SQLERRM;
+END
+$$;
+
+CREATE INDEX members_search_idx ON members USING GIN ((
+    setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce("position", '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(program, '')), 'B') ||
+    setweight(to_tsvector('english', coalesce(biography, '')), 'C')
+));
+
+CREATE INDEX teams_search_idx ON teams USING GIN ((
+    setweight(to_tsvector('english', coalesce(name, '')), 'A') ||
+    setweight(to_tsvector('english', coalesce(platform, '')), 'B')
+));
+
+-- Fuzzy matching of team names
+DO $$
+BEGIN
+    IF EXISTS (SELECT 1 FROM pg_extension WHERE extname = 'pg_trgm') THEN
+        CREATE INDEX teams_name_trgm_idx ON teams USING GIN (name gin_trgm_ops);
+    END IF;
+END
+$$;
@@ -0,0 +1,5 @@
+ALTER TABLE members
+DROP COLUMN is_admin;
+
+ALTER TABLE members
+DROP COLUMN position;
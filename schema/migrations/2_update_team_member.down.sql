@@ -0,0 +1,15 @@
+ALTER TABLE team_members
+DROP CONSTRAINT team_members_pkey;
+
+ALTER TABLE team_members
+DROP COLUMN member_slack_id;
+
+ALTER TABLE team_members
+ADD COLUMN member_email TEXT REFERENCES members(email) ON DELETE CASCADE;
+
+-- Existing memberships can't be recovered without emails, so this is
+-- destructive to team data
+DELETE FROM team_members;
+
+ALTER TABLE team_members
+ADD PRIMARY KEY (team_name, member_email);
@@ -0,0 +1,14 @@
+-- Destructive to team data
+DROP TABLE IF EXISTS team_members;
+DROP TABLE IF EXISTS teams;
+
+CREATE TABLE teams (
+    name TEXT PRIMARY KEY,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT (now() at time zone 'utc')
+);
+
+CREATE TABLE team_members (
+    team_name TEXT REFERENCES teams(name) ON DELETE CASCADE,
+    member_slack_id TEXT REFERENCES members(slack_id) ON DELETE CASCADE,
+    PRIMARY KEY (team_name, member_slack_id)
+);
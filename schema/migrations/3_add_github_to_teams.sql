@@ -1,4 +1,4 @@
-# Destructive to team data
+-- Destructive to team data
 DROP TABLE IF EXISTS team_members;
 DROP TABLE IF EXISTS teams;
 
@@ -0,0 +1,2 @@
+ALTER TABLE members
+DROP COLUMN is_tech_lead;
@@ -0,0 +1,2 @@
+ALTER TABLE team_members
+DROP COLUMN role;
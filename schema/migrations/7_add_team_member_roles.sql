@@ -0,0 +1,10 @@
+ALTER TABLE team_members
+ADD COLUMN role TEXT NOT NULL DEFAULT 'developer'
+CHECK (role IN ('lead', 'developer', 'designer', 'pm', 'mentor'));
+
+-- Tech leads lead every team they are on
+UPDATE team_members
+SET role = 'lead'
+FROM members
+WHERE members.slack_id = team_members.member_slack_id
+AND members.is_tech_lead;
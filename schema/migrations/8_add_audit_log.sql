@@ -0,0 +1,24 @@
+CREATE TABLE audit_log (
+    id SERIAL PRIMARY KEY,
+    actor_slack_id TEXT,
+    command TEXT,
+    action TEXT NOT NULL,
+    target TEXT,
+    before TEXT,
+    after TEXT,
+    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT now()
+);
+
+CREATE INDEX audit_log_actor_slack_id_idx ON audit_log (actor_slack_id);
+CREATE INDEX audit_log_target_idx ON audit_log (target);
+
+-- The audit log is append-only
+CREATE FUNCTION audit_log_append_only() RETURNS trigger AS $$
+BEGIN
+    RAISE EXCEPTION 'the audit log is append-only';
+END;
+$$ LANGUAGE plpgsql;
+
+CREATE TRIGGER audit_log_append_only
+BEFORE UPDATE OR DELETE ON audit_log
+FOR EACH ROW EXECUTE PROCEDURE audit_log_append_only();
@@ -0,0 +1,2 @@
+ALTER TABLE members
+ADD COLUMN left_at TIMESTAMP WITH TIME ZONE;
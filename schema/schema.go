@@ -0,0 +1,113 @@
+// Package schema provides the migrations that build Rocket's database schema.
+// Postgres migrations live in the migrations directory and SQLite migrations
+// live in the sqlite directory, and both are embedded in the Rocket binary.
+// Each migration is a pair of files named "<version>_<name>.sql", which
+// applies the migration, and "<version>_<name>.down.sql", which reverts it.
+// Versions start at 0 and increase by one with each migration.
+package schema
+
+import (
+	"embed"
+	"fmt"
+	"io/fs"
+	"path"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+//go:embed migrations/*.sql sqlite/*.sql
+var files embed.FS
+
+// Migration is a change to the database schema.
+type Migration struct {
+	Version int
+	Name    string
+	// Up is the SQL that applies the migration
+	Up string
+	// Down is the SQL that reverts the migration
+	Down string
+}
+
+// Migrations returns Rocket's Postgres migrations in the order they must be
+// applied, or an error if they are malformed.
+func Migrations() ([]Migration, error) {
+	return parse(files, "migrations")
+}
+
+// SQLiteMigrations returns Rocket's SQLite migrations in the order they must
+// be applied, or an error if they are malformed.
+func SQLiteMigrations() ([]Migration, error) {
+	return parse(files, "sqlite")
+}
+
+// Latest returns the version of the last Postgres migration, i.e. the version
+// of the schema this binary expects. Returns -1 if there are no migrations.
+func Latest() (int, error) {
+	migrations, err := Migrations()
+	if err != nil {
+		return 0, err
+	}
+	return len(migrations) - 1, nil
+}
+
+// parse reads the migrations in the given directory of the given filesystem.
+func parse(fsys fs.FS, dir string) ([]Migration, error) {
+	entries, err := fs.ReadDir(fsys, dir)
+	if err != nil {
+		return nil, err
+	}
+
+	byVersion := map[int]*Migration{}
+	for _, entry := range entries {
+		file := entry.Name()
+		if entry.IsDir() || path.Ext(file) != ".sql" {
+			continue
+		}
+		base := strings.TrimSuffix(file, ".sql")
+		down := strings.HasSuffix(base, ".down")
+		base = strings.TrimSuffix(base, ".down")
+
+		parts := strings.SplitN(base, "_", 2)
+		version, err := strconv.Atoi(parts[0])
+		if err != nil || len(parts) != 2 || parts[1] == "" {
+			return nil, fmt.Errorf("migration %s must be named <version>_<name>.sql", file)
+		}
+		data, err := fs.ReadFile(fsys, path.Join(dir, file))
+		if err != nil {
+			return nil, err
+		}
+
+		m, ok := byVersion[version]
+		if !ok {
+			m = &Migration{Version: version, Name: parts[1]}
+			byVersion[version] = m
+		} else if m.Name != parts[1] {
+			return nil, fmt.Errorf("migrations %s and %s have the same version",
+				m.Name, parts[1])
+		}
+		if down {
+			m.Down = string(data)
+		} else {
+			m.Up = string(data)
+		}
+	}
+
+	migrations := make([]Migration, 0, len(byVersion))
+	for _, m := range byVersion {
+		migrations = append(migrations, *m)
+	}
+	sort.Slice(migrations, func(i, j int) bool {
+		return migrations[i].Version < migrations[j].Version
+	})
+	for i, m := range migrations {
+		if m.Version != i {
+			return nil, fmt.Errorf("migration %d is missing", i)
+		}
+		if strings.TrimSpace(m.Up) == "" || strings.TrimSpace(m.Down) == "" {
+			return nil, fmt.Errorf("migration %d_%s must have both an up and a down file",
+				m.Version, m.Name)
+		}
+	}
+	return migrations, nil
+}
@@ -0,0 +1,71 @@
+package schema
+
+import (
+	"testing"
+	"testing/fstest"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMigrations(t *testing.T) {
+	migrations, err := Migrations()
+	assert.Nil(t, err)
+	assert.True(t, len(migrations) > 0)
+	assert.Equal(t, "init", migrations[0].Name)
+	for i, m := range migrations {
+		assert.Equal(t, i, m.Version)
+		assert.NotContains(t, m.Up, "\n#", m.Name)
+	}
+
+	latest, err := Latest()
+	assert.Nil(t, err)
+	assert.Equal(t, len(migrations)-1, latest)
+}
+
+func TestSQLiteMigrations(t *testing.T) {
+	migrations, err := SQLiteMigrations()
+	assert.Nil(t, err)
+	assert.True(t, len(migrations) > 0)
+	assert.Equal(t, "init", migrations[0].Name)
+}
+
+func TestParse(t *testing.T) {
+	fsys := fstest.MapFS{
+		"m/1_add_bio.sql":      {Data: []byte("ALTER TABLE members ADD bio TEXT;")},
+		"m/1_add_bio.down.sql": {Data: []byte("ALTER TABLE members DROP bio;")},
+		"m/0_init.sql":         {Data: []byte("CREATE TABLE members ();")},
+		"m/0_init.down.sql":    {Data: []byte("DROP TABLE members;")},
+		"m/README.md":          {Data: []byte("Not a migration")},
+	}
+	migrations, err := parse(fsys, "m")
+	assert.Nil(t, err)
+	assert.Equal(t, []Migration{
+		{0, "init", "CREATE TABLE members ();", "DROP TABLE members;"},
+		{1, "add_bio", "ALTER TABLE members ADD bio TEXT;", "ALTER TABLE members DROP bio;"},
+	}, migrations)
+}
+
+func TestParseErrors(t *testing.T) {
+	tests := map[string]fstest.MapFS{
+		"must be named": {
+			"m/init.sql": {Data: []byte("CREATE TABLE members ();")},
+		},
+		"migration 0 is missing": {
+			"m/1_add_bio.sql":      {Data: []byte("ALTER TABLE members ADD bio TEXT;")},
+			"m/1_add_bio.down.sql": {Data: []byte("ALTER TABLE members DROP bio;")},
+		},
+		"must have both an up and a down file": {
+			"m/0_init.sql": {Data: []byte("CREATE TABLE members ();")},
+		},
+		"have the same version": {
+			"m/0_init.sql":  {Data: []byte("CREATE TABLE members ();")},
+			"m/0_other.sql": {Data: []byte("CREATE TABLE teams ();")},
+		},
+	}
+	for expected, fsys := range tests {
+		_, err := parse(fsys, "m")
+		if assert.NotNil(t, err, expected) {
+			assert.Contains(t, err.Error(), expected)
+		}
+	}
+}
@@ -1,4 +1,5 @@
-DROP TABLE IF EXISTS members CASCADE;
+-- SQLite has no migration history of its own, so this creates the schema the
+-- Postgres migrations have built up to.
 CREATE TABLE members (
     slack_id TEXT PRIMARY KEY,
     name TEXT,
@@ -8,20 +9,18 @@ CREATE TABLE members (
     position TEXT,
     biography TEXT,
     image_url TEXT,
-    is_tech_lead BOOLEAN DEFAULT false,
-    is_admin BOOLEAN DEFAULT false,
-    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT (now() at time zone 'utc')
+    is_tech_lead BOOLEAN NOT NULL DEFAULT 0,
+    is_admin BOOLEAN NOT NULL DEFAULT 0,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 
-DROP TABLE IF EXISTS teams CASCADE;
 CREATE TABLE teams (
     name TEXT UNIQUE,
     github_team_id INTEGER PRIMARY KEY,
     platform TEXT,
-    created_at TIMESTAMP WITH TIME ZONE NOT NULL DEFAULT (now() at time zone 'utc')
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
 );
 
-DROP TABLE IF EXISTS team_members CASCADE;
 CREATE TABLE team_members (
     team_github_team_id INTEGER REFERENCES teams(github_team_id) ON DELETE CASCADE,
     member_slack_id TEXT REFERENCES members(slack_id) ON DELETE CASCADE,
@@ -0,0 +1,8 @@
+ALTER TABLE team_members
+ADD COLUMN role TEXT NOT NULL DEFAULT 'developer'
+CHECK (role IN ('lead', 'developer', 'designer', 'pm', 'mentor'));
+
+-- Tech leads lead every team they are on
+UPDATE team_members
+SET role = 'lead'
+WHERE member_slack_id IN (SELECT slack_id FROM members WHERE is_tech_lead);
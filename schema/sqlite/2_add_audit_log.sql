@@ -0,0 +1,24 @@
+CREATE TABLE audit_log (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    actor_slack_id TEXT,
+    command TEXT,
+    action TEXT NOT NULL,
+    target TEXT,
+    before TEXT,
+    after TEXT,
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX audit_log_actor_slack_id_idx ON audit_log (actor_slack_id);
+CREATE INDEX audit_log_target_idx ON audit_log (target);
+
+-- The audit log is append-only
+CREATE TRIGGER audit_log_no_update BEFORE UPDATE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'the audit log is append-only');
+END;
+
+CREATE TRIGGER audit_log_no_delete BEFORE DELETE ON audit_log
+BEGIN
+    SELECT RAISE(ABORT, 'the audit log is append-only');
+END;
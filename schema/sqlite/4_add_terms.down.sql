@@ -0,0 +1,19 @@
+-- Only memberships in the current term are kept
+CREATE TABLE old_team_members (
+    team_github_team_id INTEGER REFERENCES teams(github_team_id) ON DELETE CASCADE,
+    member_slack_id TEXT REFERENCES members(slack_id) ON DELETE CASCADE,
+    role TEXT NOT NULL DEFAULT 'developer'
+    CHECK (role IN ('lead', 'developer', 'designer', 'pm', 'mentor')),
+    PRIMARY KEY (team_github_team_id, member_slack_id)
+);
+
+INSERT INTO old_team_members (team_github_team_id, member_slack_id, role)
+SELECT team_github_team_id, member_slack_id, role
+FROM team_members
+WHERE term = (SELECT code FROM terms ORDER BY started_at DESC LIMIT 1);
+
+DROP TABLE team_members;
+ALTER TABLE old_team_members RENAME TO team_members;
+
+DROP TABLE team_terms;
+DROP TABLE terms;
@@ -0,0 +1,41 @@
+CREATE TABLE terms (
+    code TEXT PRIMARY KEY CHECK (code GLOB '[0-9][0-9][0-9][0-9][WS]'),
+    started_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
+
+-- Existing teams and memberships are in the term this migration is run in
+INSERT INTO terms (code)
+VALUES (CASE
+    WHEN CAST(strftime('%m', 'now') AS INTEGER) >= 9 THEN strftime('%Y', 'now') || 'W'
+    WHEN CAST(strftime('%m', 'now') AS INTEGER) >= 5 THEN strftime('%Y', 'now') || 'S'
+    ELSE (CAST(strftime('%Y', 'now') AS INTEGER) - 1) || 'W'
+END);
+
+CREATE TABLE team_terms (
+    team_github_team_id INTEGER REFERENCES teams(github_team_id) ON DELETE CASCADE,
+    term TEXT REFERENCES terms(code) ON DELETE CASCADE,
+    PRIMARY KEY (team_github_team_id, term)
+);
+
+INSERT INTO team_terms (team_github_team_id, term)
+SELECT teams.github_team_id, terms.code
+FROM teams, terms;
+
+-- SQLite can't change the primary key of a table, so team_members is rebuilt
+CREATE TABLE new_team_members (
+    team_github_team_id INTEGER REFERENCES teams(github_team_id) ON DELETE CASCADE,
+    member_slack_id TEXT REFERENCES members(slack_id) ON DELETE CASCADE,
+    term TEXT NOT NULL,
+    role TEXT NOT NULL DEFAULT 'developer'
+    CHECK (role IN ('lead', 'developer', 'designer', 'pm', 'mentor')),
+    PRIMARY KEY (team_github_team_id, member_slack_id, term),
+    FOREIGN KEY (team_github_team_id, term)
+        REFERENCES team_terms(team_github_team_id, term) ON DELETE CASCADE
+);
+
+INSERT INTO new_team_members (team_github_team_id, member_slack_id, term, role)
+SELECT team_github_team_id, member_slack_id, (SELECT code FROM terms), role
+FROM team_members;
+
+DROP TABLE team_members;
+ALTER TABLE new_team_members RENAME TO team_members;
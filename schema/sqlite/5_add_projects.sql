@@ -0,0 +1,13 @@
+-- SQLite has no arrays, so repos and tech_stack are JSON arrays
+CREATE TABLE projects (
+    team_github_team_id INTEGER PRIMARY KEY REFERENCES teams(github_team_id) ON DELETE CASCADE,
+    name TEXT,
+    description TEXT,
+    repos TEXT NOT NULL DEFAULT '[]',
+    website TEXT,
+    status TEXT NOT NULL DEFAULT 'planning'
+    CHECK (status IN ('planning', 'active', 'launched', 'inactive')),
+    tech_stack TEXT NOT NULL DEFAULT '[]',
+    created_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP NOT NULL DEFAULT CURRENT_TIMESTAMP
+);
@@ -0,0 +1,14 @@
+CREATE TABLE tags (
+    id INTEGER PRIMARY KEY,
+    kind TEXT NOT NULL CHECK (kind IN ('skill', 'interest')),
+    name TEXT NOT NULL,
+    UNIQUE (kind, name)
+);
+
+CREATE TABLE member_tags (
+    member_slack_id TEXT REFERENCES members(slack_id) ON DELETE CASCADE,
+    tag_id INTEGER REFERENCES tags(id) ON DELETE CASCADE,
+    PRIMARY KEY (member_slack_id, tag_id)
+);
+
+CREATE INDEX member_tags_tag_id_idx ON member_tags (tag_id);
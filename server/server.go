@@ -1,9 +1,17 @@
 package server
 
 import (
+	"context"
+	"crypto/subtle"
 	"crypto/tls"
 	"encoding/json"
+	"errors"
+	"fmt"
 	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
 
 	log "github.com/sirupsen/logrus"
 	"golang.org/x/crypto/acme/autocert"
@@ -24,22 +32,29 @@ const (
 	certDir = "/etc/ssl/certs"
 	// The location we are allowed to accept cross-origin requests from
 	allowedOrigin = "https://www.ubclaunchpad.com"
+	// The number of audit log entries returned if no limit is given
+	defaultAuditLimit = 100
 )
 
 // Server represents the HTTP server that provides a REST API interface to
 // Rocket's database.
 type Server struct {
-	router  *mux.Router
-	server  *http.Server
-	addr    string
-	dal     *data.DAL
-	api     *github.API
-	log     *log.Entry
-	manager *autocert.Manager
+	router *mux.Router
+	server *http.Server
+	// redirect serves ACME challenges and redirects other requests to HTTPS
+	redirect *http.Server
+	addr     string
+	// adminToken must be sent as a bearer token with requests to admin-only
+	// endpoints, which are disabled if it is empty
+	adminToken string
+	dal        data.Store
+	api        *github.API
+	log        *log.Entry
+	manager    *autocert.Manager
 }
 
 // New returns a new instance of the HTTP server based on a config.
-func New(c *config.Config, dal *data.DAL, gh *github.API, entry *log.Entry) *Server {
+func New(c *config.Config, dal data.Store, gh *github.API, entry *log.Entry) *Server {
 	router := mux.NewRouter()
 	addr := ":https"
 	m := &autocert.Manager{
@@ -57,13 +72,18 @@ func New(c *config.Config, dal *data.DAL, gh *github.API, entry *log.Entry) *Ser
 	}
 
 	s := &Server{
-		router:  router,
-		server:  server,
-		addr:    addr,
-		dal:     dal,
-		api:     gh,
-		log:     entry,
-		manager: m,
+		router: router,
+		server: server,
+		redirect: &http.Server{
+			Addr:    ":http",
+			Handler: m.HTTPHandler(nil),
+		},
+		addr:       addr,
+		adminToken: c.AdminToken,
+		dal:        dal,
+		api:        gh,
+		log:        entry,
+		manager:    m,
 	}
 
 	router.HandleFunc("/", s.RootHandler).Methods("GET")
@@ -71,21 +91,51 @@ func New(c *config.Config, dal *data.DAL, gh *github.API, entry *log.Entry) *Ser
 	api := router.PathPrefix("/api").Subrouter()
 	api.HandleFunc("/members", s.MemberHandler).Methods("GET")
 	api.HandleFunc("/teams", s.TeamHandler).Methods("GET")
+	api.HandleFunc("/projects", s.ProjectHandler).Methods("GET")
+	api.HandleFunc("/search", s.SearchHandler).Methods("GET")
 	api.HandleFunc("/stats", s.StatsHandler).Methods("GET")
+	api.Handle("/audit", s.adminOnly(http.HandlerFunc(s.AuditHandler))).Methods("GET")
 
 	return s
 }
 
+// Handle registers a handler for requests to the given path, e.g. to receive
+// requests from Slack. Handlers must be registered before the server starts.
+func (s *Server) Handle(path string, handler http.Handler) {
+	s.router.Handle(path, handler).Methods("POST")
+}
+
+// Start starts the server and returns once it has been shut down with
+// Shutdown. Fatal errors that occur in the server are logged.
 func (s *Server) Start() error {
 	s.log.Info("Starting API server on: ", s.addr)
-	go http.ListenAndServe(":http", s.manager.HTTPHandler(nil))
+	go func() {
+		err := s.redirect.ListenAndServe()
+		if err != nil && err != http.ErrServerClosed {
+			s.log.WithError(err).Fatal("A fatal error occurred in the HTTP redirect server")
+		}
+	}()
 	err := s.server.ListenAndServeTLS("", "")
+	if err == http.ErrServerClosed {
+		return nil
+	}
 	if err != nil {
 		s.log.WithError(err).Fatal("A fatal error occurred in the HTTP server")
 	}
 	return err
 }
 
+// Shutdown stops the server from accepting new connections and waits for
+// requests in progress to finish. Returns the context's error if the given
+// context is done first.
+func (s *Server) Shutdown(ctx context.Context) error {
+	redirectErr := s.redirect.Shutdown(ctx)
+	if err := s.server.Shutdown(ctx); err != nil {
+		return err
+	}
+	return redirectErr
+}
+
 func (s *Server) RootHandler(res http.ResponseWriter, req *http.Request) {
 	s.log.WithFields(log.Fields{
 		"method": req.Method,
@@ -105,6 +155,11 @@ func (s *Server) RootHandler(res http.ResponseWriter, req *http.Request) {
 	`))
 }
 
+// MemberHandler responds with all members who aren't alumni, or with all
+// members including alumni if the request has `?include=alumni`. Members can
+// be filtered by the tags on their profiles with `?skill=<name>`,
+// `?interest=<name>` and `?tag=<name>`, which matches either kind, e.g.
+// `?skill=react&interest=mobile`.
 func (s *Server) MemberHandler(res http.ResponseWriter, req *http.Request) {
 	s.log.WithFields(log.Fields{
 		"method": req.Method,
@@ -114,11 +169,16 @@ func (s *Server) MemberHandler(res http.ResponseWriter, req *http.Request) {
 	res.Header().Set("Content-Type", "application/json")
 	res.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
 	var members model.Members
-	if err := s.dal.GetMembers(&members); err != nil {
+	getMembers := s.dal.GetMembers
+	if req.URL.Query().Get("include") == "alumni" {
+		getMembers = s.dal.GetAllMembers
+	}
+	if err := getMembers(&members); err != nil {
 		s.log.WithError(err).Error("Failed to get members")
 		res.WriteHeader(http.StatusInternalServerError)
 		return
 	}
+	members = filterMembersByTags(members, req.URL.Query())
 
 	if err := json.NewEncoder(res).Encode(&members); err != nil {
 		s.log.WithError(err).Error("Failed to encode JSON")
@@ -128,16 +188,60 @@ func (s *Server) MemberHandler(res http.ResponseWriter, req *http.Request) {
 	res.WriteHeader(http.StatusOK)
 }
 
+// filterMembersByTags returns the given members who have every tag given by
+// the skill, interest and tag parameters of the given query.
+func filterMembersByTags(members model.Members, query url.Values) model.Members {
+	filtered := model.Members{}
+	for _, member := range members {
+		tags := append(append([]string{}, member.Skills...), member.Interests...)
+		if hasTags(member.Skills, query["skill"]) &&
+			hasTags(member.Interests, query["interest"]) &&
+			hasTags(tags, query["tag"]) {
+			filtered = append(filtered, member)
+		}
+	}
+	return filtered
+}
+
+// hasTags returns true if the given tags include every one of the wanted
+// tags after they are normalized.
+func hasTags(tags []string, wanted []string) bool {
+	have := map[string]bool{}
+	for _, tag := range tags {
+		have[tag] = true
+	}
+	for _, tag := range wanted {
+		if !have[model.NormalizeTag(tag)] {
+			return false
+		}
+	}
+	return true
+}
+
+// TeamHandler responds with the teams running in the current term, or with
+// the teams that ran in another term and their members in it if the request
+// has `?term=<code>`, e.g. `?term=2026W`.
 func (s *Server) TeamHandler(res http.ResponseWriter, req *http.Request) {
 	s.log.WithFields(log.Fields{
 		"method": req.Method,
 		"route":  "/api/teams",
 	}).Info("Received request")
 
-	res.Header().Set("Content-Type", "application/json")
 	res.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
 	var teams model.Teams
-	if err := s.dal.GetTeams(&teams); err != nil {
+	var err error
+	if term := req.URL.Query().Get("term"); term != "" {
+		if !model.TermCodeRegex.MatchString(term) {
+			http.Error(res, "invalid term: must be a year followed by W or S, e.g. 2026W",
+				http.StatusBadRequest)
+			return
+		}
+		err = s.dal.GetTeamsByTerm(term, &teams)
+	} else {
+		err = s.dal.GetTeams(&teams)
+	}
+	res.Header().Set("Content-Type", "application/json")
+	if err != nil {
 		s.log.WithError(err).Error("Failed to get teams")
 		res.WriteHeader(http.StatusInternalServerError)
 		return
@@ -151,6 +255,59 @@ func (s *Server) TeamHandler(res http.ResponseWriter, req *http.Request) {
 	res.WriteHeader(http.StatusOK)
 }
 
+// SearchHandler responds with the members and teams that match the query in
+// the request's `?q=` parameter, best match first, e.g. `?q=rocket+bot`.
+func (s *Server) SearchHandler(res http.ResponseWriter, req *http.Request) {
+	s.log.WithFields(log.Fields{
+		"method": req.Method,
+		"route":  "/api/search",
+	}).Info("Received request")
+
+	res.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
+	query := req.URL.Query().Get("q")
+	if len(model.SearchTerms(query)) == 0 {
+		http.Error(res, "missing query: search with ?q=<words>", http.StatusBadRequest)
+		return
+	}
+	res.Header().Set("Content-Type", "application/json")
+	results := &model.SearchResults{}
+	if err := s.dal.Search(query, results); err != nil {
+		s.log.WithError(err).Error("Failed to search")
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(res).Encode(results); err != nil {
+		s.log.WithError(err).Error("Failed to encode JSON")
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+	res.WriteHeader(http.StatusOK)
+}
+
+// ProjectHandler responds with the projects Launch Pad teams are building.
+func (s *Server) ProjectHandler(res http.ResponseWriter, req *http.Request) {
+	s.log.WithFields(log.Fields{
+		"method": req.Method,
+		"route":  "/api/projects",
+	}).Info("Received request")
+
+	res.Header().Set("Content-Type", "application/json")
+	res.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
+	var projects model.Projects
+	if err := s.dal.GetProjects(&projects); err != nil {
+		s.log.WithError(err).Error("Failed to get projects")
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	if err := json.NewEncoder(res).Encode(&projects); err != nil {
+		s.log.WithError(err).Error("Failed to encode JSON")
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
 func (s *Server) StatsHandler(res http.ResponseWriter, req *http.Request) {
 	s.log.WithFields(log.Fields{
 		"method": req.Method,
@@ -159,7 +316,7 @@ func (s *Server) StatsHandler(res http.ResponseWriter, req *http.Request) {
 
 	res.Header().Set("Content-Type", "application/json")
 	res.Header().Set("Access-Control-Allow-Origin", allowedOrigin)
-	stats, err := s.api.GetOrgStats()
+	stats, err := s.api.GetOrgStats(req.Context())
 	if err != nil {
 		s.log.WithError(err).Error("Failed to get stats")
 		res.WriteHeader(http.StatusInternalServerError)
@@ -173,3 +330,84 @@ func (s *Server) StatsHandler(res http.ResponseWriter, req *http.Request) {
 	}
 	res.WriteHeader(http.StatusOK)
 }
+
+// AuditHandler responds with the entries in the audit log that match the
+// request's query parameters, newest first. Entries can be filtered by
+// `actor` (a Slack ID), `action`, `target`, `since` and `until` (RFC 3339
+// times or YYYY-MM-DD dates), and `limit`.
+func (s *Server) AuditHandler(res http.ResponseWriter, req *http.Request) {
+	s.log.WithFields(log.Fields{
+		"method": req.Method,
+		"route":  "/api/audit",
+	}).Info("Received request")
+
+	filter, err := auditFilter(req.URL.Query())
+	if err != nil {
+		http.Error(res, err.Error(), http.StatusBadRequest)
+		return
+	}
+	var entries model.AuditEntries
+	if err := s.dal.GetAuditEntries(filter, &entries); err != nil {
+		s.log.WithError(err).Error("Failed to get audit log")
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+
+	res.Header().Set("Content-Type", "application/json")
+	if err := json.NewEncoder(res).Encode(&entries); err != nil {
+		s.log.WithError(err).Error("Failed to encode JSON")
+		res.WriteHeader(http.StatusInternalServerError)
+		return
+	}
+}
+
+// auditFilter returns the audit log filter described by the given query
+// parameters.
+func auditFilter(query url.Values) (data.AuditFilter, error) {
+	filter := data.AuditFilter{
+		ActorSlackID: query.Get("actor"),
+		Action:       query.Get("action"),
+		Target:       query.Get("target"),
+		Limit:        defaultAuditLimit,
+	}
+	var err error
+	if filter.Since, err = parseTime(query.Get("since")); err != nil {
+		return filter, fmt.Errorf("invalid since: %s", err)
+	}
+	if filter.Until, err = parseTime(query.Get("until")); err != nil {
+		return filter, fmt.Errorf("invalid until: %s", err)
+	}
+	if limit := query.Get("limit"); limit != "" {
+		if filter.Limit, err = strconv.Atoi(limit); err != nil || filter.Limit <= 0 {
+			return filter, errors.New("invalid limit: must be a positive number")
+		}
+	}
+	return filter, nil
+}
+
+// parseTime parses an RFC 3339 time or a YYYY-MM-DD date, which is taken to
+// be the start of the day in UTC. An empty string is the zero time.
+func parseTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse("2006-01-02", s); err == nil {
+		return t, nil
+	}
+	return time.Parse(time.RFC3339, s)
+}
+
+// adminOnly only lets requests with the admin token through to the given
+// handler.
+func (s *Server) adminOnly(handler http.Handler) http.Handler {
+	return http.HandlerFunc(func(res http.ResponseWriter, req *http.Request) {
+		auth := req.Header.Get("Authorization")
+		token := strings.TrimPrefix(auth, "Bearer ")
+		if s.adminToken == "" || token == auth ||
+			subtle.ConstantTimeCompare([]byte(token), []byte(s.adminToken)) != 1 {
+			res.WriteHeader(http.StatusUnauthorized)
+			return
+		}
+		handler.ServeHTTP(res, req)
+	})
+}
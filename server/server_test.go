@@ -0,0 +1,189 @@
+package server
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	log "github.com/sirupsen/logrus"
+	"github.com/stretchr/testify/assert"
+	"github.com/ubclaunchpad/rocket/config"
+	"github.com/ubclaunchpad/rocket/data"
+	"github.com/ubclaunchpad/rocket/model"
+)
+
+func getTestServer(adminToken string) (*Server, data.Store) {
+	store := data.NewMemoryStore()
+	cfg := &config.Config{AdminToken: adminToken}
+	return New(cfg, store, nil, log.NewEntry(log.New())), store
+}
+
+func TestAuditHandler(t *testing.T) {
+	s, store := getTestServer("secret")
+	store.CreateAuditEntry(&model.AuditEntry{ActorSlackID: "U1", Action: "team.create", Target: "Rocket"})
+	store.CreateAuditEntry(&model.AuditEntry{ActorSlackID: "U2", Action: "member.update", Target: "U2"})
+
+	req := httptest.NewRequest("GET", "/api/audit?actor=U1", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	res := httptest.NewRecorder()
+	s.router.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusOK, res.Code)
+	var entries model.AuditEntries
+	assert.Nil(t, json.NewDecoder(res.Body).Decode(&entries))
+	if assert.Len(t, entries, 1) {
+		assert.Equal(t, "team.create", entries[0].Action)
+	}
+
+	req = httptest.NewRequest("GET", "/api/audit?since=yesterday", nil)
+	req.Header.Set("Authorization", "Bearer secret")
+	res = httptest.NewRecorder()
+	s.router.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+}
+
+func TestAuditHandlerRequiresAdminToken(t *testing.T) {
+	s, _ := getTestServer("secret")
+	for _, auth := range []string{"", "Bearer wrong", "secret"} {
+		req := httptest.NewRequest("GET", "/api/audit", nil)
+		req.Header.Set("Authorization", auth)
+		res := httptest.NewRecorder()
+		s.router.ServeHTTP(res, req)
+		assert.Equal(t, http.StatusUnauthorized, res.Code, auth)
+	}
+
+	// The audit log can't be read at all without an admin token configured
+	s, _ = getTestServer("")
+	req := httptest.NewRequest("GET", "/api/audit", nil)
+	req.Header.Set("Authorization", "Bearer ")
+	res := httptest.NewRecorder()
+	s.router.ServeHTTP(res, req)
+	assert.Equal(t, http.StatusUnauthorized, res.Code)
+}
+
+func TestMemberHandlerAlumni(t *testing.T) {
+	s, store := getTestServer("")
+	leftAt := time.Date(2018, time.May, 1, 0, 0, 0, 0, time.UTC)
+	store.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno"})
+	store.CreateMember(&model.Member{SlackID: "U2", Name: "Alice", LeftAt: &leftAt})
+
+	for query, expected := range map[string]int{
+		"":                1,
+		"?include=alumni": 2,
+	} {
+		res := httptest.NewRecorder()
+		s.router.ServeHTTP(res, httptest.NewRequest("GET", "/api/members"+query, nil))
+		var members model.Members
+		assert.Nil(t, json.NewDecoder(res.Body).Decode(&members))
+		assert.Len(t, members, expected, query)
+	}
+}
+
+func TestMemberHandlerTags(t *testing.T) {
+	s, store := getTestServer("")
+	store.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno"})
+	store.CreateMember(&model.Member{SlackID: "U2", Name: "Alice"})
+	store.SetMemberSkills(&model.Member{SlackID: "U1", Skills: []string{"React", "Go"}})
+	store.SetMemberInterests(&model.Member{SlackID: "U1", Interests: []string{"Mobile"}})
+	store.SetMemberSkills(&model.Member{SlackID: "U2", Skills: []string{"React"}})
+
+	for query, expected := range map[string][]string{
+		"":                             []string{"Alice", "Bruno"},
+		"?skill=react":                 []string{"Alice", "Bruno"},
+		"?skill=REACT&skill=go":        []string{"Bruno"},
+		"?skill=react&interest=mobile": []string{"Bruno"},
+		"?interest=react":              []string{},
+		"?tag=mobile":                  []string{"Bruno"},
+		"?skill=machine%20learning":    []string{},
+		"?tag=react&include=alumni":    []string{"Alice", "Bruno"},
+	} {
+		res := httptest.NewRecorder()
+		s.router.ServeHTTP(res, httptest.NewRequest("GET", "/api/members"+query, nil))
+		var members model.Members
+		assert.Nil(t, json.NewDecoder(res.Body).Decode(&members))
+		names := []string{}
+		for _, member := range members {
+			names = append(names, member.Name)
+		}
+		assert.Equal(t, expected, names, query)
+	}
+}
+
+func TestSearchHandler(t *testing.T) {
+	s, store := getTestServer("")
+	store.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno", Position: "Rocket Mascot"})
+	store.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1})
+	store.CreateTeam(&model.Team{Name: "Buddy", GithubTeamID: 2})
+
+	res := httptest.NewRecorder()
+	s.router.ServeHTTP(res, httptest.NewRequest("GET", "/api/search?q=rockt", nil))
+	assert.Equal(t, http.StatusOK, res.Code)
+	var results model.SearchResults
+	assert.Nil(t, json.NewDecoder(res.Body).Decode(&results))
+	assert.Equal(t, "rockt", results.Query)
+	assert.Len(t, results.Members, 0)
+	if assert.Len(t, results.Teams, 1) {
+		assert.Equal(t, "Rocket", results.Teams[0].Name)
+	}
+
+	res = httptest.NewRecorder()
+	s.router.ServeHTTP(res, httptest.NewRequest("GET", "/api/search?q=rocket", nil))
+	assert.Nil(t, json.NewDecoder(res.Body).Decode(&results))
+	assert.Len(t, results.Members, 1)
+	assert.Len(t, results.Teams, 1)
+
+	res = httptest.NewRecorder()
+	s.router.ServeHTTP(res, httptest.NewRequest("GET", "/api/search?q=", nil))
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+}
+
+func TestTeamHandlerTerm(t *testing.T) {
+	s, store := getTestServer("")
+	store.CreateMember(&model.Member{SlackID: "U1", Name: "Bruno"})
+	store.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1})
+	store.CreateTeamMember(&model.TeamMember{GithubTeamID: 1, MemberSlackID: "U1"})
+	previous := model.TermAt(time.Now())
+	store.CreateTerm(&model.Term{Code: "2099W"})
+	store.CreateTeam(&model.Team{Name: "Buddy", GithubTeamID: 2})
+
+	for query, expected := range map[string]string{
+		"":                  "Buddy",
+		"?term=" + previous: "Rocket",
+		"?term=2099W":       "Buddy",
+	} {
+		res := httptest.NewRecorder()
+		s.router.ServeHTTP(res, httptest.NewRequest("GET", "/api/teams"+query, nil))
+		var teams model.Teams
+		assert.Nil(t, json.NewDecoder(res.Body).Decode(&teams))
+		if assert.Len(t, teams, 1, query) {
+			assert.Equal(t, expected, teams[0].Name, query)
+		}
+	}
+
+	res := httptest.NewRecorder()
+	s.router.ServeHTTP(res, httptest.NewRequest("GET", "/api/teams?term=2099", nil))
+	assert.Equal(t, http.StatusBadRequest, res.Code)
+}
+
+func TestProjectHandler(t *testing.T) {
+	s, store := getTestServer("")
+	store.CreateTeam(&model.Team{Name: "Rocket", GithubTeamID: 1})
+	store.SaveProject(&model.Project{
+		GithubTeamID: 1,
+		Name:         "Rocket",
+		Repos:        []string{"https://github.com/ubclaunchpad/rocket"},
+	})
+
+	res := httptest.NewRecorder()
+	s.router.ServeHTTP(res, httptest.NewRequest("GET", "/api/projects", nil))
+	assert.Equal(t, http.StatusOK, res.Code)
+	var projects []map[string]interface{}
+	assert.Nil(t, json.NewDecoder(res.Body).Decode(&projects))
+	if assert.Len(t, projects, 1) {
+		assert.Equal(t, "Rocket", projects[0]["team"])
+		assert.Equal(t, "planning", projects[0]["status"])
+		assert.Equal(t, []interface{}{"https://github.com/ubclaunchpad/rocket"}, projects[0]["repos"])
+		assert.Equal(t, []interface{}{}, projects[0]["techStack"])
+	}
+}